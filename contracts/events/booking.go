@@ -0,0 +1,109 @@
+package events
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Топики событий бронирования, в каждом - события одноимённого типа
+const (
+	TopicBookingCreated   = "booking.created"
+	TopicBookingCancelled = "booking.cancelled"
+	TopicBookingReminder  = "booking.reminder"
+)
+
+const (
+	TypeBookingCreated   = "booking.created"
+	TypeBookingCancelled = "booking.cancelled"
+	TypeBookingReminder  = "booking.reminder"
+)
+
+// CurrencyRUB - валюта сумм в событиях
+const CurrencyRUB = "RUB"
+
+// ErrInvalidPayload - payload не проходит проверку контракта
+var ErrInvalidPayload = errors.New("events: некорректные данные события")
+
+// MinorUnits переводит сумму в рублях в копейки
+func MinorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// BookingCreatedV1 - бронь создана.
+// Идентификаторы пользователей и броней - числовые ID из user-service и reservation-service
+type BookingCreatedV1 struct {
+	BookingID uint      `json:"booking_id"`
+	VenueID   uint      `json:"venue_id"`
+	UnitID    *uint     `json:"unit_id,omitempty"`
+	ClientID  uint      `json:"client_id"`
+	OwnerID   uint      `json:"owner_id"`
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	PartySize int       `json:"party_size"`
+	Status    string    `json:"status"`
+
+	AmountMinor int64  `json:"amount_minor"` // Стоимость в копейках
+	Currency    string `json:"currency"`
+
+	PrepaymentRequired bool `json:"prepayment_required,omitempty"`
+}
+
+func (BookingCreatedV1) EventType() string { return TypeBookingCreated }
+func (BookingCreatedV1) EventVersion() int { return 1 }
+
+func (e BookingCreatedV1) Validate() error {
+	if e.BookingID == 0 || e.VenueID == 0 || e.ClientID == 0 {
+		return ErrInvalidPayload
+	}
+	if e.AmountMinor < 0 || e.Currency == "" || !e.StartAt.Before(e.EndAt) {
+		return ErrInvalidPayload
+	}
+	return nil
+}
+
+// BookingCancelledV1 - бронь отменена.
+// Единица и время брони добавлены позже без смены версии, в старых событиях их нет
+type BookingCancelledV1 struct {
+	BookingID uint   `json:"booking_id"`
+	VenueID   uint   `json:"venue_id"`
+	ClientID  uint   `json:"client_id"`
+	Reason    string `json:"reason"`
+	Status    string `json:"status"`
+
+	UnitID  *uint     `json:"unit_id,omitempty"`
+	StartAt time.Time `json:"start_at,omitzero"`
+	EndAt   time.Time `json:"end_at,omitzero"`
+}
+
+func (BookingCancelledV1) EventType() string { return TypeBookingCancelled }
+func (BookingCancelledV1) EventVersion() int { return 1 }
+
+func (e BookingCancelledV1) Validate() error {
+	if e.BookingID == 0 {
+		return ErrInvalidPayload
+	}
+	return nil
+}
+
+// BookingReminderV1 - напоминание о предстоящей брони за OffsetMinutes до начала
+type BookingReminderV1 struct {
+	BookingID     uint      `json:"booking_id"`
+	VenueID       uint      `json:"venue_id"`
+	UnitID        *uint     `json:"unit_id,omitempty"`
+	ClientID      uint      `json:"client_id"`
+	OwnerID       uint      `json:"owner_id"`
+	StartAt       time.Time `json:"start_at"`
+	EndAt         time.Time `json:"end_at"`
+	OffsetMinutes int       `json:"offset_minutes"`
+}
+
+func (BookingReminderV1) EventType() string { return TypeBookingReminder }
+func (BookingReminderV1) EventVersion() int { return 1 }
+
+func (e BookingReminderV1) Validate() error {
+	if e.BookingID == 0 || e.ClientID == 0 || e.OffsetMinutes <= 0 {
+		return ErrInvalidPayload
+	}
+	return nil
+}
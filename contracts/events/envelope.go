@@ -0,0 +1,92 @@
+// Package events описывает версионированные контракты событий Kafka, общие для всех сервисов.
+// Каждое событие передаётся в конверте Envelope: тип и версия определяют схему payload.
+// Совместимые изменения (новые необязательные поля) не меняют версию,
+// несовместимые - добавляют новую структуру payload со следующей версией
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidEnvelope    = errors.New("events: некорректный конверт события")
+	ErrUnexpectedType     = errors.New("events: неожиданный тип события")
+	ErrUnsupportedVersion = errors.New("events: неподдерживаемая версия события")
+)
+
+// Event - payload события известного типа и версии
+type Event interface {
+	EventType() string
+	EventVersion() int
+	Validate() error
+}
+
+// Envelope - конверт события в Kafka
+type Envelope struct {
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	EventID    string          `json:"event_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Meta - данные конверта, которые задаёт отправитель.
+// EventID должен быть уникальным: по нему потребители отбрасывают повторы
+type Meta struct {
+	EventID    string
+	OccurredAt time.Time
+}
+
+// Encode проверяет payload и упаковывает его в конверт
+func Encode(meta Meta, payload Event) ([]byte, error) {
+	if meta.EventID == "" || meta.OccurredAt.IsZero() {
+		return nil, fmt.Errorf("%w: не заданы event_id или occurred_at", ErrInvalidEnvelope)
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(Envelope{
+		Type:       payload.EventType(),
+		Version:    payload.EventVersion(),
+		EventID:    meta.EventID,
+		OccurredAt: meta.OccurredAt.UTC(),
+		Payload:    raw,
+	})
+}
+
+// Decode разбирает конверт и payload ожидаемого типа T.
+// Событие другого типа или версии возвращает ErrUnexpectedType или ErrUnsupportedVersion
+func Decode[T Event](data []byte) (Envelope, T, error) {
+	var env Envelope
+	var payload T
+
+	if err := json.Unmarshal(data, &env); err != nil {
+		return env, payload, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	if env.Type == "" || env.Version == 0 || env.EventID == "" || len(env.Payload) == 0 {
+		return env, payload, ErrInvalidEnvelope
+	}
+	if env.Type != payload.EventType() {
+		return env, payload, fmt.Errorf("%w: %s", ErrUnexpectedType, env.Type)
+	}
+	if env.Version != payload.EventVersion() {
+		return env, payload, fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, env.Type, env.Version)
+	}
+
+	if err := json.Unmarshal(env.Payload, &payload); err != nil {
+		return env, payload, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	if err := payload.Validate(); err != nil {
+		return env, payload, err
+	}
+	return env, payload, nil
+}
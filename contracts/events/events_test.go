@@ -0,0 +1,174 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"contracts/events"
+	"contracts/events/fixtures"
+)
+
+func ptr(v uint) *uint { return &v }
+
+var (
+	startAt = time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC)
+	endAt   = time.Date(2026, 1, 25, 12, 0, 0, 0, time.UTC)
+)
+
+// assertJSONEqual сравнивает JSON без учёта форматирования и порядка полей
+func assertJSONEqual(t *testing.T, got, want []byte) {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("unmarshal got: %v", err)
+	}
+	if err := json.Unmarshal(want, &w); err != nil {
+		t.Fatalf("unmarshal want: %v", err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("JSON mismatch:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestEncodeMatchesFixtures(t *testing.T) {
+	cases := []struct {
+		fixture string
+		meta    events.Meta
+		payload events.Event
+	}{
+		{
+			fixture: "booking_created.v1.json",
+			meta:    events.Meta{EventID: "0b7e4f0e-2a57-4c1e-9a4a-3f1d2c9e8b10", OccurredAt: time.Date(2026, 1, 20, 9, 30, 0, 0, time.UTC)},
+			payload: events.BookingCreatedV1{
+				BookingID: 42, VenueID: 7, UnitID: ptr(3), ClientID: 15, OwnerID: 4,
+				StartAt: startAt, EndAt: endAt, PartySize: 1, Status: "confirmed",
+				AmountMinor: events.MinorUnits(3000.5), Currency: events.CurrencyRUB,
+			},
+		},
+		{
+			fixture: "booking_cancelled.v1.json",
+			meta:    events.Meta{EventID: "5c1d7a52-8f0b-4d7e-b4c2-1e9f6a3d2b44", OccurredAt: time.Date(2026, 1, 21, 18, 0, 0, 0, time.UTC)},
+			payload: events.BookingCancelledV1{
+				BookingID: 42, VenueID: 7, ClientID: 15, Reason: "Изменение планов", Status: "cancelled",
+				UnitID: ptr(3), StartAt: startAt, EndAt: endAt,
+			},
+		},
+		{
+			fixture: "saved_search_matched.v1.json",
+			meta:    events.Meta{EventID: "saved-search-9-booking-42", OccurredAt: time.Date(2026, 1, 21, 18, 0, 1, 0, time.UTC)},
+			payload: events.SavedSearchMatchedV1{
+				SearchID: 9, ClientID: 21, VenueID: 7, Reason: events.MatchReasonSlotFreed,
+				UnitID: ptr(3), StartAt: startAt, EndAt: endAt,
+			},
+		},
+		{
+			fixture: "booking_reminder.v1.json",
+			meta:    events.Meta{EventID: "booking-42-reminder-120-1769335200", OccurredAt: time.Date(2026, 1, 25, 8, 0, 0, 0, time.UTC)},
+			payload: events.BookingReminderV1{
+				BookingID: 42, VenueID: 7, UnitID: ptr(3), ClientID: 15, OwnerID: 4,
+				StartAt: startAt, EndAt: endAt, OffsetMinutes: 120,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			got, err := events.Encode(tc.meta, tc.payload)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			assertJSONEqual(t, got, fixtures.Load(tc.fixture))
+		})
+	}
+}
+
+func TestDecodeFixtures(t *testing.T) {
+	env, created, err := events.Decode[events.BookingCreatedV1](fixtures.Load("booking_created.v1.json"))
+	if err != nil {
+		t.Fatalf("Decode booking.created: %v", err)
+	}
+	if env.EventID == "" || created.BookingID != 42 || created.ClientID != 15 || created.AmountMinor != 300050 {
+		t.Fatalf("unexpected booking.created: %+v %+v", env, created)
+	}
+
+	if _, cancelled, err := events.Decode[events.BookingCancelledV1](fixtures.Load("booking_cancelled.v1.json")); err != nil || cancelled.BookingID != 42 {
+		t.Fatalf("Decode booking.cancelled: %+v, %v", cancelled, err)
+	}
+	if _, reminder, err := events.Decode[events.BookingReminderV1](fixtures.Load("booking_reminder.v1.json")); err != nil || reminder.OffsetMinutes != 120 {
+		t.Fatalf("Decode booking.reminder: %+v, %v", reminder, err)
+	}
+	if _, matched, err := events.Decode[events.SavedSearchMatchedV1](fixtures.Load("saved_search_matched.v1.json")); err != nil || matched.SearchID != 9 || !matched.StartAt.Equal(startAt) {
+		t.Fatalf("Decode saved_search.matched: %+v, %v", matched, err)
+	}
+}
+
+func TestSavedSearchMatchedValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		payload events.SavedSearchMatchedV1
+		valid   bool
+	}{
+		{"новая площадка", events.SavedSearchMatchedV1{SearchID: 9, ClientID: 21, VenueID: 7, Reason: events.MatchReasonVenuePublished}, true},
+		{"освободившееся время", events.SavedSearchMatchedV1{SearchID: 9, ClientID: 21, VenueID: 7, Reason: events.MatchReasonSlotFreed, StartAt: startAt, EndAt: endAt}, true},
+		{"освободившееся время без интервала", events.SavedSearchMatchedV1{SearchID: 9, ClientID: 21, VenueID: 7, Reason: events.MatchReasonSlotFreed}, false},
+		{"неизвестная причина", events.SavedSearchMatchedV1{SearchID: 9, ClientID: 21, VenueID: 7, Reason: "other"}, false},
+		{"без клиента", events.SavedSearchMatchedV1{SearchID: 9, VenueID: 7, Reason: events.MatchReasonVenuePublished}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.payload.Validate(); (err == nil) != tc.valid {
+				t.Fatalf("Validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsOtherTypeAndVersion(t *testing.T) {
+	if _, _, err := events.Decode[events.BookingCancelledV1](fixtures.Load("booking_created.v1.json")); !errors.Is(err, events.ErrUnexpectedType) {
+		t.Fatalf("expected ErrUnexpectedType, got %v", err)
+	}
+
+	var env map[string]any
+	if err := json.Unmarshal(fixtures.Load("booking_created.v1.json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	env["version"] = 2
+	data, _ := json.Marshal(env)
+	if _, _, err := events.Decode[events.BookingCreatedV1](data); !errors.Is(err, events.ErrUnsupportedVersion) {
+		t.Fatalf("expected ErrUnsupportedVersion, got %v", err)
+	}
+
+	// Старый формат без конверта
+	legacy := []byte(`{"booking_id":42,"client_id":15,"price_cents":3000.5}`)
+	if _, _, err := events.Decode[events.BookingCreatedV1](legacy); !errors.Is(err, events.ErrInvalidEnvelope) {
+		t.Fatalf("expected ErrInvalidEnvelope, got %v", err)
+	}
+}
+
+// Новые необязательные поля в той же версии не ломают старых получателей
+func TestDecodeIgnoresUnknownFields(t *testing.T) {
+	var env map[string]any
+	if err := json.Unmarshal(fixtures.Load("booking_created.v1.json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	env["payload"].(map[string]any)["promo_code"] = "SPRING"
+	env["trace_id"] = "abc"
+	data, _ := json.Marshal(env)
+
+	if _, created, err := events.Decode[events.BookingCreatedV1](data); err != nil || created.BookingID != 42 {
+		t.Fatalf("Decode with unknown fields: %+v, %v", created, err)
+	}
+}
+
+func TestEncodeValidatesPayload(t *testing.T) {
+	meta := events.Meta{EventID: "id", OccurredAt: time.Now()}
+	if _, err := events.Encode(meta, events.BookingCreatedV1{BookingID: 1}); !errors.Is(err, events.ErrInvalidPayload) {
+		t.Fatalf("expected ErrInvalidPayload, got %v", err)
+	}
+	if _, err := events.Encode(events.Meta{}, events.BookingCancelledV1{BookingID: 1}); !errors.Is(err, events.ErrInvalidEnvelope) {
+		t.Fatalf("expected ErrInvalidEnvelope, got %v", err)
+	}
+}
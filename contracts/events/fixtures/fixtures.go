@@ -0,0 +1,18 @@
+// Package fixtures содержит эталонные сообщения событий. Тесты отправителей сравнивают с ними
+// то, что публикуют, а тесты получателей - разбирают их, так что обе стороны проверяются
+// на одном и том же формате
+package fixtures
+
+import "embed"
+
+//go:embed *.json
+var files embed.FS
+
+// Load возвращает эталонное сообщение по имени файла, например "booking_created.v1.json"
+func Load(name string) []byte {
+	data, err := files.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
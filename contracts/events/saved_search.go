@@ -0,0 +1,46 @@
+package events
+
+import "time"
+
+// Топик событий сохранённых поисков
+const TopicSavedSearchMatched = "saved_search.matched"
+
+const TypeSavedSearchMatched = "saved_search.matched"
+
+// Причины, по которым сохранённый поиск дал результат
+const (
+	MatchReasonSlotFreed      = "slot_freed"      // На подходящей площадке освободилось время после отмены брони
+	MatchReasonVenuePublished = "venue_published" // Опубликована новая подходящая площадка
+)
+
+// SavedSearchMatchedV1 - площадка подошла под сохранённый поиск клиента.
+// Для slot_freed заполнены единица и интервал освободившейся брони
+type SavedSearchMatchedV1 struct {
+	SearchID uint   `json:"search_id"`
+	ClientID uint   `json:"client_id"`
+	VenueID  uint   `json:"venue_id"`
+	Reason   string `json:"reason"`
+
+	UnitID  *uint     `json:"unit_id,omitempty"`
+	StartAt time.Time `json:"start_at,omitzero"`
+	EndAt   time.Time `json:"end_at,omitzero"`
+}
+
+func (SavedSearchMatchedV1) EventType() string { return TypeSavedSearchMatched }
+func (SavedSearchMatchedV1) EventVersion() int { return 1 }
+
+func (e SavedSearchMatchedV1) Validate() error {
+	if e.SearchID == 0 || e.ClientID == 0 || e.VenueID == 0 {
+		return ErrInvalidPayload
+	}
+	switch e.Reason {
+	case MatchReasonVenuePublished:
+	case MatchReasonSlotFreed:
+		if !e.StartAt.Before(e.EndAt) {
+			return ErrInvalidPayload
+		}
+	default:
+		return ErrInvalidPayload
+	}
+	return nil
+}
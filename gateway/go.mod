@@ -5,7 +5,6 @@ go 1.25.5
 require (
 	github.com/gin-gonic/gin v1.11.0
 	github.com/golang-jwt/jwt/v4 v4.5.2
-	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
 )
 
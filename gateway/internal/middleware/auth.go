@@ -3,6 +3,7 @@ package middleware
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"gateway/internal/models"
@@ -13,6 +14,10 @@ import (
 
 func AuthUnless(jwtSecret string, isPublic func(*http.Request) bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Заголовки личности выставляет только gateway, присланные клиентом отбрасываются
+		c.Request.Header.Del("X-User-Id")
+		c.Request.Header.Del("X-User-Role")
+
 		if isPublic != nil && isPublic(c.Request) {
 			c.Next()
 			return
@@ -50,7 +55,7 @@ func AuthUnless(jwtSecret string, isPublic func(*http.Request) bool) gin.Handler
 
 		c.Set("claims", claims)
 		c.Set("userID", claims.UserID)
-		c.Request.Header.Set("X-User-Id", claims.UserID.String())
+		c.Request.Header.Set("X-User-Id", strconv.FormatUint(uint64(claims.UserID), 10))
 		c.Request.Header.Set("X-User-Role", claims.Role)
 		c.Next()
 	}
@@ -2,11 +2,12 @@ package models
 
 import (
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/google/uuid"
 )
 
+// Claims - JWT, выпущенный user-service. UserID - числовой ID пользователя,
+// единый идентификатор пользователя во всех сервисах
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Role   string    `json:"role"`
+	UserID uint   `json:"user_id"`
+	Role   string `json:"role"`
 	jwt.RegisteredClaims
 }
\ No newline at end of file
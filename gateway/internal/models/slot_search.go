@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// SearchVenue - площадка из списка venue-service, нужные для поиска поля
+type SearchVenue struct {
+	ID           uint                `json:"id"`
+	VenueType    string              `json:"venue_type"`
+	District     string              `json:"district"`
+	HourPrice    int                 `json:"hour_price"`
+	Capacity     int                 `json:"capacity"`
+	BookingRules *SearchBookingRules `json:"booking_rules,omitempty"`
+}
+
+// SearchVenueList - страница списка площадок venue-service
+type SearchVenueList struct {
+	Venues     []SearchVenue `json:"venues"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// SearchBookingRules - правила бронирования площадки, влияющие на подбор окна
+type SearchBookingRules struct {
+	MinDurationMinutes int `json:"min_duration_minutes"`
+	MaxDurationMinutes int `json:"max_duration_minutes"`
+	StartStepMinutes   int `json:"start_step_minutes"`
+}
+
+// AvailableSlot - свободный отрезок из reservation-service
+type AvailableSlot struct {
+	StartAt        time.Time `json:"start_at"`
+	EndAt          time.Time `json:"end_at"`
+	RemainingSpots int       `json:"remaining_spots,omitempty"`
+	UnitID         *uint     `json:"unit_id,omitempty"`
+}
+
+// SlotSearchResult - площадка с ближайшим к запрошенному времени свободным окном
+type SlotSearchResult struct {
+	VenueID        uint      `json:"venue_id"`
+	VenueType      string    `json:"venue_type"`
+	District       string    `json:"district"`
+	HourPrice      int       `json:"hour_price"`
+	StartAt        time.Time `json:"start_at"`
+	EndAt          time.Time `json:"end_at"`
+	UnitID         *uint     `json:"unit_id,omitempty"`
+	RemainingSpots int       `json:"remaining_spots,omitempty"`
+	OffsetMinutes  int       `json:"offset_minutes"` // Отклонение начала окна от запрошенного времени
+}
+
+type SlotSearchResponse struct {
+	Items      []SlotSearchResult `json:"items"`
+	Page       int                `json:"page"`
+	Limit      int                `json:"limit"`
+	HasMore    bool               `json:"has_more"`
+	NextCursor string             `json:"next_cursor,omitempty"` // Передаётся в cursor для следующей страницы
+}
@@ -40,7 +40,14 @@ func RegisterRoutes(r *gin.Engine, cfg Config) error {
 	api.Use(middleware.AuthUnless(cfg.JWTSecret, isPublicRequest))
 
 	api.Any("/auth/*path", gin.WrapH(http.HandlerFunc(userUpstream.ServeHTTP)))
-	api.Any("/users/*path", gin.WrapH(http.HandlerFunc(userUpstream.ServeHTTP)))
+	// Площадки пользователя отдает venue-service, остальные пути /users - user-service
+	api.Any("/users/*path", func(c *gin.Context) {
+		if strings.HasSuffix(c.Request.URL.Path, "/venues") {
+			venueUpstream.ServeHTTP(c.Writer, c.Request)
+			return
+		}
+		userUpstream.ServeHTTP(c.Writer, c.Request)
+	})
 
 	// Venue routes - сначала специфичные для reservation, потом общие
 	api.Any("/venues", gin.WrapH(http.HandlerFunc(venueUpstream.ServeHTTP)))
@@ -48,8 +55,8 @@ func RegisterRoutes(r *gin.Engine, cfg Config) error {
 	// Создаем специальный handler для venue маршрутов, который определяет upstream по пути
 	venueHandler := func(c *gin.Context) {
 		path := c.Request.URL.Path
-		// Если путь заканчивается на /availability или /bookings, используем reservation service
-		if strings.HasSuffix(path, "/availability") || strings.HasSuffix(path, "/bookings") {
+		// Если путь заканчивается на /availability, /bookings или /bookings/export, используем reservation service
+		if strings.HasSuffix(path, "/availability") || strings.HasSuffix(path, "/bookings") || strings.HasSuffix(path, "/bookings/export") {
 			reservationUpstream.ServeHTTP(c.Writer, c.Request)
 		} else {
 			// Иначе используем venue service
@@ -59,6 +66,13 @@ func RegisterRoutes(r *gin.Engine, cfg Config) error {
 	api.Any("/venues/*path", venueHandler)
 	api.Any("/venue-types", gin.WrapH(http.HandlerFunc(venueUpstream.ServeHTTP)))
 	api.Any("/venue-types/*path", gin.WrapH(http.HandlerFunc(venueUpstream.ServeHTTP)))
+	// Фотографии площадок из локального хранилища venue-service
+	api.GET("/media/*path", gin.WrapH(http.HandlerFunc(venueUpstream.ServeHTTP)))
+	// Очередь модерации площадок (только администратор)
+	api.Any("/moderation/*path", gin.WrapH(http.HandlerFunc(venueUpstream.ServeHTTP)))
+	// Сохранённые поиски площадок (только с токеном)
+	api.Any("/saved-searches", gin.WrapH(http.HandlerFunc(venueUpstream.ServeHTTP)))
+	api.Any("/saved-searches/*path", gin.WrapH(http.HandlerFunc(venueUpstream.ServeHTTP)))
 
 	// Bookings routes - используем handler для определения upstream
 	aggregator := NewAggregator(cfg)
@@ -82,9 +96,14 @@ func RegisterRoutes(r *gin.Engine, cfg Config) error {
 	}
 	api.Any("/bookings", gin.WrapH(http.HandlerFunc(reservationUpstream.ServeHTTP)))
 	api.Any("/bookings/*path", bookingsHandler)
+	api.Any("/reliability/*path", gin.WrapH(http.HandlerFunc(reservationUpstream.ServeHTTP)))
+
+	// Поиск свободных окон по всем площадкам (venue-service + reservation-service)
+	api.GET("/search/slots", aggregator.SearchSlots)
 
 	api.Any("/payments", gin.WrapH(http.HandlerFunc(paymentUpstream.ServeHTTP)))
 	api.Any("/payments/*path", gin.WrapH(http.HandlerFunc(paymentUpstream.ServeHTTP)))
+	api.Any("/refunds/*path", gin.WrapH(http.HandlerFunc(paymentUpstream.ServeHTTP)))
 
 	return nil
 }
@@ -101,12 +120,29 @@ func isPublicRequest(r *http.Request) bool {
 		return false
 	}
 
+	if path == "/api/search/slots" {
+		return true
+	}
+
 	if path == "/api/venue-types" || strings.HasPrefix(path, "/api/venue-types/") {
 		return true
 	}
 
+	if strings.HasPrefix(path, "/api/media/") {
+		return true
+	}
+
+	// Опубликованные площадки владельца видны всем, свои черновики владелец видит с токеном
+	if strings.HasPrefix(path, "/api/users/") && strings.HasSuffix(path, "/venues") {
+		return true
+	}
+
 	if path == "/api/venues" || strings.HasPrefix(path, "/api/venues/") {
-		if strings.HasSuffix(path, "/bookings") {
+		if strings.HasSuffix(path, "/bookings") || strings.HasSuffix(path, "/bookings/export") {
+			return false
+		}
+		// Избранное - личный список пользователя
+		if path == "/api/venues/favorites" {
 			return false
 		}
 		return true
@@ -0,0 +1,397 @@
+package transport
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gateway/internal/models"
+)
+
+const (
+	// Размер страницы, которой площадки читаются из venue-service
+	searchVenuePageSize = 100
+	// Сколько площадок проверяется за один проход (параллельно)
+	searchBatchSize = 16
+	// Максимум одновременных запросов доступности в reservation-service
+	searchConcurrency = 8
+)
+
+type slotSearchQuery struct {
+	VenueType    string `form:"venue_type"`
+	District     string `form:"district"`
+	MinPrice     int    `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice     int    `form:"max_price" binding:"omitempty,min=0"`
+	MinHourPrice int    `form:"min_hour_price" binding:"omitempty,min=0"` // Устаревший синоним min_price
+	MaxHourPrice int    `form:"max_hour_price" binding:"omitempty,min=0"` // Устаревший синоним max_price
+	Date         string `form:"date" binding:"required"`                     // YYYY-MM-DD
+	Time         string `form:"time"`                                        // HH:MM, желаемое время начала
+	Duration     int    `form:"duration" binding:"omitempty,min=1,max=1440"` // В минутах, по умолчанию 60
+	PartySize    int    `form:"party_size" binding:"omitempty,min=1"`
+	Page         int    `form:"page" binding:"omitempty,min=1"`
+	Limit        int    `form:"limit" binding:"omitempty,min=1,max=50"`
+	Cursor       string `form:"cursor"` // next_cursor предыдущей страницы, вместо page
+}
+
+// normalizePrice переносит устаревшие синонимы в min_price/max_price, которые имеют приоритет
+func (q *slotSearchQuery) normalizePrice() error {
+	if q.MinPrice == 0 {
+		q.MinPrice = q.MinHourPrice
+	}
+	if q.MaxPrice == 0 {
+		q.MaxPrice = q.MaxHourPrice
+	}
+	if q.MaxPrice > 0 && q.MinPrice > q.MaxPrice {
+		return fmt.Errorf("min_price не может быть больше max_price")
+	}
+	return nil
+}
+
+// slotSearchCursor - позиция продолжения поиска: цена группы площадок, с которой начинается
+// следующая страница, и сколько результатов этой цены уже выдано. Продолжение читает площадки
+// с min_price = Price, поэтому заново проверяется только одна ценовая группа, а не все предыдущие страницы
+type slotSearchCursor struct {
+	Price int `json:"p"`
+	Skip  int `json:"s"`
+}
+
+func (c slotSearchCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSlotSearchCursor(raw string) (slotSearchCursor, error) {
+	var c slotSearchCursor
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, err
+	}
+	if c.Price < 0 || c.Skip < 0 {
+		return c, fmt.Errorf("неверный курсор")
+	}
+	return c, nil
+}
+
+// SearchSlots ищет площадки со свободным окном нужной длительности на дату.
+// Результаты упорядочены по цене, затем по близости начала окна к запрошенному времени.
+// Площадки читаются из venue-service по возрастанию цены и проверяются пачками,
+// поэтому для страницы не нужно запрашивать доступность всех площадок. Следующая страница
+// продолжается с next_cursor и не повторяет запросы доступности предыдущих
+func (a *Aggregator) SearchSlots(c *gin.Context) {
+	var query slotSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.normalizePrice(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.Duration == 0 {
+		query.Duration = 60
+	}
+	if query.PartySize == 0 {
+		query.PartySize = 1
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	date, err := time.Parse("2006-01-02", query.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format, use YYYY-MM-DD"})
+		return
+	}
+	// Без времени ищем самое раннее окно дня
+	target := date
+	if query.Time != "" {
+		t, err := time.Parse("15:04", query.Time)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time format, use HH:MM"})
+			return
+		}
+		target = date.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+	}
+
+	// offset - сколько результатов от начала выборки пропустить: по курсору выборка
+	// начинается с его ценовой группы, по page - с самой дешёвой площадки
+	offset := (query.Page - 1) * query.Limit
+	minPrice := query.MinPrice
+	if query.Cursor != "" {
+		cur, err := decodeSlotSearchCursor(query.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+			return
+		}
+		offset = cur.Skip
+		minPrice = max(minPrice, cur.Price)
+	}
+
+	// Одна лишняя запись нужна, чтобы определить has_more
+	need := offset + query.Limit + 1
+
+	var results []models.SlotSearchResult
+	lastPrice := -1
+	done := false
+	// Площадки читаем по курсору venue-service: добавленные во время поиска не сдвигают страницы
+	venueCursor := ""
+	for !done {
+		body, status, err := a.fetchJSON(c, a.searchVenuesURL(query, minPrice, venueCursor))
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": "venue service unavailable"})
+			return
+		}
+		if status != http.StatusOK {
+			c.Data(status, "application/json", body)
+			return
+		}
+
+		var list models.SearchVenueList
+		if err := json.Unmarshal(body, &list); err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": "invalid venue response"})
+			return
+		}
+		venues := list.Venues
+
+		for start := 0; start < len(venues); {
+			// Площадки дальше по списку дороже уже найденных, они не попадут на запрошенную страницу
+			if len(results) >= need && venues[start].HourPrice > lastPrice {
+				done = true
+				break
+			}
+
+			// Пачку не разрываем внутри одной цены, иначе порядок по близости времени будет неполным
+			end := start + searchBatchSize
+			if end > len(venues) {
+				end = len(venues)
+			}
+			for end < len(venues) && venues[end].HourPrice == venues[end-1].HourPrice {
+				end++
+			}
+
+			results = append(results, a.searchVenueBatch(c, venues[start:end], query, date, target)...)
+			lastPrice = venues[end-1].HourPrice
+			start = end
+		}
+
+		if list.NextCursor == "" {
+			break
+		}
+		venueCursor = list.NextCursor
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].HourPrice != results[j].HourPrice {
+			return results[i].HourPrice < results[j].HourPrice
+		}
+		if results[i].OffsetMinutes != results[j].OffsetMinutes {
+			return results[i].OffsetMinutes < results[j].OffsetMinutes
+		}
+		if !results[i].StartAt.Equal(results[j].StartAt) {
+			return results[i].StartAt.Before(results[j].StartAt)
+		}
+		return results[i].VenueID < results[j].VenueID
+	})
+
+	resp := models.SlotSearchResponse{
+		Items:   []models.SlotSearchResult{},
+		Page:    query.Page,
+		Limit:   query.Limit,
+		HasMore: len(results) > offset+query.Limit,
+	}
+	if offset < len(results) {
+		resp.Items = results[offset:min(offset+query.Limit, len(results))]
+	}
+	if resp.HasMore {
+		resp.NextCursor = nextSlotSearchCursor(results, offset+query.Limit).encode()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// nextSlotSearchCursor возвращает курсор страницы, которая начинается с results[next]:
+// цену этого результата и число результатов той же цены перед ним
+func nextSlotSearchCursor(results []models.SlotSearchResult, next int) slotSearchCursor {
+	cur := slotSearchCursor{Price: results[next].HourPrice}
+	for i := next - 1; i >= 0 && results[i].HourPrice == cur.Price; i-- {
+		cur.Skip++
+	}
+	return cur
+}
+
+func (a *Aggregator) searchVenuesURL(query slotSearchQuery, minPrice int, cursor string) string {
+	params := url.Values{}
+	params.Set("is_active", "true")
+	params.Set("sort", "price")
+	params.Set("limit", strconv.Itoa(searchVenuePageSize))
+	params.Set("envelope", "true")
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+	if query.VenueType != "" {
+		params.Set("venue_type", query.VenueType)
+	}
+	if query.District != "" {
+		params.Set("district", query.District)
+	}
+	if minPrice > 0 {
+		params.Set("min_price", strconv.Itoa(minPrice))
+	}
+	if query.MaxPrice > 0 {
+		params.Set("max_price", strconv.Itoa(query.MaxPrice))
+	}
+	return fmt.Sprintf("%s/venues?%s", a.venueURL, params.Encode())
+}
+
+// searchVenueBatch параллельно запрашивает доступность площадок пачки и подбирает для каждой лучшее окно.
+// Площадки, для которых reservation-service вернул ошибку, пропускаются
+func (a *Aggregator) searchVenueBatch(c *gin.Context, venues []models.SearchVenue, query slotSearchQuery, date, target time.Time) []models.SlotSearchResult {
+	found := make([]*models.SlotSearchResult, len(venues))
+	sem := make(chan struct{}, searchConcurrency)
+	var wg sync.WaitGroup
+
+	for i := range venues {
+		venue := venues[i]
+		if !venueFitsSearch(venue, query) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			availabilityURL := fmt.Sprintf("%s/venues/%d/availability?date=%s", a.reservationURL, venue.ID, date.Format("2006-01-02"))
+			body, status, err := a.fetchJSON(c, availabilityURL)
+			if err != nil || status != http.StatusOK {
+				slog.Warn("не удалось получить доступность площадки", "venue_id", venue.ID, "status", status, "error", err)
+				return
+			}
+
+			var slots []models.AvailableSlot
+			if err := json.Unmarshal(body, &slots); err != nil {
+				slog.Warn("неверный ответ доступности площадки", "venue_id", venue.ID, "error", err)
+				return
+			}
+
+			found[i] = bestWindow(venue, slots, query, target)
+		}(i)
+	}
+	wg.Wait()
+
+	var results []models.SlotSearchResult
+	for _, r := range found {
+		if r != nil {
+			results = append(results, *r)
+		}
+	}
+	return results
+}
+
+// venueFitsSearch отсекает площадки, которые заведомо не подходят по правилам бронирования или вместимости
+func venueFitsSearch(venue models.SearchVenue, query slotSearchQuery) bool {
+	if venue.Capacity > 0 && query.PartySize > venue.Capacity {
+		return false
+	}
+	if rules := venue.BookingRules; rules != nil {
+		if rules.MinDurationMinutes > 0 && query.Duration < rules.MinDurationMinutes {
+			return false
+		}
+		if rules.MaxDurationMinutes > 0 && query.Duration > rules.MaxDurationMinutes {
+			return false
+		}
+	}
+	return true
+}
+
+// bestWindow выбирает окно нужной длительности с началом, ближайшим к target.
+// Начало окна выравнивается по шагу начала брони площадки
+func bestWindow(venue models.SearchVenue, slots []models.AvailableSlot, query slotSearchQuery, target time.Time) *models.SlotSearchResult {
+	duration := time.Duration(query.Duration) * time.Minute
+	step := time.Minute
+	if venue.BookingRules != nil && venue.BookingRules.StartStepMinutes > 0 {
+		step = time.Duration(venue.BookingRules.StartStepMinutes) * time.Minute
+	}
+
+	var best *models.SlotSearchResult
+	var bestOffset time.Duration
+	for _, w := range searchWindows(slots, venue.Capacity, query.PartySize) {
+		latest := w.EndAt.Add(-duration)
+		if latest.Before(w.StartAt) {
+			continue
+		}
+
+		// Ближайший к target шаг внутри окна
+		maxSteps := int64(latest.Sub(w.StartAt) / step)
+		steps := int64((target.Sub(w.StartAt) + step/2) / step)
+		if target.Before(w.StartAt) {
+			steps = 0
+		}
+		if steps > maxSteps {
+			steps = maxSteps
+		}
+		start := w.StartAt.Add(time.Duration(steps) * step)
+
+		offset := start.Sub(target)
+		if offset < 0 {
+			offset = -offset
+		}
+		if best != nil && (offset > bestOffset || (offset == bestOffset && !start.Before(best.StartAt))) {
+			continue
+		}
+
+		bestOffset = offset
+		best = &models.SlotSearchResult{
+			VenueID:        venue.ID,
+			VenueType:      venue.VenueType,
+			District:       venue.District,
+			HourPrice:      venue.HourPrice,
+			StartAt:        start,
+			EndAt:          start.Add(duration),
+			UnitID:         w.UnitID,
+			RemainingSpots: w.RemainingSpots,
+			OffsetMinutes:  int(offset / time.Minute),
+		}
+	}
+	return best
+}
+
+// searchWindows возвращает непрерывные свободные окна. Для площадок с вместимостью больше 1
+// соседние отрезки, где хватает мест на всю компанию, склеиваются в одно окно
+func searchWindows(slots []models.AvailableSlot, capacity, partySize int) []models.AvailableSlot {
+	if capacity <= 1 {
+		return slots
+	}
+
+	var windows []models.AvailableSlot
+	for _, s := range slots {
+		if s.RemainingSpots < partySize {
+			continue
+		}
+		if n := len(windows); n > 0 && windows[n-1].EndAt.Equal(s.StartAt) {
+			windows[n-1].EndAt = s.EndAt
+			if s.RemainingSpots < windows[n-1].RemainingSpots {
+				windows[n-1].RemainingSpots = s.RemainingSpots
+			}
+			continue
+		}
+		windows = append(windows, s)
+	}
+	return windows
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 
+	"payment-service/internal/clients"
 	"payment-service/internal/config"
 	"payment-service/internal/models"
 	"payment-service/internal/repository"
@@ -26,6 +27,11 @@ func main() {
 
 	db := config.ConnectDB()
 
+	if err := config.MigrateNumericIdentity(db); err != nil {
+		slog.Error("ошибка перевода платежей на числовые идентификаторы", "error", err)
+		os.Exit(1)
+	}
+
 	if err := db.AutoMigrate(
 		&models.Payment{},
 		&models.Refund{},
@@ -34,9 +40,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	reservationClient := clients.NewReservationClient(config.GetEnv("RESERVATION_SERVICE_URL", "http://localhost:8081"))
+	// Не задерживает запуск: reservation-service может подняться позже
+	go func() {
+		if err := config.BackfillPaymentOwners(db, reservationClient); err != nil {
+			slog.Warn("не удалось заполнить владельцев старых платежей, повтор при следующем запуске", "error", err)
+		}
+	}()
+
 	paymentRepo := repository.NewPaymentRepository(db)
 	refundRepo := repository.NewRefundRepository(db)
-	paymentService := services.NewPaymentService(paymentRepo)
+	paymentService := services.NewPaymentService(paymentRepo, reservationClient)
 	refundService := services.NewRefundService(refundRepo, paymentRepo, db)
 	transportHandler := transport.NewPaymentHandler(paymentService, refundService, logger)
 	consumer := kafkaconsumer.NewConsumerFromEnv(paymentService, refundService, logger)
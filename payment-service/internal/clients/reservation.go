@@ -0,0 +1,57 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrBookingNotFound = errors.New("бронь не найдена")
+
+// Booking - данные брони из reservation-service, нужные payment-service
+type Booking struct {
+	ID       uint `json:"id"`
+	VenueID  uint `json:"venue_id"`
+	OwnerID  uint `json:"owner_id"`
+	ClientID uint `json:"client_id"`
+}
+
+type ReservationClient interface {
+	GetBooking(id uint) (*Booking, error)
+}
+
+type reservationClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewReservationClient(baseURL string) ReservationClient {
+	return &reservationClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (c *reservationClient) GetBooking(id uint) (*Booking, error) {
+	resp, err := c.client.Get(fmt.Sprintf("%s/bookings/%d", c.baseURL, id))
+	if err != nil {
+		return nil, fmt.Errorf("reservation-service недоступен: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrBookingNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reservation-service вернул статус %d", resp.StatusCode)
+	}
+
+	var booking Booking
+	if err := json.NewDecoder(resp.Body).Decode(&booking); err != nil {
+		return nil, fmt.Errorf("неверный ответ reservation-service: %w", err)
+	}
+	return &booking, nil
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"payment-service/internal/clients"
+
+	"gorm.io/gorm"
+)
+
+// MigrateNumericIdentity переводит payments.booking_id и payments.user_id с uuid на числовые ID
+// reservation-service и user-service. Выполняется до AutoMigrate и только если колонки ещё uuid.
+// UUID вида 00000000-0000-0000-xxxx-xxxxxxxxxxxx (так consumer записывал числовые ID из событий)
+// переводятся обратно в число, остальные получают 0. Исходные значения сохраняются
+// в legacy_booking_uuid и legacy_user_uuid для ручной сверки
+func MigrateNumericIdentity(db *gorm.DB) error {
+	var dataType string
+	err := db.Raw(`SELECT data_type FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'payments' AND column_name = 'booking_id'`).
+		Scan(&dataType).Error
+	if err != nil {
+		return err
+	}
+	if dataType != "uuid" {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, column := range []string{"booking_id", "user_id"} {
+			legacy := "legacy_" + column[:len(column)-len("_id")] + "_uuid"
+			statements := []string{
+				fmt.Sprintf(`ALTER TABLE payments ADD COLUMN IF NOT EXISTS %s uuid`, legacy),
+				fmt.Sprintf(`UPDATE payments SET %s = %s`, legacy, column),
+				fmt.Sprintf(`ALTER TABLE payments ALTER COLUMN %[1]s TYPE bigint USING (
+					CASE WHEN %[1]s::text LIKE '00000000-0000-0000-%%'
+						THEN ('x' || right(replace(%[1]s::text, '-', ''), 16))::bit(64)::bigint
+						ELSE 0
+					END)`, column),
+			}
+			for _, stmt := range statements {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		var unmapped int64
+		if err := tx.Table("payments").Where("booking_id = 0 OR user_id = 0").Count(&unmapped).Error; err != nil {
+			return err
+		}
+		slog.Info("payments переведены на числовые идентификаторы", "unmapped", unmapped)
+		if unmapped > 0 {
+			slog.Warn("часть платежей не удалось сопоставить с бронями и пользователями, исходные uuid сохранены в legacy_booking_uuid и legacy_user_uuid", "count", unmapped)
+		}
+		return nil
+	})
+}
+
+// BackfillPaymentOwners заполняет venue_id и owner_id платежей, созданных до появления этих колонок,
+// по броням из reservation-service. Затрагивает только платежи с owner_id = 0, поэтому безопасен
+// при каждом запуске. Если reservation-service недоступен, заполнение прерывается до следующего запуска
+func BackfillPaymentOwners(db *gorm.DB, bookings clients.ReservationClient) error {
+	var bookingIDs []uint
+	if err := db.Table("payments").Where("owner_id = 0 AND booking_id > 0").Distinct().Pluck("booking_id", &bookingIDs).Error; err != nil {
+		return err
+	}
+	if len(bookingIDs) == 0 {
+		return nil
+	}
+
+	var filled, missing int
+	for _, id := range bookingIDs {
+		booking, err := bookings.GetBooking(id)
+		if errors.Is(err, clients.ErrBookingNotFound) || err == nil && booking.OwnerID == 0 {
+			missing++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("бронь %d: %w", id, err)
+		}
+
+		err = db.Table("payments").Where("booking_id = ? AND owner_id = 0", id).
+			Updates(map[string]interface{}{"venue_id": booking.VenueID, "owner_id": booking.OwnerID}).Error
+		if err != nil {
+			return err
+		}
+		filled++
+	}
+
+	slog.Info("владельцы старых платежей заполнены по броням", "bookings", filled)
+	if missing > 0 {
+		slog.Warn("для части платежей бронь не найдена, их видит только администратор", "count", missing)
+	}
+	return nil
+}
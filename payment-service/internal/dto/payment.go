@@ -3,14 +3,15 @@
 import (
 	"time"
 
-	"github.com/google/uuid"
-
 	"payment-service/internal/models"
 )
 
 type CreatePaymentRequest struct {
-	BookingID uuid.UUID            `json:"booking_id" binding:"required"`
-	UserID    uuid.UUID            `json:"user_id" binding:"required"`
+	BookingID uint                 `json:"booking_id" binding:"required,min=1"`
+	UserID    uint                 `json:"user_id" binding:"required,min=1"`
+	// Площадка и владелец берутся из брони, а не из тела запроса: по owner_id строится выгрузка владельца
+	VenueID   uint                 `json:"-"`
+	OwnerID   uint                 `json:"-"`
 	Amount    int64                `json:"amount" binding:"required,gt=0"`
 	Currency  string               `json:"currency" binding:"omitempty,oneof=RUB"`
 	Method    models.PaymentMethod `json:"method" binding:"required"`
@@ -18,8 +19,10 @@ type CreatePaymentRequest struct {
 
 type PaymentResponse struct {
 	ID             uint                 `json:"id"`
-	BookingID      uuid.UUID            `json:"booking_id"`
-	UserID         uuid.UUID            `json:"user_id"`
+	BookingID      uint                 `json:"booking_id"`
+	UserID         uint                 `json:"user_id"`
+	VenueID        uint                 `json:"venue_id"`
+	OwnerID        uint                 `json:"owner_id"`
 	Amount         int64                `json:"amount"`
 	Currency       string               `json:"currency"`
 	Method         models.PaymentMethod `json:"method"`
@@ -3,7 +3,6 @@ package models
 import (
 	"time"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -32,8 +31,10 @@ func IsValidPaymentMethod(method PaymentMethod) bool {
 
 type Payment struct {
 	gorm.Model
-	BookingID      uuid.UUID     `gorm:"type:uuid;index" json:"booking_id"`
-	UserID         uuid.UUID     `gorm:"type:uuid;index" json:"user_id"`
+	BookingID      uint          `gorm:"index" json:"booking_id"` // ID брони в reservation-service
+	UserID         uint          `gorm:"index" json:"user_id"`    // ID пользователя в user-service
+	VenueID        uint          `gorm:"index" json:"venue_id"`   // ID площадки брони, 0 для платежей до появления поля
+	OwnerID        uint          `gorm:"index" json:"owner_id"`   // ID владельца площадки, по нему ограничивается выгрузка
 	Amount         int64         `gorm:"column:amount" json:"amount"`
 	Currency       string        `gorm:"column:currency" json:"currency"`
 	Method         PaymentMethod `gorm:"column:method" json:"method"`
@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"payment-service/internal/models"
@@ -16,9 +16,18 @@ var ErrNotFound = errors.New("не найдено")
 type PaymentRepository interface {
 	CreatePayment(payment *models.Payment) error
 	GetPaymentByID(id uint) (*models.Payment, error)
-	GetPaymentsByUserID(userID uuid.UUID, limit, offset int) ([]models.Payment, int64, error)
-	GetPaymentByBookingID(bookingID uuid.UUID) (*models.Payment, error)
+	GetPaymentsByUserID(userID uint, limit, offset int) ([]models.Payment, int64, error)
+	GetPaymentByBookingID(bookingID uint) (*models.Payment, error)
 	UpdatePayment(payment *models.Payment) error
+	StreamPayments(filter ExportFilter, handle func(models.Payment) error) error
+}
+
+// ExportFilter - условия выгрузки платежей и возвратов. Нулевые значения не ограничивают выборку
+type ExportFilter struct {
+	OwnerID *uint // Только платежи по площадкам владельца
+	Status string
+	From   time.Time // Дата создания >= From
+	To     time.Time // Дата создания < To
 }
 
 type PaymentRepositoryImpl struct {
@@ -55,7 +64,7 @@ func (r *PaymentRepositoryImpl) GetPaymentByID(id uint) (*models.Payment, error)
 	return &payment, nil
 }
 
-func (r *PaymentRepositoryImpl) GetPaymentsByUserID(userID uuid.UUID, limit, offset int) ([]models.Payment, int64, error) {
+func (r *PaymentRepositoryImpl) GetPaymentsByUserID(userID uint, limit, offset int) ([]models.Payment, int64, error) {
 	if limit <= 0 {
 		limit = 10
 	}
@@ -83,7 +92,7 @@ func (r *PaymentRepositoryImpl) GetPaymentsByUserID(userID uuid.UUID, limit, off
 	return payments, total, nil
 }
 
-func (r *PaymentRepositoryImpl) GetPaymentByBookingID(bookingID uuid.UUID) (*models.Payment, error) {
+func (r *PaymentRepositoryImpl) GetPaymentByBookingID(bookingID uint) (*models.Payment, error) {
 	var payment models.Payment
 	if err := r.db.Where("booking_id = ?", bookingID).First(&payment).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -104,3 +113,39 @@ func (r *PaymentRepositoryImpl) UpdatePayment(payment *models.Payment) error {
 	r.logger.Info("платеж обновлен", "payment_id", payment.ID)
 	return nil
 }
+
+// StreamPayments построчно читает платежи курсором и передаёт их в handle, не загружая выборку целиком
+func (r *PaymentRepositoryImpl) StreamPayments(filter ExportFilter, handle func(models.Payment) error) error {
+	q := r.db.Model(&models.Payment{})
+	if filter.OwnerID != nil {
+		q = q.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		q = q.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		q = q.Where("created_at < ?", filter.To)
+	}
+
+	rows, err := q.Order("created_at ASC").Order("id ASC").Rows()
+	if err != nil {
+		r.logger.Error("ошибка выгрузки платежей", "error", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payment models.Payment
+		if err := r.db.ScanRows(rows, &payment); err != nil {
+			r.logger.Error("ошибка чтения платежа при выгрузке", "error", err)
+			return err
+		}
+		if err := handle(payment); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
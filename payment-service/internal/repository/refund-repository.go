@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -15,6 +16,20 @@ type RefundRepository interface {
 	GetRefundByID(id uint) (*models.Refund, error)
 	GetRefundsByPaymentID(paymentID uint) ([]models.Refund, error)
 	UpdateRefund(refund *models.Refund) error
+	StreamRefunds(filter ExportFilter, handle func(RefundExportRow) error) error
+}
+
+// RefundExportRow - возврат вместе с данными платежа для выгрузки
+type RefundExportRow struct {
+	ID        uint
+	PaymentID uint
+	BookingID uint
+	UserID    uint
+	Amount    int64
+	Currency  string
+	Reason    string
+	Status    models.RefundStatus
+	CreatedAt time.Time
 }
 
 type RefundRepositoryImpl struct {
@@ -68,3 +83,43 @@ func (r *RefundRepositoryImpl) UpdateRefund(refund *models.Refund) error {
 	r.logger.Info("возврат обновлен", "refund_id", refund.ID)
 	return nil
 }
+
+// StreamRefunds построчно читает возвраты с данными платежа и передаёт их в handle.
+// OwnerID фильтрует по владельцу площадки, за которую принят платеж
+func (r *RefundRepositoryImpl) StreamRefunds(filter ExportFilter, handle func(RefundExportRow) error) error {
+	q := r.db.Table("refunds").
+		Select("refunds.id, refunds.payment_id, payments.booking_id, payments.user_id, refunds.amount, payments.currency, refunds.reason, refunds.status, refunds.created_at").
+		Joins("JOIN payments ON payments.id = refunds.payment_id").
+		Where("refunds.deleted_at IS NULL")
+	if filter.OwnerID != nil {
+		q = q.Where("payments.owner_id = ?", *filter.OwnerID)
+	}
+	if filter.Status != "" {
+		q = q.Where("refunds.status = ?", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		q = q.Where("refunds.created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		q = q.Where("refunds.created_at < ?", filter.To)
+	}
+
+	rows, err := q.Order("refunds.created_at ASC").Order("refunds.id ASC").Rows()
+	if err != nil {
+		r.logger.Error("ошибка выгрузки возвратов", "error", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row RefundExportRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			r.logger.Error("ошибка чтения возврата при выгрузке", "error", err)
+			return err
+		}
+		if err := handle(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -8,4 +8,5 @@ var (
 	ErrInvalidMethod      = errors.New("недопустимый метод оплаты")
 	ErrPaymentNotComplete = errors.New("платеж не завершен")
 	ErrRefundAmountExceed = errors.New("сумма возврата превышает доступную")
+	ErrBookingNotFound    = errors.New("бронь не найдена")
 )
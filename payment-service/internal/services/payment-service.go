@@ -1,11 +1,12 @@
 ﻿package services
 
 import (
+	"errors"
 	"log/slog"
 	"time"
 
-	"github.com/google/uuid"
 
+	"payment-service/internal/clients"
 	"payment-service/internal/dto"
 	"payment-service/internal/models"
 	"payment-service/internal/repository"
@@ -15,23 +16,42 @@ type PaymentService interface {
 	CreatePayment(req *dto.CreatePaymentRequest) (*models.Payment, error)
 	CreatePendingPayment(req *dto.CreatePaymentRequest) (*models.Payment, error)
 	GetPaymentByID(id uint) (*models.Payment, error)
-	GetPaymentByBookingID(bookingID uuid.UUID) (*models.Payment, error)
-	GetPaymentsByUserID(userID uuid.UUID, limit, offset int) ([]models.Payment, int64, error)
+	GetPaymentByBookingID(bookingID uint) (*models.Payment, error)
+	GetPaymentsByUserID(userID uint, limit, offset int) ([]models.Payment, int64, error)
+	StreamPayments(filter repository.ExportFilter, handle func(models.Payment) error) error
 }
 
 type PaymentServiceImpl struct {
 	paymentRepo repository.PaymentRepository
+	bookings    clients.ReservationClient
 	logger      *slog.Logger
 }
 
-func NewPaymentService(paymentRepo repository.PaymentRepository) PaymentService {
+func NewPaymentService(paymentRepo repository.PaymentRepository, bookings clients.ReservationClient) PaymentService {
 	return &PaymentServiceImpl{
 		paymentRepo: paymentRepo,
+		bookings:    bookings,
 		logger:      slog.Default(),
 	}
 }
 
+// CreatePayment создаёт оплаченный платёж по запросу клиента. Площадка и владелец
+// берутся из брони в reservation-service: тело запроса на выгрузку владельца не влияет
 func (s *PaymentServiceImpl) CreatePayment(req *dto.CreatePaymentRequest) (*models.Payment, error) {
+	if req == nil {
+		s.logger.Error("пустой запрос на создание платежа")
+		return nil, ErrEmptyRequest
+	}
+	booking, err := s.bookings.GetBooking(req.BookingID)
+	if err != nil {
+		if errors.Is(err, clients.ErrBookingNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		s.logger.Error("ошибка получения брони для платежа", "booking_id", req.BookingID, "error", err)
+		return nil, err
+	}
+	req.VenueID = booking.VenueID
+	req.OwnerID = booking.OwnerID
 	return s.createPayment(req, models.PaymentStatusCompleted, true)
 }
 
@@ -48,7 +68,7 @@ func (s *PaymentServiceImpl) GetPaymentByID(id uint) (*models.Payment, error) {
 	return payment, nil
 }
 
-func (s *PaymentServiceImpl) GetPaymentByBookingID(bookingID uuid.UUID) (*models.Payment, error) {
+func (s *PaymentServiceImpl) GetPaymentByBookingID(bookingID uint) (*models.Payment, error) {
 	payment, err := s.paymentRepo.GetPaymentByBookingID(bookingID)
 	if err != nil {
 		s.logger.Error("ошибка получения платежа по booking_id", "booking_id", bookingID, "error", err)
@@ -57,7 +77,7 @@ func (s *PaymentServiceImpl) GetPaymentByBookingID(bookingID uuid.UUID) (*models
 	return payment, nil
 }
 
-func (s *PaymentServiceImpl) GetPaymentsByUserID(userID uuid.UUID, limit, offset int) ([]models.Payment, int64, error) {
+func (s *PaymentServiceImpl) GetPaymentsByUserID(userID uint, limit, offset int) ([]models.Payment, int64, error) {
 	payments, total, err := s.paymentRepo.GetPaymentsByUserID(userID, limit, offset)
 	if err != nil {
 		s.logger.Error("ошибка получения платежей пользователя", "user_id", userID, "error", err)
@@ -86,6 +106,8 @@ func (s *PaymentServiceImpl) createPayment(req *dto.CreatePaymentRequest, status
 	payment := &models.Payment{
 		BookingID: req.BookingID,
 		UserID:    req.UserID,
+		VenueID:   req.VenueID,
+		OwnerID:   req.OwnerID,
 		Amount:    req.Amount,
 		Currency:  req.Currency,
 		Method:    req.Method,
@@ -104,3 +126,7 @@ func (s *PaymentServiceImpl) createPayment(req *dto.CreatePaymentRequest, status
 	s.logger.Info("платеж создан", "payment_id", payment.ID)
 	return payment, nil
 }
+
+func (s *PaymentServiceImpl) StreamPayments(filter repository.ExportFilter, handle func(models.Payment) error) error {
+	return s.paymentRepo.StreamPayments(filter, handle)
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"payment-service/internal/clients"
+	"payment-service/internal/dto"
+	"payment-service/internal/models"
+	"payment-service/internal/repository"
+)
+
+// fakePaymentRepo запоминает созданные платежи
+type fakePaymentRepo struct {
+	repository.PaymentRepository
+	created []models.Payment
+}
+
+func (r *fakePaymentRepo) CreatePayment(payment *models.Payment) error {
+	payment.ID = uint(len(r.created) + 1)
+	r.created = append(r.created, *payment)
+	return nil
+}
+
+// fakeReservationClient отдаёт брони из памяти
+type fakeReservationClient struct {
+	bookings map[uint]clients.Booking
+}
+
+func (c *fakeReservationClient) GetBooking(id uint) (*clients.Booking, error) {
+	booking, ok := c.bookings[id]
+	if !ok {
+		return nil, clients.ErrBookingNotFound
+	}
+	return &booking, nil
+}
+
+func TestCreatePaymentTakesOwnerFromBooking(t *testing.T) {
+	repo := &fakePaymentRepo{}
+	bookings := &fakeReservationClient{bookings: map[uint]clients.Booking{
+		42: {ID: 42, VenueID: 7, OwnerID: 4, ClientID: 15},
+	}}
+	service := NewPaymentService(repo, bookings)
+
+	// Чужие venue_id и owner_id в запросе не попадают в платёж
+	req := &dto.CreatePaymentRequest{BookingID: 42, UserID: 15, VenueID: 99, OwnerID: 99, Amount: 10000, Method: models.MethodCard}
+	payment, err := service.CreatePayment(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payment.VenueID != 7 || payment.OwnerID != 4 {
+		t.Fatalf("venue_id/owner_id: %d/%d, ожидалось 7/4", payment.VenueID, payment.OwnerID)
+	}
+
+	_, err = service.CreatePayment(&dto.CreatePaymentRequest{BookingID: 43, UserID: 15, Amount: 10000, Method: models.MethodCard})
+	if !errors.Is(err, ErrBookingNotFound) {
+		t.Fatalf("ошибка %v, ожидалась ErrBookingNotFound", err)
+	}
+	if len(repo.created) != 1 {
+		t.Fatalf("создано %d платежей", len(repo.created))
+	}
+}
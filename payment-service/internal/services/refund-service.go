@@ -15,6 +15,7 @@ type RefundService interface {
 	CreateRefund(paymentID uint, req *dto.RefundRequest) (*models.Refund, error)
 	GetRefundByID(id uint) (*models.Refund, error)
 	GetRefundsByPaymentID(paymentID uint) ([]models.Refund, error)
+	StreamRefunds(filter repository.ExportFilter, handle func(repository.RefundExportRow) error) error
 }
 
 type RefundServiceImpl struct {
@@ -112,3 +113,7 @@ func (s *RefundServiceImpl) GetRefundsByPaymentID(paymentID uint) ([]models.Refu
 	}
 	return refunds, nil
 }
+
+func (s *RefundServiceImpl) StreamRefunds(filter repository.ExportFilter, handle func(repository.RefundExportRow) error) error {
+	return s.refundRepo.StreamRefunds(filter, handle)
+}
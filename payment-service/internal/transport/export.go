@@ -0,0 +1,204 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pkg/export"
+	"payment-service/internal/models"
+	"payment-service/internal/repository"
+)
+
+// Роли из токена, которые gateway передаёт в X-User-Role
+const (
+	roleOwner = "Owner"
+	roleAdmin = "Admin"
+)
+
+func formatUint(v uint) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// paymentExportColumns - колонки выгрузки платежей, выбираются параметром columns
+var paymentExportColumns = []export.Column[models.Payment]{
+	{Key: "id", Title: "ID", Numeric: true, Value: func(p models.Payment, _ export.Locale) string { return formatUint(p.ID) }},
+	{Key: "booking_id", Title: "Бронь", Numeric: true, Value: func(p models.Payment, _ export.Locale) string { return formatUint(p.BookingID) }},
+	{Key: "user_id", Title: "Пользователь", Numeric: true, Value: func(p models.Payment, _ export.Locale) string { return formatUint(p.UserID) }},
+	{Key: "amount", Title: "Сумма", Numeric: true, Value: func(p models.Payment, _ export.Locale) string {
+		return strconv.FormatInt(p.Amount, 10)
+	}},
+	{Key: "refunded_amount", Title: "Возвращено", Numeric: true, Value: func(p models.Payment, _ export.Locale) string {
+		return strconv.FormatInt(p.RefundedAmount, 10)
+	}},
+	{Key: "currency", Title: "Валюта", Value: func(p models.Payment, _ export.Locale) string { return p.Currency }},
+	{Key: "method", Title: "Способ оплаты", Value: func(p models.Payment, _ export.Locale) string { return string(p.Method) }},
+	{Key: "status", Title: "Статус", Value: func(p models.Payment, _ export.Locale) string { return string(p.Status) }},
+	{Key: "paid_at", Title: "Оплачен", Value: func(p models.Payment, l export.Locale) string { return l.TimePtr(p.PaidAt) }},
+	{Key: "refunded_at", Title: "Возвращен", Value: func(p models.Payment, l export.Locale) string { return l.TimePtr(p.RefundedAt) }},
+	{Key: "created_at", Title: "Создан", Value: func(p models.Payment, l export.Locale) string { return l.Time(p.CreatedAt) }},
+}
+
+// refundExportColumns - колонки выгрузки возвратов
+var refundExportColumns = []export.Column[repository.RefundExportRow]{
+	{Key: "id", Title: "ID", Numeric: true, Value: func(r repository.RefundExportRow, _ export.Locale) string { return formatUint(r.ID) }},
+	{Key: "payment_id", Title: "Платеж", Numeric: true, Value: func(r repository.RefundExportRow, _ export.Locale) string {
+		return formatUint(r.PaymentID)
+	}},
+	{Key: "booking_id", Title: "Бронь", Numeric: true, Value: func(r repository.RefundExportRow, _ export.Locale) string {
+		return formatUint(r.BookingID)
+	}},
+	{Key: "user_id", Title: "Пользователь", Numeric: true, Value: func(r repository.RefundExportRow, _ export.Locale) string {
+		return formatUint(r.UserID)
+	}},
+	{Key: "amount", Title: "Сумма", Numeric: true, Value: func(r repository.RefundExportRow, _ export.Locale) string {
+		return strconv.FormatInt(r.Amount, 10)
+	}},
+	{Key: "currency", Title: "Валюта", Value: func(r repository.RefundExportRow, _ export.Locale) string { return r.Currency }},
+	{Key: "reason", Title: "Причина", Value: func(r repository.RefundExportRow, _ export.Locale) string { return r.Reason }},
+	{Key: "status", Title: "Статус", Value: func(r repository.RefundExportRow, _ export.Locale) string { return string(r.Status) }},
+	{Key: "created_at", Title: "Создан", Value: func(r repository.RefundExportRow, l export.Locale) string { return l.Time(r.CreatedAt) }},
+}
+
+var paymentStatuses = map[string]struct{}{
+	string(models.PaymentStatusPending):   {},
+	string(models.PaymentStatusCompleted): {},
+	string(models.PaymentStatusRefunded):  {},
+	string(models.PaymentStatusFailed):    {},
+}
+
+var refundStatuses = map[string]struct{}{
+	string(models.RefundStatusPending):   {},
+	string(models.RefundStatusCompleted): {},
+	string(models.RefundStatusFailed):    {},
+}
+
+// exportRequest - общие параметры выгрузки: формат, колонки, локаль и фильтры
+type exportRequest struct {
+	format  export.Format
+	locale  export.Locale
+	filter  repository.ExportFilter
+	columns string
+}
+
+func parseExportRequest(c *gin.Context, statuses map[string]struct{}) (*exportRequest, bool) {
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, "ОШИБКА_ВАЛИДАЦИИ", "400", err.Error())
+		return nil, false
+	}
+	locale, err := export.ParseLocale(c.Query("locale"), c.Query("tz"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, "ОШИБКА_ВАЛИДАЦИИ", "400", err.Error())
+		return nil, false
+	}
+
+	req := &exportRequest{format: format, locale: locale, columns: c.Query("columns")}
+
+	// Выгрузка доступна владельцу (только его площадки) и администратору (все платежи).
+	// Заголовки выставляет gateway по токену
+	userID, err := parseUintID(c.GetHeader("X-User-Id"))
+	if err != nil || userID == 0 {
+		writeError(c, http.StatusUnauthorized, "НЕ_АВТОРИЗОВАН", "401", "требуется авторизация")
+		return nil, false
+	}
+	switch c.GetHeader("X-User-Role") {
+	case roleAdmin:
+	case roleOwner:
+		req.filter.OwnerID = &userID
+	default:
+		writeError(c, http.StatusForbidden, "ДОСТУП_ЗАПРЕЩЕН", "403", "выгрузка доступна владельцу площадки и администратору")
+		return nil, false
+	}
+
+	if status := c.Query("status"); status != "" {
+		if _, ok := statuses[status]; !ok {
+			writeError(c, http.StatusBadRequest, "ОШИБКА_ВАЛИДАЦИИ", "400", "некорректный статус: "+status)
+			return nil, false
+		}
+		req.filter.Status = status
+	}
+
+	// Даты периода задаются в часовом поясе выгрузки, to включительно
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.ParseInLocation("2006-01-02", fromStr, locale.Location())
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "ОШИБКА_ВАЛИДАЦИИ", "400", "некорректная дата from, формат YYYY-MM-DD")
+			return nil, false
+		}
+		req.filter.From = from
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.ParseInLocation("2006-01-02", toStr, locale.Location())
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "ОШИБКА_ВАЛИДАЦИИ", "400", "некорректная дата to, формат YYYY-MM-DD")
+			return nil, false
+		}
+		req.filter.To = to.AddDate(0, 0, 1)
+	}
+
+	return req, true
+}
+
+func (h *PaymentHandler) ExportPayments(c *gin.Context) {
+	req, ok := parseExportRequest(c, paymentStatuses)
+	if !ok {
+		return
+	}
+	columns, err := export.SelectColumns(paymentExportColumns, req.columns)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, "ОШИБКА_ВАЛИДАЦИИ", "400", err.Error())
+		return
+	}
+
+	table, err := startExport(c, req, "payments", "Платежи", columns)
+	if err != nil {
+		h.logger.Error("ошибка начала выгрузки платежей", "error", err)
+		return
+	}
+	if err := h.paymentService.StreamPayments(req.filter, table.Write); err != nil {
+		h.logger.Error("ошибка выгрузки платежей", "error", err)
+		return
+	}
+	if err := table.Close(); err != nil {
+		h.logger.Error("ошибка завершения выгрузки платежей", "error", err)
+	}
+}
+
+func (h *PaymentHandler) ExportRefunds(c *gin.Context) {
+	req, ok := parseExportRequest(c, refundStatuses)
+	if !ok {
+		return
+	}
+	columns, err := export.SelectColumns(refundExportColumns, req.columns)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, "ОШИБКА_ВАЛИДАЦИИ", "400", err.Error())
+		return
+	}
+
+	table, err := startExport(c, req, "refunds", "Возвраты", columns)
+	if err != nil {
+		h.logger.Error("ошибка начала выгрузки возвратов", "error", err)
+		return
+	}
+	if err := h.refundService.StreamRefunds(req.filter, table.Write); err != nil {
+		h.logger.Error("ошибка выгрузки возвратов", "error", err)
+		return
+	}
+	if err := table.Close(); err != nil {
+		h.logger.Error("ошибка завершения выгрузки возвратов", "error", err)
+	}
+}
+
+// startExport выставляет заголовки ответа и пишет строку заголовков таблицы.
+// После этого ответ уже начат, и ошибки выгрузки только логируются
+func startExport[T any](c *gin.Context, req *exportRequest, filename, sheet string, columns []export.Column[T]) (*export.Table[T], error) {
+	c.Header("Content-Type", req.format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, req.format.Filename(filename)))
+	c.Status(http.StatusOK)
+
+	return export.NewTable(c.Writer, req.format, sheet, columns, req.locale)
+}
@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseExportRequestAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name      string
+		userID    string
+		role      string
+		query     string
+		wantOK    bool
+		wantCode  int
+		wantOwner *uint
+	}{
+		{name: "без авторизации", wantCode: http.StatusUnauthorized},
+		{name: "user_id в запросе не заменяет авторизацию", query: "?user_id=7", wantCode: http.StatusUnauthorized},
+		{name: "клиент", userID: "9", role: "Client", wantCode: http.StatusForbidden},
+		{name: "владелец", userID: "7", role: roleOwner, wantOK: true, wantOwner: ptrUint(7)},
+		{name: "владелец не выбирает чужие платежи", userID: "7", role: roleOwner, query: "?user_id=8", wantOK: true, wantOwner: ptrUint(7)},
+		{name: "администратор", userID: "1", role: roleAdmin, wantOK: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/payments/export"+tc.query, nil)
+			if tc.userID != "" {
+				c.Request.Header.Set("X-User-Id", tc.userID)
+				c.Request.Header.Set("X-User-Role", tc.role)
+			}
+
+			req, ok := parseExportRequest(c, paymentStatuses)
+			if ok != tc.wantOK {
+				t.Fatalf("ok = %v, want %v (status %d)", ok, tc.wantOK, w.Code)
+			}
+			if !ok {
+				if w.Code != tc.wantCode {
+					t.Fatalf("status = %d, want %d", w.Code, tc.wantCode)
+				}
+				return
+			}
+			switch {
+			case tc.wantOwner == nil && req.filter.OwnerID != nil:
+				t.Fatalf("OwnerID = %d, want nil", *req.filter.OwnerID)
+			case tc.wantOwner != nil && (req.filter.OwnerID == nil || *req.filter.OwnerID != *tc.wantOwner):
+				t.Fatalf("OwnerID = %v, want %d", req.filter.OwnerID, *tc.wantOwner)
+			}
+		})
+	}
+}
+
+func ptrUint(v uint) *uint {
+	return &v
+}
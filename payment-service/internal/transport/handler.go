@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"log/slog"
 
 	"payment-service/internal/dto"
@@ -37,6 +36,7 @@ func (h *PaymentHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	{
 		payments.POST("", h.CreatePayment)
 		payments.GET("", h.GetPaymentsHistory)
+		payments.GET("/export", h.ExportPayments)
 		payments.GET("/:id", h.GetPaymentByID)
 		payments.POST("/:id/refund", h.CreateRefund)
 	}
@@ -45,6 +45,11 @@ func (h *PaymentHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	{
 		bookings.GET("/:id/payment", h.GetPaymentByBookingID)
 	}
+
+	refunds := rg.Group("/refunds")
+	{
+		refunds.GET("/export", h.ExportRefunds)
+	}
 }
 
 func (h *PaymentHandler) CreatePayment(c *gin.Context) {
@@ -99,9 +104,9 @@ func (h *PaymentHandler) GetPaymentsHistory(c *gin.Context) {
 		return
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	userID, err := parseUintID(userIDStr)
 	if err != nil {
-		writeError(c, http.StatusBadRequest, "НЕКОРРЕКТНЫЙ_UUID", "400", "некорректный user_id")
+		writeError(c, http.StatusBadRequest, "НЕКОРРЕКТНЫЙ_ID", "400", "некорректный user_id")
 		return
 	}
 
@@ -157,9 +162,9 @@ func (h *PaymentHandler) CreateRefund(c *gin.Context) {
 }
 
 func (h *PaymentHandler) GetPaymentByBookingID(c *gin.Context) {
-	bookingID, err := uuid.Parse(c.Param("id"))
+	bookingID, err := parseUintID(c.Param("id"))
 	if err != nil {
-		writeError(c, http.StatusBadRequest, "НЕКОРРЕКТНЫЙ_UUID", "400", "некорректный id брони")
+		writeError(c, http.StatusBadRequest, "НЕКОРРЕКТНЫЙ_ID", "400", "некорректный id брони")
 		return
 	}
 
@@ -182,6 +187,8 @@ func paymentToResponse(payment *models.Payment) *dto.PaymentResponse {
 		ID:             payment.ID,
 		BookingID:      payment.BookingID,
 		UserID:         payment.UserID,
+		VenueID:        payment.VenueID,
+		OwnerID:        payment.OwnerID,
 		Amount:         payment.Amount,
 		Currency:       payment.Currency,
 		Method:         payment.Method,
@@ -246,5 +253,6 @@ func isClientError(err error) bool {
 		errors.Is(err, services.ErrInvalidAmount) ||
 		errors.Is(err, services.ErrInvalidMethod) ||
 		errors.Is(err, services.ErrPaymentNotComplete) ||
-		errors.Is(err, services.ErrRefundAmountExceed)
+		errors.Is(err, services.ErrRefundAmountExceed) ||
+		errors.Is(err, services.ErrBookingNotFound)
 }
@@ -2,12 +2,12 @@
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"strings"
 
+	"contracts/events"
+
 	kafkago "github.com/segmentio/kafka-go"
-	"github.com/google/uuid"
 
 	"payment-service/internal/config"
 	"payment-service/internal/dto"
@@ -25,17 +25,6 @@ type Consumer struct {
 	cancelledTopic string
 }
 
-type BookingCreatedEvent struct {
-	BookingID uuid.UUID            `json:"booking_id"`
-	UserID    uuid.UUID            `json:"user_id"`
-	Amount    int64                `json:"amount"`
-	Method    models.PaymentMethod `json:"method"`
-}
-
-type BookingCancelledEvent struct {
-	BookingID uuid.UUID `json:"booking_id"`
-}
-
 func NewConsumerFromEnv(paymentService services.PaymentService, refundService services.RefundService, logger *slog.Logger) *Consumer {
 	if logger == nil {
 		logger = slog.Default()
@@ -48,8 +37,8 @@ func NewConsumerFromEnv(paymentService services.PaymentService, refundService se
 		logger:         logger,
 		brokers:        brokers,
 		groupID:        config.GetEnv("KAFKA_GROUP_ID", "payment-service"),
-		createdTopic:   config.GetEnv("KAFKA_TOPIC_BOOKING_CREATED", "booking.created"),
-		cancelledTopic: config.GetEnv("KAFKA_TOPIC_BOOKING_CANCELLED", "booking.cancelled"),
+		createdTopic:   config.GetEnv("KAFKA_TOPIC_BOOKING_CREATED", events.TopicBookingCreated),
+		cancelledTopic: config.GetEnv("KAFKA_TOPIC_BOOKING_CANCELLED", events.TopicBookingCancelled),
 	}
 }
 
@@ -87,34 +76,18 @@ func (c *Consumer) consumeBookingCreated(ctx context.Context) {
 			continue
 		}
 
-		var event BookingCreatedEvent
-		if err := json.Unmarshal(msg.Value, &event); err != nil {
-			c.logger.Error("ошибка парсинга booking.created", "error", err)
-			continue
-		}
-
-		if event.BookingID == uuid.Nil || event.UserID == uuid.Nil {
-			c.logger.Error("некорректные данные booking.created", "booking_id", event.BookingID, "user_id", event.UserID)
+		env, event, err := events.Decode[events.BookingCreatedV1](msg.Value)
+		if err != nil {
+			c.logger.Error("ошибка разбора booking.created", "error", err, "event_id", env.EventID)
 			continue
 		}
 
-		if event.Amount <= 0 {
-			c.logger.Error("некорректная сумма в booking.created", "amount", event.Amount)
+		// Бесплатной брони платёж не нужен
+		if event.AmountMinor == 0 {
 			continue
 		}
 
-		if event.Method == "" {
-			event.Method = models.MethodCard
-		}
-
-		req := dto.CreatePaymentRequest{
-			BookingID: event.BookingID,
-			UserID:    event.UserID,
-			Amount:    event.Amount,
-			Currency:  "RUB",
-			Method:    event.Method,
-		}
-
+		req := createPaymentRequest(event)
 		if _, err := c.paymentService.CreatePendingPayment(&req); err != nil {
 			c.logger.Error("ошибка создания pending платежа из booking.created", "error", err, "booking_id", event.BookingID)
 			continue
@@ -146,14 +119,9 @@ func (c *Consumer) consumeBookingCancelled(ctx context.Context) {
 			continue
 		}
 
-		var event BookingCancelledEvent
-		if err := json.Unmarshal(msg.Value, &event); err != nil {
-			c.logger.Error("ошибка парсинга booking.cancelled", "error", err)
-			continue
-		}
-
-		if event.BookingID == uuid.Nil {
-			c.logger.Error("некорректные данные booking.cancelled", "booking_id", event.BookingID)
+		env, event, err := events.Decode[events.BookingCancelledV1](msg.Value)
+		if err != nil {
+			c.logger.Error("ошибка разбора booking.cancelled", "error", err, "event_id", env.EventID)
 			continue
 		}
 
@@ -182,6 +150,20 @@ func (c *Consumer) consumeBookingCancelled(ctx context.Context) {
 	}
 }
 
+// createPaymentRequest переводит событие booking.created в запрос на pending платёж.
+// Сумма в событии уже в копейках, как и в платежах
+func createPaymentRequest(event events.BookingCreatedV1) dto.CreatePaymentRequest {
+	return dto.CreatePaymentRequest{
+		BookingID: event.BookingID,
+		UserID:    event.ClientID,
+		VenueID:   event.VenueID,
+		OwnerID:   event.OwnerID,
+		Amount:    event.AmountMinor,
+		Currency:  event.Currency,
+		Method:    models.MethodCard,
+	}
+}
+
 func splitBrokers(raw string) []string {
 	if raw == "" {
 		return nil
@@ -0,0 +1,38 @@
+package kafka
+
+import (
+	"testing"
+
+	"contracts/events"
+	"contracts/events/fixtures"
+
+	"payment-service/internal/models"
+)
+
+func TestBookingCreatedFixtureToPayment(t *testing.T) {
+	_, event, err := events.Decode[events.BookingCreatedV1](fixtures.Load("booking_created.v1.json"))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	req := createPaymentRequest(event)
+	if req.BookingID != 42 || req.UserID != 15 {
+		t.Fatalf("booking_id/user_id: %d/%d", req.BookingID, req.UserID)
+	}
+	if req.VenueID != 7 || req.OwnerID != 4 {
+		t.Fatalf("venue_id/owner_id: %d/%d", req.VenueID, req.OwnerID)
+	}
+	if req.Amount != 300050 || req.Currency != "RUB" || req.Method != models.MethodCard {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+}
+
+func TestBookingCancelledFixtureDecodes(t *testing.T) {
+	_, event, err := events.Decode[events.BookingCancelledV1](fixtures.Load("booking_cancelled.v1.json"))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if event.BookingID != 42 {
+		t.Fatalf("booking_id: %d", event.BookingID)
+	}
+}
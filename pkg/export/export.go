@@ -0,0 +1,231 @@
+// Package export реализует потоковую выгрузку таблиц в CSV и XLSX.
+// Используется выгрузками бронирований, платежей и площадок, колонки задаёт вызывающий сервис
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat разбирает формат выгрузки, по умолчанию csv
+func ParseFormat(raw string) (Format, error) {
+	switch Format(strings.ToLower(raw)) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("неизвестный формат выгрузки: %s (csv или xlsx)", raw)
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Filename возвращает имя файла выгрузки с расширением формата
+func (f Format) Filename(name string) string {
+	return name + "." + string(f)
+}
+
+// Locale определяет формат дат, заголовки колонок и разделитель CSV
+type Locale struct {
+	lang     string
+	location *time.Location
+}
+
+// ParseLocale разбирает язык (ru, en, iso - по умолчанию) и часовой пояс IANA (по умолчанию UTC)
+func ParseLocale(lang, tz string) (Locale, error) {
+	lang = strings.ToLower(lang)
+	switch lang {
+	case "":
+		lang = "iso"
+	case "ru", "en", "iso":
+	default:
+		return Locale{}, fmt.Errorf("неизвестная локаль: %s (ru, en или iso)", lang)
+	}
+
+	location := time.UTC
+	if tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return Locale{}, fmt.Errorf("неизвестный часовой пояс: %s", tz)
+		}
+		location = loc
+	}
+	return Locale{lang: lang, location: location}, nil
+}
+
+// Location возвращает часовой пояс выгрузки
+func (l Locale) Location() *time.Location {
+	return l.location
+}
+
+// Time форматирует время по локали
+func (l Locale) Time(t time.Time) string {
+	t = t.In(l.location)
+	switch l.lang {
+	case "ru":
+		return t.Format("02.01.2006 15:04")
+	case "en":
+		return t.Format("01/02/2006 03:04 PM")
+	default:
+		return t.Format(time.RFC3339)
+	}
+}
+
+// TimePtr форматирует необязательное время, nil - пустая ячейка
+func (l Locale) TimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return l.Time(*t)
+}
+
+// Column описывает колонку выгрузки: ключ для параметра columns, русский заголовок и значение
+type Column[T any] struct {
+	Key     string
+	Title   string
+	Numeric bool // В XLSX записывается числом, а не строкой, и не экранируется от формул
+	Value   func(row T, l Locale) string
+}
+
+// SelectColumns возвращает колонки в порядке, перечисленном через запятую в raw.
+// Пустой raw - все колонки
+func SelectColumns[T any](all []Column[T], raw string) ([]Column[T], error) {
+	if strings.TrimSpace(raw) == "" {
+		return all, nil
+	}
+
+	byKey := make(map[string]Column[T], len(all))
+	for _, col := range all {
+		byKey[col.Key] = col
+	}
+
+	var selected []Column[T]
+	for _, key := range strings.Split(raw, ",") {
+		key = strings.TrimSpace(key)
+		col, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("неизвестная колонка: %s", key)
+		}
+		selected = append(selected, col)
+	}
+	return selected, nil
+}
+
+// Table пишет строки выгрузки в выбранном формате по мере поступления, не накапливая их в памяти
+type Table[T any] struct {
+	columns []Column[T]
+	locale  Locale
+	rows    rowWriter
+	values  []string
+}
+
+type rowWriter interface {
+	WriteRow(values []string, numeric []bool) error
+	Close() error
+}
+
+// NewTable создаёт выгрузку и сразу пишет строку заголовков
+func NewTable[T any](w io.Writer, format Format, sheet string, columns []Column[T], locale Locale) (*Table[T], error) {
+	var rows rowWriter
+	if format == FormatXLSX {
+		xw, err := newXLSXWriter(w, sheet)
+		if err != nil {
+			return nil, err
+		}
+		rows = xw
+	} else {
+		rows = newCSVWriter(w, locale.lang == "ru")
+	}
+
+	t := &Table[T]{columns: columns, locale: locale, rows: rows, values: make([]string, len(columns))}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Key
+		if locale.lang == "ru" {
+			header[i] = col.Title
+		}
+	}
+	if err := rows.WriteRow(header, nil); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Write пишет строку. Текстовые ячейки экранируются от формул (см. escapeCell)
+func (t *Table[T]) Write(row T) error {
+	numeric := make([]bool, len(t.columns))
+	for i, col := range t.columns {
+		t.values[i] = col.Value(row, t.locale)
+		numeric[i] = col.Numeric
+		if !col.Numeric {
+			t.values[i] = escapeCell(t.values[i])
+		}
+	}
+	return t.rows.WriteRow(t.values, numeric)
+}
+
+// formulaStart - символы, с которых Excel и LibreOffice начинают формулу
+const formulaStart = "=+-@\t\r"
+
+// escapeCell защищает от CSV-инъекции: пользовательский текст, начинающийся с символа формулы,
+// получает префикс "'", и табличный редактор показывает его как текст, а не вычисляет
+func escapeCell(v string) string {
+	if v != "" && strings.IndexByte(formulaStart, v[0]) >= 0 {
+		return "'" + v
+	}
+	return v
+}
+
+// UnescapeCell снимает префикс, добавленный при выгрузке, чтобы выгруженный файл можно было загрузить обратно
+func UnescapeCell(v string) string {
+	if len(v) > 1 && v[0] == '\'' && strings.IndexByte(formulaStart, v[1]) >= 0 {
+		return v[1:]
+	}
+	return v
+}
+
+// Close дописывает выгрузку (для XLSX - закрывающие теги и оглавление архива)
+func (t *Table[T]) Close() error {
+	return t.rows.Close()
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// newCSVWriter пишет CSV с BOM, чтобы Excel правильно определил UTF-8.
+// Для русской локали разделитель ";" - так CSV открывается в Excel без импорта
+func newCSVWriter(w io.Writer, semicolon bool) *csvWriter {
+	io.WriteString(w, "\xEF\xBB\xBF")
+	cw := csv.NewWriter(w)
+	if semicolon {
+		cw.Comma = ';'
+	}
+	return &csvWriter{w: cw}
+}
+
+func (c *csvWriter) WriteRow(values []string, _ []bool) error {
+	return c.w.Write(values)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
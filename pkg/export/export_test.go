@@ -0,0 +1,129 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testRow struct {
+	ID   string
+	Name string
+	At   time.Time
+}
+
+var testColumns = []Column[testRow]{
+	{Key: "id", Title: "ID", Numeric: true, Value: func(r testRow, _ Locale) string { return r.ID }},
+	{Key: "name", Title: "Название", Value: func(r testRow, _ Locale) string { return r.Name }},
+	{Key: "at", Title: "Время", Value: func(r testRow, l Locale) string { return l.Time(r.At) }},
+}
+
+func writeTable(t *testing.T, format Format, locale Locale, columns []Column[testRow], rows ...testRow) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	table, err := NewTable(&buf, format, "Лист", columns, locale)
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	for _, row := range rows {
+		if err := table.Write(row); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := table.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestCSVUsesLocaleSeparatorAndTime(t *testing.T) {
+	locale, err := ParseLocale("ru", "Europe/Moscow")
+	if err != nil {
+		t.Fatalf("ParseLocale: %v", err)
+	}
+	row := testRow{ID: "1", Name: "Корт", At: time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC)}
+
+	out := string(writeTable(t, FormatCSV, locale, testColumns, row))
+	if !strings.HasPrefix(out, "\xEF\xBB\xBF") {
+		t.Fatal("CSV без BOM")
+	}
+	if !strings.Contains(out, "1;Корт;02.01.2026 12:30") {
+		t.Fatalf("unexpected csv: %q", out)
+	}
+}
+
+func TestTextCellsEscapeFormulas(t *testing.T) {
+	locale, _ := ParseLocale("", "")
+	rows := []testRow{
+		{ID: "-1", Name: "=HYPERLINK(\"http://evil\")"},
+		{ID: "2", Name: "+7 900"},
+		{ID: "3", Name: "@SUM(A1)"},
+		{ID: "4", Name: "Корт - крытый"},
+	}
+
+	out := string(writeTable(t, FormatCSV, locale, testColumns[:2], rows...))
+	for _, want := range []string{`-1,"'=HYPERLINK(""http://evil"")"`, "2,'+7 900", "3,'@SUM(A1)", "4,Корт - крытый"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("нет %q в csv: %q", want, out)
+		}
+	}
+
+	xlsx := writeTable(t, FormatXLSX, locale, testColumns[:2], rows[0])
+	zr, err := zip.NewReader(bytes.NewReader(xlsx), int64(len(xlsx)))
+	if err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sheet, _ := io.ReadAll(rc)
+		rc.Close()
+		if !strings.Contains(string(sheet), "<v>-1</v>") || !strings.Contains(string(sheet), "&#39;=HYPERLINK") {
+			t.Fatalf("unexpected sheet: %s", sheet)
+		}
+	}
+
+	for _, row := range rows {
+		if got := UnescapeCell(escapeCell(row.Name)); got != row.Name {
+			t.Fatalf("UnescapeCell(escapeCell(%q)) = %q", row.Name, got)
+		}
+	}
+}
+
+func TestSelectColumnsKeepsRequestedOrder(t *testing.T) {
+	columns, err := SelectColumns(testColumns, "name, id")
+	if err != nil {
+		t.Fatalf("SelectColumns: %v", err)
+	}
+	if len(columns) != 2 || columns[0].Key != "name" || columns[1].Key != "id" {
+		t.Fatalf("unexpected columns: %+v", columns)
+	}
+	if _, err := SelectColumns(testColumns, "id,unknown"); err == nil {
+		t.Fatal("ожидалась ошибка для неизвестной колонки")
+	}
+}
+
+func TestXLSXIsValidZip(t *testing.T) {
+	locale, _ := ParseLocale("", "")
+	out := writeTable(t, FormatXLSX, locale, testColumns, testRow{ID: "5", Name: "<Зал>"})
+
+	zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	if err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if !strings.Contains(strings.Join(names, ","), "xl/worksheets/sheet1.xml") {
+		t.Fatalf("нет листа: %v", names)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"reservation/internal/config"
@@ -23,7 +24,7 @@ func main() {
 
 	db := config.SetUpDatabaseConnection()
 
-	if err := db.AutoMigrate(&models.ReservationDetails{}); err != nil {
+	if err := db.AutoMigrate(&models.ReservationDetails{}, &models.BookingReminder{}, &models.ClientIncident{}); err != nil {
 		log.Fatal("Ошибка миграции базы данных:", err)
 	}
 
@@ -44,16 +45,33 @@ func main() {
 	if venueServiceURL == "" {
 		log.Fatal("VENUE_SERVICE_URL не задан в переменных окружения")
 	}
-	bookingServ := service.NewBookingServ(bookingRepo, producer, venueServiceURL, db)
+	reliabilityRepo := repository.NewReliabilityRepo(db)
+	bookingServ := service.NewBookingServ(bookingRepo, reliabilityRepo, producer, venueServiceURL, db)
+	reliabilityServ := service.NewReliabilityService(reliabilityRepo)
+
+	reminderCfg, err := config.LoadReminderConfig()
+	if err != nil {
+		log.Fatal("Ошибка настройки напоминаний:", err)
+	}
+	reminderScheduler := service.NewReminderScheduler(repository.NewReminderRepo(db), producer, reminderCfg)
+	reminderScheduler.Start(context.Background())
 
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
 		log.Fatal("JWT_SECRET не задан в переменных окружения")
 	}
 
+	checkInCfg, err := config.LoadCheckInConfig(jwtSecret)
+	if err != nil {
+		log.Fatal("Ошибка настройки отметки о приходе:", err)
+	}
+	checkInServ := service.NewCheckInService(bookingRepo, checkInCfg)
+	statusScheduler := service.NewBookingStatusScheduler(bookingRepo, checkInCfg.StatusInterval)
+	statusScheduler.Start(context.Background())
+
 	r := gin.Default()
 
-	transport.RegisterRoutes(r, bookingServ, jwtSecret)
+	transport.RegisterRoutes(r, bookingServ, checkInServ, reliabilityServ, jwtSecret)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// CheckInConfig - настройки отметки о приходе по QR-коду
+type CheckInConfig struct {
+	Secret         string        // Ключ подписи токенов (по умолчанию JWT_SECRET)
+	TokenTTL       time.Duration // Время жизни токена в QR-коде
+	EarlyWindow    time.Duration // За сколько до начала брони открывается отметка
+	StatusInterval time.Duration // Период перевода завершившихся броней в completed/no_show
+}
+
+// LoadCheckInConfig читает настройки из окружения: CHECKIN_SECRET, CHECKIN_TOKEN_TTL (10m),
+// CHECKIN_EARLY_WINDOW (30m), BOOKING_STATUS_INTERVAL (5m)
+func LoadCheckInConfig(jwtSecret string) (CheckInConfig, error) {
+	cfg := CheckInConfig{
+		Secret:         os.Getenv("CHECKIN_SECRET"),
+		TokenTTL:       10 * time.Minute,
+		EarlyWindow:    30 * time.Minute,
+		StatusInterval: 5 * time.Minute,
+	}
+	if cfg.Secret == "" {
+		cfg.Secret = jwtSecret
+	}
+
+	durations := []struct {
+		env    string
+		target *time.Duration
+	}{
+		{"CHECKIN_TOKEN_TTL", &cfg.TokenTTL},
+		{"CHECKIN_EARLY_WINDOW", &cfg.EarlyWindow},
+		{"BOOKING_STATUS_INTERVAL", &cfg.StatusInterval},
+	}
+	for _, d := range durations {
+		raw := os.Getenv(d.env)
+		if raw == "" {
+			continue
+		}
+		value, err := time.ParseDuration(raw)
+		if err != nil || value <= 0 {
+			return cfg, fmt.Errorf("неверное значение %s: %q", d.env, raw)
+		}
+		*d.target = value
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReminderConfig - настройки планировщика напоминаний о бронях
+type ReminderConfig struct {
+	Offsets   []time.Duration // За сколько до начала брони отправлять напоминания
+	Interval  time.Duration   // Период опроса БД
+	BatchSize int             // Сколько броней обрабатывать за один проход на каждое смещение
+	MaxDelay  time.Duration   // Напоминание, опоздавшее больше чем на MaxDelay (например, после простоя), пропускается
+}
+
+// LoadReminderConfig читает настройки напоминаний из окружения:
+// REMINDER_OFFSETS (например, "24h,2h"), REMINDER_INTERVAL (по умолчанию 1m),
+// REMINDER_MAX_DELAY (по умолчанию 30m)
+func LoadReminderConfig() (ReminderConfig, error) {
+	cfg := ReminderConfig{
+		Interval:  time.Minute,
+		BatchSize: 100,
+		MaxDelay:  30 * time.Minute,
+	}
+
+	rawOffsets := os.Getenv("REMINDER_OFFSETS")
+	if rawOffsets == "" {
+		rawOffsets = "24h,2h"
+	}
+	for _, part := range strings.Split(rawOffsets, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		offset, err := time.ParseDuration(part)
+		if err != nil || offset < time.Minute {
+			return cfg, fmt.Errorf("неверное значение REMINDER_OFFSETS: %q", part)
+		}
+		cfg.Offsets = append(cfg.Offsets, offset)
+	}
+	// Сначала обрабатываем ближайшие напоминания
+	sort.Slice(cfg.Offsets, func(i, j int) bool { return cfg.Offsets[i] < cfg.Offsets[j] })
+
+	if rawInterval := os.Getenv("REMINDER_INTERVAL"); rawInterval != "" {
+		interval, err := time.ParseDuration(rawInterval)
+		if err != nil || interval <= 0 {
+			return cfg, fmt.Errorf("неверное значение REMINDER_INTERVAL: %q", rawInterval)
+		}
+		cfg.Interval = interval
+	}
+
+	if rawDelay := os.Getenv("REMINDER_MAX_DELAY"); rawDelay != "" {
+		delay, err := time.ParseDuration(rawDelay)
+		if err != nil || delay <= 0 {
+			return cfg, fmt.Errorf("неверное значение REMINDER_MAX_DELAY: %q", rawDelay)
+		}
+		cfg.MaxDelay = delay
+	}
+	if cfg.MaxDelay < cfg.Interval {
+		return cfg, fmt.Errorf("REMINDER_MAX_DELAY не может быть меньше REMINDER_INTERVAL")
+	}
+
+	return cfg, nil
+}
@@ -11,60 +11,104 @@ type ReservationCreate struct {
 	OwnerID  uint          `json:"owner_id" binding:"required,min=1"`
 	StartAt  time.Time     `json:"start_at" binding:"required"`
 	EndAt    time.Time     `json:"end_at" binding:"required"`
+	// Количество мест; учитывается только для площадок с вместимостью больше 1
+	PartySize int `json:"party_size" binding:"omitempty,min=1"`
+	// Единица площадки; если не указана для площадки с единицами - выбирается любая свободная
+	UnitID *uint `json:"unit_id,omitempty" binding:"omitempty,min=1"`
 
 	Status   models.Status `json:"status" binding:"required"`
 }
 
+// UpcomingBookingsResponse - число действующих броней площадки, которые ещё не закончились
+type UpcomingBookingsResponse struct {
+	VenueID uint  `json:"venue_id"`
+	Count   int64 `json:"count"`
+}
+
+// CheckInRequest - отметка о приходе по токену из QR-кода, venue_id - площадка, на которой проходит проверка
+type CheckInRequest struct {
+	Token   string `json:"token" binding:"required"`
+	VenueID uint   `json:"venue_id" binding:"required,min=1"`
+}
+
+// CheckInTokenResponse - токен для QR-кода отметки о приходе
+type CheckInTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type ReservationCancel struct {
 	Reason string `json:"reason" binding:"required"`
 }
 
 type ReservationUpdate struct {
-	VenueID  *uint      `json:"venue_id,omitempty"`
-	ClientID *uint      `json:"client_id,omitempty"`
-	OwnerID  *uint      `json:"owner_id,omitempty"`
-	StartAt  *time.Time `json:"start_at,omitempty"`
-	EndAt    *time.Time `json:"end_at,omitempty"`
-	Price    *float64   `json:"price_cents,omitempty"`
+	VenueID   *uint      `json:"venue_id,omitempty"`
+	ClientID  *uint      `json:"client_id,omitempty"`
+	OwnerID   *uint      `json:"owner_id,omitempty"`
+	StartAt   *time.Time `json:"start_at,omitempty"`
+	EndAt     *time.Time `json:"end_at,omitempty"`
+	Price     *float64   `json:"price_cents,omitempty"`
+	PartySize *int       `json:"party_size,omitempty"`
+	UnitID    *uint      `json:"unit_id,omitempty"`
 }
 
-type BookingCreatedEvent struct {
-	EventID   string    `json:"event_id"`
-	CreatedAt time.Time `json:"created_at"`
-
-	BookingID uint      `json:"booking_id"`
-	VenueID   uint      `json:"venue_id"`
-	ClientID  uint      `json:"client_id"`
+type ResponsVenueServ struct {
+	ID        uint      `json:"id"`
 	OwnerID   uint      `json:"owner_id"`
 	StartAt   time.Time `json:"start_at"`
 	EndAt     time.Time `json:"end_at"`
+	HourPrice float64         `json:"hour_price"`
+	Capacity  int             `json:"capacity"`
+	Status    string          `json:"status"` // статус модерации, бронировать можно только published
+	Revision  int             `json:"revision"` // версия цены и расписания, по которой считается цена
+	Units     []VenueUnitResp `json:"units"`
 
-	Price  float64       `json:"price_cents"`
-	Status models.Status `json:"status"`
+	BookingRules BookingRulesResp `json:"booking_rules"`
 }
 
-type BookingCancelledEvent struct {
-	EventID   string    `json:"event_id"`
-	CreatedAt time.Time `json:"created_at"`
-
-	BookingID uint          `json:"booking_id"`
-	Reason    string        `json:"reason"`
-	Status    models.Status `json:"status"`
+// BookingRulesResp - правила бронирования площадки в ответе от venue-service.
+// Нулевые MaxAdvanceDays, MaxDurationMinutes и StartStepMinutes означают отсутствие ограничения
+type BookingRulesResp struct {
+	MinLeadMinutes     int `json:"min_lead_minutes"`
+	MaxAdvanceDays     int `json:"max_advance_days"`
+	MinDurationMinutes int `json:"min_duration_minutes"`
+	MaxDurationMinutes int `json:"max_duration_minutes"`
+	StartStepMinutes   int `json:"start_step_minutes"`
+
+	// Подтверждённая бронь без отметки о приходе завершается неявкой
+	CheckInRequired bool `json:"check_in_required"`
+
+	// Политика надёжности клиентов, ReliabilityLimit = 0 - отключена
+	LateCancelHours       int    `json:"late_cancel_hours"`
+	ReliabilityLimit      int    `json:"reliability_limit"`
+	ReliabilityPeriodDays int    `json:"reliability_period_days"`
+	ReliabilityAction     string `json:"reliability_action"`
 }
 
-type ResponsVenueServ struct {
-	ID        uint      `json:"id"`
-	OwnerID   uint      `json:"owner_id"`
-	StartAt   time.Time `json:"start_at"`
-	EndAt     time.Time `json:"end_at"`
-	HourPrice float64   `json:"price_cents"`
+// VenueUnitResp - бронируемая единица площадки в ответе от venue-service.
+// HourPrice = nil означает, что используется цена площадки
+type VenueUnitResp struct {
+	ID        uint     `json:"id"`
+	ParentID  *uint    `json:"parent_id,omitempty"`
+	Name      string   `json:"name"`
+	HourPrice *float64 `json:"hour_price,omitempty"`
+	IsActive  *bool    `json:"is_active,omitempty"`
 }
 
 // DayScheduleDTO - DTO для расписания одного дня недели (совместимо с venue-service)
+// Если день разбит на несколько интервалов, start_time/end_time - границы первого и последнего
 type DayScheduleDTO struct {
 	Enabled   bool    `json:"enabled"`
 	StartTime *string `json:"start_time,omitempty"`
 	EndTime   *string `json:"end_time,omitempty"`
+
+	Intervals []TimeIntervalDTO `json:"intervals,omitempty"`
+}
+
+// TimeIntervalDTO - интервал работы площадки внутри дня в формате "HH:MM"
+type TimeIntervalDTO struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
 }
 
 // WeekdaysDTO - DTO для расписания всех дней недели (совместимо с venue-service)
@@ -78,23 +122,41 @@ type WeekdaysDTO struct {
 	Sunday    DayScheduleDTO `json:"sunday"`
 }
 
-// VenueScheduleResp - расписание в ответе от venue-service
-type VenueScheduleResp struct {
-	Weekdays WeekdaysDTO `json:"weekdays"`
-}
-
 // ResponsVenueServFull - расширенный ответ с расписанием (используется при получении данных от venue-service)
 type ResponsVenueServFull struct {
-	ID        uint              `json:"id"`
-	OwnerID   uint              `json:"owner_id"`
-	StartAt   time.Time         `json:"start_at"`
-	EndAt     time.Time         `json:"end_at"`
-	HourPrice float64           `json:"price_cents"`
-	Weekdays  VenueScheduleResp `json:"weekdays"`
+	ID        uint            `json:"id"`
+	OwnerID   uint            `json:"owner_id"`
+	StartAt   time.Time       `json:"start_at"`
+	EndAt     time.Time       `json:"end_at"`
+	HourPrice float64         `json:"hour_price"`
+	Capacity  int             `json:"capacity"`
+	Status    string          `json:"status"` // статус модерации, бронировать можно только published
+	Units     []VenueUnitResp `json:"units"`
+	Weekdays  WeekdaysDTO     `json:"weekdays"`
+
+	BookingRules BookingRulesResp `json:"booking_rules"`
 }
 
-// AvailableSlot - свободный временной отрезок площадки на дату
+// AvailableSlot - свободный временной отрезок площадки на дату.
+// RemainingSpots заполняется только для площадок с вместимостью больше 1,
+// UnitID - только для площадок, разделённых на единицы
 type AvailableSlot struct {
-	StartAt time.Time `json:"start_at"`
-	EndAt   time.Time `json:"end_at"`
+	StartAt        time.Time `json:"start_at"`
+	EndAt          time.Time `json:"end_at"`
+	RemainingSpots int       `json:"remaining_spots,omitempty"`
+	UnitID         *uint     `json:"unit_id,omitempty"`
+}
+
+// ForgiveIncidentRequest - прощение нарушения клиента
+type ForgiveIncidentRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+// ClientReliabilityResponse - сводка надёжности клиента: непрощённые нарушения и полная история
+type ClientReliabilityResponse struct {
+	ClientID          uint                    `json:"client_id"`
+	NoShows           int                     `json:"no_shows"`
+	LateCancellations int                     `json:"late_cancellations"`
+	Forgiven          int                     `json:"forgiven"`
+	Incidents         []models.ClientIncident `json:"incidents"`
 }
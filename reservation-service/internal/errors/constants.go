@@ -18,5 +18,21 @@ var (
 	ErrOnlyPendingReservations = errors.New("only pending reservations can be updated")
 	ErrNotOwner                = errors.New("you are not the owner of this venue")
 	ErrForbidden			   = errors.New("forbidden access to the resource")
-	ErrDuration             = errors.New("минимальная длительность бронирования - 1 час")
+	ErrDuration             = errors.New("недопустимая длительность бронирования")
+	ErrLeadTime             = errors.New("бронирование делается слишком поздно")
+	ErrBookingHorizon       = errors.New("бронирование делается слишком далеко вперед")
+	ErrStartAlignment       = errors.New("недопустимое время начала бронирования")
+	ErrPartySize            = errors.New("party size must be greater than zero")
+	ErrCapacityExceeded     = errors.New("недостаточно свободных мест на площадке в выбранный период")
+	ErrUnitNotFound         = errors.New("unit not found in this venue")
+	ErrNoFreeUnit           = errors.New("в выбранный период нет свободных единиц площадки")
+	ErrCheckInToken         = errors.New("invalid or expired check-in token")
+	ErrCheckInNotAllowed    = errors.New("check-in is available only for confirmed bookings")
+	ErrCheckInWindow        = errors.New("check-in is not open for this booking")
+	ErrAlreadyCheckedIn     = errors.New("booking is already checked in")
+	ErrWrongVenue           = errors.New("booking belongs to another venue")
+	ErrClientBlocked        = errors.New("бронирование недоступно: слишком много неявок и поздних отмен")
+	ErrIncidentNotFound     = errors.New("incident not found")
+	ErrIncidentForgiven     = errors.New("incident is already forgiven")
+	ErrVenueNotPublished    = errors.New("площадка не опубликована и недоступна для бронирования")
 )
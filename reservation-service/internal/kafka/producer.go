@@ -2,25 +2,22 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"reservation/internal/dto"
 	"time"
 
+	"contracts/events"
+
 	kafkago "github.com/segmentio/kafka-go"
 )
 
+// Producer публикует события бронирования в формате общих контрактов (contracts/events)
 type Producer interface {
-	PublishBookingCreated(ctx context.Context, evt dto.BookingCreatedEvent) error
-	PublishBookingCancelled(ctx context.Context, evt dto.BookingCancelledEvent) error
+	PublishBookingCreated(ctx context.Context, meta events.Meta, evt events.BookingCreatedV1) error
+	PublishBookingCancelled(ctx context.Context, meta events.Meta, evt events.BookingCancelledV1) error
+	PublishBookingReminder(ctx context.Context, meta events.Meta, evt events.BookingReminderV1) error
 	Close() error
 }
 
-const (
-	TopicBookingCreated   = "booking.created"
-	TopicBookingCancelled = "booking.cancelled"
-)
-
 type kafkaGoProducer struct {
 	writer *kafkago.Writer
 }
@@ -35,27 +32,31 @@ func NewProducer(brokers []string) Producer {
 	}
 }
 
-func (p *kafkaGoProducer) PublishBookingCreated(ctx context.Context, evt dto.BookingCreatedEvent) error {
-	return p.writeJSON(ctx, TopicBookingCreated, fmt.Sprintf("%d", evt.BookingID), evt)
+func (p *kafkaGoProducer) PublishBookingCreated(ctx context.Context, meta events.Meta, evt events.BookingCreatedV1) error {
+	return p.writeEvent(ctx, events.TopicBookingCreated, evt.BookingID, meta, evt)
 }
 
-func (p *kafkaGoProducer) PublishBookingCancelled (ctx context.Context, evt dto.BookingCancelledEvent)error {
-	return p.writeJSON(ctx, TopicBookingCancelled, fmt.Sprintf("%d", evt.BookingID), evt)
+func (p *kafkaGoProducer) PublishBookingCancelled(ctx context.Context, meta events.Meta, evt events.BookingCancelledV1) error {
+	return p.writeEvent(ctx, events.TopicBookingCancelled, evt.BookingID, meta, evt)
 }
 
-func (p *kafkaGoProducer) writeJSON(ctx context.Context, topic, key string, v any) error {
-	// 1. Превращаем структуру в JSON
-	b, err := json.Marshal(v)
+func (p *kafkaGoProducer) PublishBookingReminder(ctx context.Context, meta events.Meta, evt events.BookingReminderV1) error {
+	return p.writeEvent(ctx, events.TopicBookingReminder, evt.BookingID, meta, evt)
+}
+
+// writeEvent упаковывает событие в конверт и отправляет его. Ключ - ID брони,
+// чтобы события одной брони попадали в одну партицию и читались по порядку
+func (p *kafkaGoProducer) writeEvent(ctx context.Context, topic string, bookingID uint, meta events.Meta, evt events.Event) error {
+	b, err := events.Encode(meta, evt)
 	if err != nil {
 		return err
 	}
 
-	// 2. Создаем сообщение для Kafka
 	msg := kafkago.Message{
-		Topic: topic,       // В какой топик отправить
-		Key:   []byte(key), // Ключ (для порядка)
-		Value: b,           // Сами данные (JSON)
-		Time:  time.Now(),  // Время отправки
+		Topic: topic,
+		Key:   []byte(fmt.Sprintf("%d", bookingID)),
+		Value: b,
+		Time:  time.Now(),
 	}
 
 	return p.writer.WriteMessages(ctx, msg)
@@ -63,4 +64,4 @@ func (p *kafkaGoProducer) writeJSON(ctx context.Context, topic, key string, v an
 
 func (p *kafkaGoProducer) Close() error {
 	return p.writer.Close()
-}
\ No newline at end of file
+}
@@ -11,6 +11,7 @@ const (
 	Confirmed Status = "confirmed"
 	Cancelled Status = "cancelled"
 	Completed Status = "completed"
+	NoShow    Status = "no_show" // Подтверждённая бронь завершилась без отметки о приходе
 )
 
 type ReservationDetails struct {
@@ -21,9 +22,16 @@ type ReservationDetails struct {
 	StartAt         time.Time     `json:"start_at" gorm:"not null"`
 	EndAt           time.Time     `json:"end_at" gorm:"not null"`
 	Price           float64       `json:"price_cents,omitempty"`
+	VenueRevision   int           `json:"venue_revision,omitempty" gorm:"not null;default:0"` // Версия цены и расписания площадки, по которой рассчитана цена (0 - бронь создана до появления версий)
+	PartySize       int           `json:"party_size" gorm:"not null;default:1"`
+	UnitID          *uint         `json:"unit_id,omitempty" gorm:"index"` // Забронированная единица площадки (корт, половина поля)
 	Duration        time.Duration `json:"duration_minutes,omitempty"`
 	ReasonForCancel string        `json:"reason_for_cancel,omitempty"`
 	Status          Status        `json:"status"`
+	CheckedInAt     *time.Time    `json:"checked_in_at,omitempty"` // Время отметки о приходе по QR-коду
+
+	PrepaymentRequired bool `json:"prepayment_required" gorm:"not null;default:false"` // Бронь подтверждается только после оплаты (политика надёжности площадки)
+	CheckInRequired    bool `json:"check_in_required" gorm:"not null;default:false"`   // Площадка требовала отметку о приходе на момент брони: без неё бронь завершается неявкой
 }
 
 type Reservation struct {
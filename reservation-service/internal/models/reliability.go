@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type IncidentKind string
+
+const (
+	IncidentNoShow     IncidentKind = "no_show"     // Клиент не пришёл на подтверждённую бронь
+	IncidentLateCancel IncidentKind = "late_cancel" // Клиент отменил бронь незадолго до начала
+)
+
+// ClientIncident - нарушение клиента (неявка или поздняя отмена), по одному на бронь.
+// Прощённые нарушения не учитываются политикой надёжности площадок
+type ClientIncident struct {
+	Base
+	ClientID      uint         `json:"client_id" gorm:"not null;index"`
+	VenueID       uint         `json:"venue_id" gorm:"not null"`
+	OwnerID       uint         `json:"owner_id" gorm:"not null"`
+	BookingID     uint         `json:"booking_id" gorm:"not null;uniqueIndex"`
+	Kind          IncidentKind `json:"kind" gorm:"type:varchar(20);not null"`
+	OccurredAt    time.Time    `json:"occurred_at" gorm:"not null;index"`
+	ForgivenAt    *time.Time   `json:"forgiven_at,omitempty"`
+	ForgivenBy    *uint        `json:"forgiven_by,omitempty"`
+	ForgiveReason string       `json:"forgive_reason,omitempty"`
+}
+
+// Действия политики надёжности площадки (reliability_action в правилах бронирования)
+const (
+	ReliabilityBlock  = "block"  // Бронирование запрещено
+	ReliabilityPrepay = "prepay" // Бронь создаётся только с предоплатой
+)
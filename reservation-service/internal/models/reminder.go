@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// BookingReminder фиксирует отправленное (или пропущенное) напоминание о брони.
+// Уникальный индекс по брони, смещению и времени начала гарантирует, что напоминание
+// за одно и то же смещение не будет отправлено повторно; после переноса брони
+// (другой start_at) напоминания отправляются заново
+type BookingReminder struct {
+	ID            uint      `json:"id" gorm:"primarykey"`
+	BookingID     uint      `json:"booking_id" gorm:"not null;uniqueIndex:idx_booking_reminder_once"`
+	OffsetMinutes int       `json:"offset_minutes" gorm:"not null;uniqueIndex:idx_booking_reminder_once"`
+	StartAt       time.Time `json:"start_at" gorm:"not null;uniqueIndex:idx_booking_reminder_once"`
+	Skipped       bool      `json:"skipped" gorm:"not null;default:false"` // Бронь создана позже момента напоминания
+	CreatedAt     time.Time `json:"created_at"`
+}
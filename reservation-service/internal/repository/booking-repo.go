@@ -3,16 +3,32 @@ package repository
 import (
 	"errors"
 	"reservation/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BookingRepo interface {
 	GetByID(id uint) (*models.ReservationDetails, error)
 	GetUserReservations(userID uint) ([]models.Reservation, error)
 	GetVenueBookings(venueID uint) ([]models.ReservationDetails, error)
+	CountUpcomingBookings(venueID uint, now time.Time) (int64, error)
+	GetOverlappingBookings(venueID uint, startAt, endAt time.Time, excludeID *uint) ([]models.ReservationDetails, error)
 	Create(reservation *models.ReservationDetails) error
 	Save(reservation *models.ReservationDetails) error
+	Cancel(reservation *models.ReservationDetails, incident *models.ClientIncident) error
+	StreamBookings(filter BookingExportFilter, handle func(models.ReservationDetails) error) error
+	CheckIn(id uint, at time.Time) (bool, error)
+	CloseFinishedBookings(now time.Time) (completed int64, noShow int64, err error)
+}
+
+// BookingExportFilter - условия выгрузки броней. Нулевые значения не ограничивают выборку
+type BookingExportFilter struct {
+	VenueID uint
+	OwnerID uint
+	From    time.Time // Начало брони >= From
+	To      time.Time // Начало брони < To
 }
 
 type gormBookingRepo struct {
@@ -63,6 +79,19 @@ func (r *gormBookingRepo) Save(reservation *models.ReservationDetails) error {
 	return result.Error
 }
 
+// Cancel сохраняет отменённую бронь вместе с нарушением клиента (если incident != nil) в одной транзакции
+func (r *gormBookingRepo) Cancel(reservation *models.ReservationDetails, incident *models.ClientIncident) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(reservation).Error; err != nil {
+			return err
+		}
+		if incident == nil {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(incident).Error
+	})
+}
+
 func (r *gormBookingRepo) GetVenueBookings(venueID uint) ([]models.ReservationDetails, error) {
 	var bookings []models.ReservationDetails
 
@@ -73,3 +102,146 @@ func (r *gormBookingRepo) GetVenueBookings(venueID uint) ([]models.ReservationDe
 
 	return bookings, nil
 }
+
+// CountUpcomingBookings считает действующие брони площадки, которые ещё не закончились
+func (r *gormBookingRepo) CountUpcomingBookings(venueID uint, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ReservationDetails{}).
+		Where("venue_id = ? AND status IN ? AND end_at > ?", venueID, []models.Status{models.Pending, models.Confirmed}, now).
+		Count(&count).Error
+	return count, err
+}
+
+// GetOverlappingBookings возвращает неотменённые брони площадки, пересекающиеся с интервалом [startAt, endAt).
+// Если excludeID != nil, бронь с этим id не учитывается (используется при обновлении).
+func (r *gormBookingRepo) GetOverlappingBookings(venueID uint, startAt, endAt time.Time, excludeID *uint) ([]models.ReservationDetails, error) {
+	var bookings []models.ReservationDetails
+
+	q := r.db.Where("venue_id = ? AND status <> ? AND start_at < ? AND end_at > ?", venueID, models.Cancelled, endAt, startAt)
+	if excludeID != nil {
+		q = q.Where("id <> ?", *excludeID)
+	}
+
+	if err := q.Find(&bookings).Error; err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// StreamBookings построчно читает брони курсором и передаёт их в handle, не загружая выборку целиком.
+// Ошибка handle прерывает чтение
+func (r *gormBookingRepo) StreamBookings(filter BookingExportFilter, handle func(models.ReservationDetails) error) error {
+	q := r.db.Model(&models.ReservationDetails{})
+	if filter.VenueID > 0 {
+		q = q.Where("venue_id = ?", filter.VenueID)
+	}
+	if filter.OwnerID > 0 {
+		q = q.Where("owner_id = ?", filter.OwnerID)
+	}
+	if !filter.From.IsZero() {
+		q = q.Where("start_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		q = q.Where("start_at < ?", filter.To)
+	}
+
+	rows, err := q.Order("start_at ASC").Order("id ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var booking models.ReservationDetails
+		if err := r.db.ScanRows(rows, &booking); err != nil {
+			return err
+		}
+		if err := handle(booking); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// CheckIn отмечает приход по подтверждённой брони. Условие checked_in_at IS NULL в самом UPDATE
+// не даёт отметить бронь дважды при одновременных запросах. false - бронь уже отмечена или не подтверждена
+func (r *gormBookingRepo) CheckIn(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.ReservationDetails{}).
+		Where("id = ? AND status = ? AND checked_in_at IS NULL", id, models.Confirmed).
+		Update("checked_in_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CloseFinishedBookings переводит завершившиеся подтверждённые брони в no_show, если площадка требует
+// отметку о приходе, а отметки не было. Остальные (с отметкой и на площадках без обязательной отметки)
+// становятся completed. Для каждой неявки в той же транзакции фиксируется нарушение клиента
+func (r *gormBookingRepo) CloseFinishedBookings(now time.Time) (int64, int64, error) {
+	var completed, noShow int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var finished []models.ReservationDetails
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ? AND end_at <= ?", models.Confirmed, now).
+			Find(&finished).Error
+		if err != nil {
+			return err
+		}
+
+		completedIDs, incidents := splitFinishedBookings(finished)
+		if len(completedIDs) > 0 {
+			result := tx.Model(&models.ReservationDetails{}).
+				Where("id IN ?", completedIDs).
+				Update("status", models.Completed)
+			if result.Error != nil {
+				return result.Error
+			}
+			completed = result.RowsAffected
+		}
+		if len(incidents) == 0 {
+			return nil
+		}
+
+		noShowIDs := make([]uint, 0, len(incidents))
+		for _, incident := range incidents {
+			noShowIDs = append(noShowIDs, incident.BookingID)
+		}
+		result := tx.Model(&models.ReservationDetails{}).
+			Where("id IN ?", noShowIDs).
+			Update("status", models.NoShow)
+		if result.Error != nil {
+			return result.Error
+		}
+		noShow = result.RowsAffected
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&incidents).Error
+	})
+
+	return completed, noShow, err
+}
+
+// splitFinishedBookings делит завершившиеся брони на completed и неявки. Неявка - бронь на площадке
+// с обязательной отметкой о приходе, по которой отметки не было: для неё создаётся нарушение клиента.
+// Требование берётся из брони, а не из действий клиента, поэтому избежать неявки, не открыв QR, нельзя
+func splitFinishedBookings(bookings []models.ReservationDetails) ([]uint, []models.ClientIncident) {
+	var completedIDs []uint
+	var incidents []models.ClientIncident
+	for _, b := range bookings {
+		if b.CheckedInAt != nil || !b.CheckInRequired {
+			completedIDs = append(completedIDs, b.ID)
+			continue
+		}
+		incidents = append(incidents, models.ClientIncident{
+			ClientID:   b.ClientID,
+			VenueID:    b.VenueID,
+			OwnerID:    b.OwnerID,
+			BookingID:  b.ID,
+			Kind:       models.IncidentNoShow,
+			OccurredAt: b.EndAt,
+		})
+	}
+	return completedIDs, incidents
+}
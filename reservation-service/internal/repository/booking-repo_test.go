@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"reservation/internal/models"
+	"testing"
+	"time"
+)
+
+func TestSplitFinishedBookings(t *testing.T) {
+	endAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	checkedInAt := endAt.Add(-90 * time.Minute)
+
+	bookings := []models.ReservationDetails{
+		// Площадка не требует отметки о приходе
+		{Base: models.Base{ID: 1}, ClientID: 10, VenueID: 3, OwnerID: 7, EndAt: endAt},
+		// Отметка обязательна, приход отмечен
+		{Base: models.Base{ID: 2}, ClientID: 11, VenueID: 3, OwnerID: 7, EndAt: endAt, CheckInRequired: true, CheckedInAt: &checkedInAt},
+		// Отметка обязательна, прихода не было
+		{Base: models.Base{ID: 3}, ClientID: 12, VenueID: 3, OwnerID: 7, EndAt: endAt, CheckInRequired: true},
+	}
+
+	completedIDs, incidents := splitFinishedBookings(bookings)
+
+	if len(completedIDs) != 2 || completedIDs[0] != 1 || completedIDs[1] != 2 {
+		t.Fatalf("completed = %v, want [1 2]", completedIDs)
+	}
+	if len(incidents) != 1 {
+		t.Fatalf("incidents = %+v, want one no_show", incidents)
+	}
+	incident := incidents[0]
+	if incident.BookingID != 3 || incident.ClientID != 12 || incident.VenueID != 3 || incident.OwnerID != 7 {
+		t.Fatalf("unexpected incident: %+v", incident)
+	}
+	if incident.Kind != models.IncidentNoShow || !incident.OccurredAt.Equal(endAt) {
+		t.Fatalf("kind/occurred_at: %s/%s", incident.Kind, incident.OccurredAt)
+	}
+}
+
+// Клиент, который не открывал QR-код и не пришёл, на площадке с обязательной отметкой - неявка
+func TestSplitFinishedBookingsWithoutCheckInCreatesIncident(t *testing.T) {
+	bookings := []models.ReservationDetails{
+		{Base: models.Base{ID: 5}, ClientID: 10, VenueID: 3, OwnerID: 7, EndAt: time.Now(), CheckInRequired: true},
+	}
+
+	completedIDs, incidents := splitFinishedBookings(bookings)
+
+	if len(completedIDs) != 0 {
+		t.Fatalf("completed = %v, want none", completedIDs)
+	}
+	if len(incidents) != 1 || incidents[0].BookingID != 5 || incidents[0].Kind != models.IncidentNoShow {
+		t.Fatalf("incidents = %+v, want no_show for booking 5", incidents)
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"reservation/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReliabilityRepo interface {
+	CountActiveIncidents(clientID uint, since time.Time) (int64, error)
+	GetClientIncidents(clientID uint) ([]models.ClientIncident, error)
+	GetIncident(id uint) (*models.ClientIncident, error)
+	Forgive(id, by uint, reason string, at time.Time) (bool, error)
+}
+
+type gormReliabilityRepo struct {
+	db *gorm.DB
+}
+
+func NewReliabilityRepo(db *gorm.DB) ReliabilityRepo {
+	return &gormReliabilityRepo{db: db}
+}
+
+// CountActiveIncidents считает непрощённые нарушения клиента начиная с since (нулевое - за всё время)
+func (r *gormReliabilityRepo) CountActiveIncidents(clientID uint, since time.Time) (int64, error) {
+	query := r.db.Model(&models.ClientIncident{}).Where("client_id = ? AND forgiven_at IS NULL", clientID)
+	if !since.IsZero() {
+		query = query.Where("occurred_at >= ?", since)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetClientIncidents возвращает все нарушения клиента, включая прощённые, новые сначала
+func (r *gormReliabilityRepo) GetClientIncidents(clientID uint) ([]models.ClientIncident, error) {
+	var incidents []models.ClientIncident
+	if err := r.db.Where("client_id = ?", clientID).Order("occurred_at DESC, id DESC").Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+func (r *gormReliabilityRepo) GetIncident(id uint) (*models.ClientIncident, error) {
+	var incident models.ClientIncident
+	if err := r.db.First(&incident, id).Error; err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// Forgive прощает нарушение. Условие forgiven_at IS NULL не даёт перезаписать причину повторным запросом.
+// false - нарушение уже прощено
+func (r *gormReliabilityRepo) Forgive(id, by uint, reason string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.ClientIncident{}).
+		Where("id = ? AND forgiven_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"forgiven_at":    at,
+			"forgiven_by":    by,
+			"forgive_reason": reason,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"reservation/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReminderSkipper решает, что напоминание по брони отправлять не нужно.
+// Пропущенное напоминание фиксируется так же, как отправленное, и больше не рассматривается
+type ReminderSkipper func(booking models.ReservationDetails) bool
+
+type ReminderRepo interface {
+	ClaimDueReminders(offset time.Duration, now time.Time, limit int, skip ReminderSkipper) ([]models.ReservationDetails, error)
+	ReleaseReminder(bookingID uint, offset time.Duration, startAt time.Time) error
+}
+
+type gormReminderRepo struct {
+	db *gorm.DB
+}
+
+func NewReminderRepo(db *gorm.DB) ReminderRepo {
+	return &gormReminderRepo{db: db}
+}
+
+// ClaimDueReminders в одной транзакции блокирует подтверждённые брони, для которых наступило
+// время напоминания за offset до начала и напоминание ещё не зафиксировано, и фиксирует напоминания.
+// FOR UPDATE SKIP LOCKED позволяет нескольким репликам работать параллельно без повторных отправок.
+// Возвращает брони, напоминание по которым нужно отправить: отправка идёт после коммита,
+// чтобы откат транзакции не приводил к повторным событиям
+func (r *gormReminderRepo) ClaimDueReminders(offset time.Duration, now time.Time, limit int, skip ReminderSkipper) ([]models.ReservationDetails, error) {
+	offsetMinutes := int(offset.Minutes())
+	var due []models.ReservationDetails
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var bookings []models.ReservationDetails
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND start_at > ? AND start_at <= ?", models.Confirmed, now, now.Add(offset)).
+			Where("NOT EXISTS (SELECT 1 FROM booking_reminders br WHERE br.booking_id = reservation_details.id AND br.offset_minutes = ? AND br.start_at = reservation_details.start_at)", offsetMinutes).
+			Order("start_at ASC").
+			Limit(limit).
+			Find(&bookings).Error
+		if err != nil {
+			return err
+		}
+
+		for _, b := range bookings {
+			skipped := skip(b)
+			reminder := models.BookingReminder{
+				BookingID:     b.ID,
+				OffsetMinutes: offsetMinutes,
+				StartAt:       b.StartAt,
+				Skipped:       skipped,
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&reminder).Error; err != nil {
+				return err
+			}
+			if !skipped {
+				due = append(due, b)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return due, nil
+}
+
+// ReleaseReminder снимает фиксацию напоминания, которое не удалось отправить,
+// чтобы оно было повторено на следующем проходе
+func (r *gormReminderRepo) ReleaseReminder(bookingID uint, offset time.Duration, startAt time.Time) error {
+	return r.db.
+		Where("booking_id = ? AND offset_minutes = ? AND start_at = ?", bookingID, int(offset.Minutes()), startAt).
+		Delete(&models.BookingReminder{}).Error
+}
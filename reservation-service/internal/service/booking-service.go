@@ -9,35 +9,39 @@ import (
 	"reservation/internal/kafka"
 	"reservation/internal/models"
 	"reservation/internal/repository"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type BookingService interface {
 	GetUserReservations(userID uint) ([]models.Reservation, error)
 	GetVenueBookings(venueID uint, claims *models.Claims) ([]models.ReservationDetails, error)
-	GetVenueAvailability(venueID uint, date time.Time) ([]dto.AvailableSlot, error)
+	CountUpcomingBookings(venueID uint) (int64, error)
+	GetVenueAvailability(venueID uint, unitID *uint, date time.Time) ([]dto.AvailableSlot, error)
 	CreateReservation(reservation *dto.ReservationCreate, claims *models.Claims) (*models.ReservationDetails, error)
-	ReservationCancel(id uint, reason string) (*models.ReservationDetails, error)
+	ReservationCancel(id uint, reason string, claims *models.Claims) (*models.ReservationDetails, error)
 	GetByID(id uint) (*models.ReservationDetails, error)
 	ReservationUpdate(id uint, reservation *dto.ReservationUpdate) (*models.ReservationDetails, error)
+	ExportBookings(venueID uint, from, to time.Time, claims *models.Claims) (BookingStream, error)
 }
 
+// BookingStream последовательно передаёт брони выгрузки в handle
+type BookingStream func(handle func(models.ReservationDetails) error) error
+
 type bookingService struct {
-	repo     repository.BookingRepo
-	producer kafka.Producer
-	client   *resty.Client
-	venueURL string
-	db       *gorm.DB
+	repo        repository.BookingRepo
+	reliability repository.ReliabilityRepo
+	producer    kafka.Producer
+	client      *resty.Client
+	venueURL    string
+	db          *gorm.DB
 }
 
-func NewBookingServ(repo repository.BookingRepo, producer kafka.Producer, venueURL string, db *gorm.DB) BookingService {
-	return &bookingService{repo: repo, producer: producer, client: resty.New(), venueURL: strings.TrimRight(venueURL, "/"), db: db}
+func NewBookingServ(repo repository.BookingRepo, reliability repository.ReliabilityRepo, producer kafka.Producer, venueURL string, db *gorm.DB) BookingService {
+	return &bookingService{repo: repo, reliability: reliability, producer: producer, client: resty.New(), venueURL: strings.TrimRight(venueURL, "/"), db: db}
 }
 
 func (r *bookingService) GetUserReservations(userID uint) ([]models.Reservation, error) {
@@ -78,6 +82,42 @@ func (r *bookingService) GetVenueBookings(venueID uint, claims *models.Claims) (
 	return bookings, nil
 }
 
+// ExportBookings проверяет права и возвращает поток броней для выгрузки.
+// venueID = 0 - все брони владельца (для администратора - все брони).
+// Права проверяются до начала выгрузки, чтобы ошибка не оборвала уже начатый файл
+func (r *bookingService) ExportBookings(venueID uint, from, to time.Time, claims *models.Claims) (BookingStream, error) {
+	if claims == nil {
+		return nil, errors.ErrForbidden
+	}
+
+	if claims.Role != models.RoleOwner && claims.Role != models.RoleAdmin {
+		return nil, errors.ErrForbidden
+	}
+
+	filter := repository.BookingExportFilter{VenueID: venueID, From: from, To: to}
+	if venueID > 0 {
+		venue, err := r.GetVenue(venueID)
+		if err != nil {
+			return nil, err
+		}
+		if claims.Role != models.RoleAdmin && venue.OwnerID != claims.UserID {
+			return nil, errors.ErrNotOwner
+		}
+	} else if claims.Role != models.RoleAdmin {
+		filter.OwnerID = claims.UserID
+	}
+
+	return func(handle func(models.ReservationDetails) error) error {
+		return r.repo.StreamBookings(filter, handle)
+	}, nil
+}
+
+// CountUpcomingBookings - число действующих будущих броней площадки. Площадку в venue-service не проверяет:
+// venue-service спрашивает об этом и для удалённых площадок
+func (r *bookingService) CountUpcomingBookings(venueID uint) (int64, error) {
+	return r.repo.CountUpcomingBookings(venueID, time.Now())
+}
+
 func (r *bookingService) GetByID(id uint) (*models.ReservationDetails, error) {
 	reservation, err := r.repo.GetByID(id)
 
@@ -115,6 +155,13 @@ func (r *bookingService) CreateReservation(reservation *dto.ReservationCreate, c
 		return nil, errors.ErrStatusEmpty
 	}
 
+	if reservation.PartySize < 0 {
+		return nil, errors.ErrPartySize
+	}
+	if reservation.PartySize == 0 {
+		reservation.PartySize = 1
+	}
+
 	if claims.Role != models.RoleClient && claims.Role != models.RoleAdmin {
 		return nil, errors.ErrInvalidRole
 	}
@@ -130,39 +177,40 @@ func (r *bookingService) CreateReservation(reservation *dto.ReservationCreate, c
 		return nil, err
 	}
 
+	prepaymentRequired, err := r.checkClientReliability(venue.BookingRules, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	price := bookingPrice(venue, reservation.UnitID, reservation.PartySize, reservation.StartAt, reservation.EndAt)
+
 	newReservation := &models.ReservationDetails{
-		ClientID: reservation.ClientID,
-		VenueID:  reservation.VenueID,
-		OwnerID:  reservation.OwnerID,
-		StartAt:  reservation.StartAt,
-		EndAt:    reservation.EndAt,
-		Price:    venue.HourPrice * reservation.EndAt.Sub(reservation.StartAt).Hours(),
-		Status:   models.Status(reservation.Status),
-		Duration: reservation.EndAt.Sub(reservation.StartAt),
+		ClientID:  reservation.ClientID,
+		VenueID:   reservation.VenueID,
+		OwnerID:   reservation.OwnerID,
+		StartAt:   reservation.StartAt,
+		EndAt:     reservation.EndAt,
+		Price:     price,
+		PartySize: reservation.PartySize,
+		UnitID:    reservation.UnitID,
+		Status:    models.Status(reservation.Status),
+		Duration:  reservation.EndAt.Sub(reservation.StartAt),
+
+		VenueRevision:   venue.Revision,
+		CheckInRequired: venue.BookingRules.CheckInRequired,
 	}
 
-	if newReservation.Duration < time.Hour {
-		return nil, errors.ErrDuration
+	// Ненадёжному клиенту бронь подтверждается только после оплаты
+	if prepaymentRequired {
+		newReservation.Status = models.Pending
+		newReservation.PrepaymentRequired = true
 	}
 
 	if err := r.repo.Create(newReservation); err != nil {
 		return nil, err
 	}
 
-	evt := dto.BookingCreatedEvent{
-		EventID:   uuid.NewString(),
-		CreatedAt: time.Now(),
-		BookingID: newReservation.ID,
-		VenueID:   newReservation.VenueID,
-		ClientID:  newReservation.ClientID,
-		OwnerID:   newReservation.OwnerID,
-		StartAt:   newReservation.StartAt,
-		EndAt:     newReservation.EndAt,
-		Price:     newReservation.Price,
-		Status:    newReservation.Status,
-	}
-
-	if err := r.producer.PublishBookingCreated(context.Background(), evt); err != nil {
+	if err := r.producer.PublishBookingCreated(context.Background(), newEventMeta(), bookingCreatedEvent(newReservation)); err != nil {
 		log.Printf("Ошибка отправки события в Kafka: %v", err)
 		return nil, fmt.Errorf("бронь создана (id=%d), но не удалось отправить событие в Kafka: %w", newReservation.ID, err)
 	}
@@ -170,32 +218,31 @@ func (r *bookingService) CreateReservation(reservation *dto.ReservationCreate, c
 	return newReservation, nil
 }
 
-func (r *bookingService) ReservationCancel(id uint, reason string) (*models.ReservationDetails, error) {
+// ReservationCancel отменяет бронь. Отмена самим клиентом позже срока из правил площадки
+// фиксируется как нарушение клиента
+func (r *bookingService) ReservationCancel(id uint, reason string, claims *models.Claims) (*models.ReservationDetails, error) {
 	reservation, err := r.repo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if reservation.Status == models.Cancelled || reservation.Status == models.Completed {
+	if reservation.Status == models.Cancelled || reservation.Status == models.Completed || reservation.Status == models.NoShow {
 		return nil, errors.ErrCannotCancel
 	}
 
+	var incident *models.ClientIncident
+	if claims != nil && claims.Role == models.RoleClient && claims.UserID == reservation.ClientID {
+		incident = r.lateCancelIncident(reservation, time.Now())
+	}
+
 	reservation.Status = models.Cancelled
 	reservation.ReasonForCancel = reason
 
-	if err := r.repo.Save(reservation); err != nil {
+	if err := r.repo.Cancel(reservation, incident); err != nil {
 		return nil, err
 	}
 
-	evt := dto.BookingCancelledEvent{
-		EventID:   uuid.NewString(),
-		CreatedAt: time.Now(),
-		BookingID: reservation.ID,
-		Reason:    reason,
-		Status:    reservation.Status,
-	}
-
-	if err := r.producer.PublishBookingCancelled(context.Background(), evt); err != nil {
+	if err := r.producer.PublishBookingCancelled(context.Background(), newEventMeta(), bookingCancelledEvent(reservation)); err != nil {
 		log.Printf("Ошибка отправки события отмены в Kafka: %v", err)
 	}
 
@@ -225,6 +272,10 @@ func (r *bookingService) ReservationUpdate(id uint, reservation *dto.Reservation
 		return nil, errors.ErrEndAtEmpty
 	}
 
+	if reservation.PartySize != nil && *reservation.PartySize <= 0 {
+		return nil, errors.ErrPartySize
+	}
+
 	// Определяем финальные значения для валидации (не мутируя reserv заранее)
 	finalStartAt := reserv.StartAt
 	if reservation.StartAt != nil {
@@ -259,6 +310,10 @@ func (r *bookingService) ReservationUpdate(id uint, reservation *dto.Reservation
 		return nil, errors.ErrOnlyPendingReservations
 	}
 
+	// Цена зависит от площадки, единицы, времени и числа мест: при их изменении она пересчитывается
+	repriced := reservation.VenueID != nil || reservation.StartAt != nil || reservation.EndAt != nil ||
+		reservation.PartySize != nil || !sameUnit(reserv.UnitID, reservation.UnitID)
+
 	if reservation.VenueID != nil {
 		reserv.VenueID = *reservation.VenueID
 	}
@@ -283,6 +338,23 @@ func (r *bookingService) ReservationUpdate(id uint, reservation *dto.Reservation
 		reserv.Price = *reservation.Price
 	}
 
+	if reservation.PartySize != nil {
+		reserv.PartySize = *reservation.PartySize
+	}
+
+	// Единица площадки определяется при валидации (в том числе при смене площадки)
+	reserv.UnitID = reservation.UnitID
+
+	// Явно переданная цена не пересчитывается
+	if repriced && reservation.Price == nil {
+		venue, err := r.GetVenue(reserv.VenueID)
+		if err != nil {
+			return nil, err
+		}
+		reserv.Price = bookingPrice(venue, reserv.UnitID, reserv.PartySize, reserv.StartAt, reserv.EndAt)
+		reserv.VenueRevision = venue.Revision
+	}
+
 	if err := r.repo.Save(reserv); err != nil {
 		return nil, err
 	}
@@ -293,9 +365,11 @@ func (r *bookingService) ReservationUpdate(id uint, reservation *dto.Reservation
 
 }
 
+// GetVenue читает площадку через внутренний путь venue-service: публичный GET /venues/:id
+// скрывает неопубликованные площадки, а брони на них остаются
 func (r *bookingService) GetVenue(id uint) (*dto.ResponsVenueServ, error) {
 
-	url := fmt.Sprintf("%s/venues/%d", r.venueURL, id)
+	url := fmt.Sprintf("%s/internal/venues/%d", r.venueURL, id)
 
 	var venue dto.ResponsVenueServ
 
@@ -324,41 +398,34 @@ func (r *bookingService) ValidateReservation(reservation *dto.ReservationCreate)
 	if err != nil {
 		return err
 	}
-
-	// Определяем день недели и соответствующее расписание
-	var day dto.DayScheduleDTO
-	switch reservation.StartAt.Weekday() {
-	case time.Monday:
-		day = venueFull.Weekdays.Weekdays.Monday
-	case time.Tuesday:
-		day = venueFull.Weekdays.Weekdays.Tuesday
-	case time.Wednesday:
-		day = venueFull.Weekdays.Weekdays.Wednesday
-	case time.Thursday:
-		day = venueFull.Weekdays.Weekdays.Thursday
-	case time.Friday:
-		day = venueFull.Weekdays.Weekdays.Friday
-	case time.Saturday:
-		day = venueFull.Weekdays.Weekdays.Saturday
-	case time.Sunday:
-		day = venueFull.Weekdays.Weekdays.Sunday
+	if !venueBookable(venueFull) {
+		return errors.ErrVenueNotPublished
 	}
 
+	// Определяем расписание на день недели
+	day := dayScheduleFor(venueFull.Weekdays, reservation.StartAt.Weekday())
+
 	if err := r.checkScheduleMatch(day, reservation.StartAt, reservation.EndAt); err != nil {
 		return err
 	}
 
-	if err := r.checkBookingConflicts(reservation.VenueID, reservation.StartAt, reservation.EndAt, nil); err != nil {
+	if err := checkBookingRules(venueFull.BookingRules, reservation.StartAt, reservation.EndAt, time.Now()); err != nil {
 		return err
 	}
 
+	unitID, err := r.checkBookingConflicts(venueFull, reservation.UnitID, reservation.PartySize, reservation.StartAt, reservation.EndAt, nil)
+	if err != nil {
+		return err
+	}
+	reservation.UnitID = unitID
+
 	return nil
 
 }
 
 // getVenueSchedule загружает полное представление площадки с расписанием
 func (r *bookingService) getVenueSchedule(venueID uint) (*dto.ResponsVenueServFull, error) {
-	url := fmt.Sprintf("%s/venues/%d", r.venueURL, venueID)
+	url := fmt.Sprintf("%s/internal/venues/%d", r.venueURL, venueID)
 	var venueFull dto.ResponsVenueServFull
 	resp, err := r.client.R().SetResult(&venueFull).Get(url)
 	if err != nil {
@@ -370,53 +437,80 @@ func (r *bookingService) getVenueSchedule(venueID uint) (*dto.ResponsVenueServFu
 	return &venueFull, nil
 }
 
-// checkScheduleMatch проверяет, что бронь попадает в рабочее время дня и корректно парсит расписание
+// venueBookable сообщает, прошла ли площадка модерацию.
+// Пустой статус - ответ venue-service без модерации, такие площадки считаются опубликованными
+func venueBookable(venue *dto.ResponsVenueServFull) bool {
+	return venue.Status == "" || venue.Status == "published"
+}
+
+// checkScheduleMatch проверяет, что бронь целиком попадает в один из интервалов рабочего времени дня
 func (r *bookingService) checkScheduleMatch(day dto.DayScheduleDTO, startAt, endAt time.Time) error {
 	if !day.Enabled {
 		return fmt.Errorf("площадка не работает в выбранный день")
 	}
-	if day.StartTime == nil || day.EndTime == nil {
-		return fmt.Errorf("в расписании площадки отсутствует время работы для выбранного дня")
-	}
 
-	tStart, err := time.Parse("15:04", *day.StartTime)
+	windows, err := workWindows(day, startAt)
 	if err != nil {
-		return fmt.Errorf("неверный формат start_time в расписании площадки: %w", err)
+		return err
+	}
+	for _, w := range windows {
+		if !startAt.Before(w.start) && !endAt.After(w.end) {
+			return nil
+		}
 	}
-	tEnd, err := time.Parse("15:04", *day.EndTime)
+
+	intervals, _ := dayIntervals(day)
+	return fmt.Errorf("бронь должна быть в пределах одного интервала рабочего времени площадки: %s", formatIntervals(intervals))
+}
+
+// checkBookingConflicts проверяет наличие конфликтующих броней в БД и возвращает единицу площадки для брони.
+// Площадки с вместимостью 1 бронируются целиком - любое пересечение считается конфликтом.
+// Для площадок с большей вместимостью суммируются места пересекающихся броней.
+// Если площадка разделена на единицы, проверяется выбранная единица вместе с её родителем и частями,
+// а при unitID == nil выбирается любая свободная единица.
+// Если excludeID != nil, то брони с этим id будут исключены (полезно для обновления).
+func (r *bookingService) checkBookingConflicts(venue *dto.ResponsVenueServFull, unitID *uint, partySize int, startAt, endAt time.Time, excludeID *uint) (*uint, error) {
+	bookings, err := r.repo.GetOverlappingBookings(venue.ID, startAt, endAt, excludeID)
 	if err != nil {
-		return fmt.Errorf("неверный формат end_time в расписании площадки: %w", err)
+		return nil, err
 	}
 
-	venueStart := time.Date(startAt.Year(), startAt.Month(), startAt.Day(), tStart.Hour(), tStart.Minute(), 0, 0, startAt.Location())
-	venueEnd := time.Date(endAt.Year(), endAt.Month(), endAt.Day(), tEnd.Hour(), tEnd.Minute(), 0, 0, endAt.Location())
+	if len(venue.Units) > 0 {
+		if unitID != nil {
+			unit := findUnit(venue.Units, *unitID)
+			if unit == nil || !unitIsActive(*unit) {
+				return nil, errors.ErrUnitNotFound
+			}
+			if len(bookingsForUnit(bookings, venue.Units, *unitID)) > 0 {
+				return nil, fmt.Errorf("в выбранный период единица площадки %q уже занята", unit.Name)
+			}
+			return unitID, nil
+		}
 
-	if startAt.Before(venueStart) || endAt.After(venueEnd) {
-		return fmt.Errorf("бронь должна быть в пределах рабочего времени площадки: с %s по %s", (*day.StartTime), (*day.EndTime))
+		for _, unit := range unitCandidates(venue.Units) {
+			if len(bookingsForUnit(bookings, venue.Units, unit.ID)) == 0 {
+				id := unit.ID
+				return &id, nil
+			}
+		}
+		return nil, errors.ErrNoFreeUnit
 	}
 
-	return nil
-}
+	if unitID != nil {
+		return nil, errors.ErrUnitNotFound
+	}
 
-// checkBookingConflicts проверяет наличие конфликтующих броней в БД.
-// Если excludeID != nil, то брони с этим id будут исключены (полезно для обновления).
-func (r *bookingService) checkBookingConflicts(venueID uint, startAt, endAt time.Time, excludeID *uint) error {
-	var count int64
-	q := r.db.Model(&models.Reservation{})
-	if excludeID != nil {
-		q = q.Where("venue_id = ? AND id <> ? AND ((start_at < ? AND end_at > ?) OR (start_at < ? AND end_at > ?) OR (start_at >= ? AND end_at <= ?))",
-			venueID, *excludeID, endAt, endAt, startAt, startAt, startAt, endAt)
-	} else {
-		q = q.Where("venue_id = ? AND ((start_at < ? AND end_at > ?) OR (start_at < ? AND end_at > ?) OR (start_at >= ? AND end_at <= ?))",
-			venueID, endAt, endAt, startAt, startAt, startAt, endAt)
-	}
-	if err := q.Count(&count).Error; err != nil {
-		return err
+	if venue.Capacity <= 1 {
+		if len(bookings) > 0 {
+			return nil, fmt.Errorf("в выбранный период уже есть бронирования на эту площадку")
+		}
+		return nil, nil
 	}
-	if count > 0 {
-		return fmt.Errorf("в выбранный период уже есть бронирования на эту площадку")
+
+	if peakOccupancy(bookings, startAt, endAt)+partySize > venue.Capacity {
+		return nil, errors.ErrCapacityExceeded
 	}
-	return nil
+	return nil, nil
 }
 
 // ValidateReservationUpdate выполняет валидацию аналогичную ValidateReservation,
@@ -442,6 +536,18 @@ func (r *bookingService) ValidateReservationUpdate(id uint, reservation *dto.Res
 	if reservation.EndAt != nil {
 		finalEndAt = *reservation.EndAt
 	}
+	partySize := current.PartySize
+	if reservation.PartySize != nil {
+		partySize = *reservation.PartySize
+	}
+	// При смене площадки прежняя единица к ней не относится - подбираем любую свободную
+	unitID := current.UnitID
+	if venueID != current.VenueID {
+		unitID = nil
+	}
+	if reservation.UnitID != nil {
+		unitID = reservation.UnitID
+	}
 
 	// Бронь должна быть в пределах одного дня
 	if finalStartAt.Year() != finalEndAt.Year() || finalStartAt.YearDay() != finalEndAt.YearDay() {
@@ -453,41 +559,36 @@ func (r *bookingService) ValidateReservationUpdate(id uint, reservation *dto.Res
 	if err != nil {
 		return err
 	}
-
-	// Определяем день недели
-	var day dto.DayScheduleDTO
-	switch finalStartAt.Weekday() {
-	case time.Monday:
-		day = venueFull.Weekdays.Weekdays.Monday
-	case time.Tuesday:
-		day = venueFull.Weekdays.Weekdays.Tuesday
-	case time.Wednesday:
-		day = venueFull.Weekdays.Weekdays.Wednesday
-	case time.Thursday:
-		day = venueFull.Weekdays.Weekdays.Thursday
-	case time.Friday:
-		day = venueFull.Weekdays.Weekdays.Friday
-	case time.Saturday:
-		day = venueFull.Weekdays.Weekdays.Saturday
-	case time.Sunday:
-		day = venueFull.Weekdays.Weekdays.Sunday
+	if !venueBookable(venueFull) {
+		return errors.ErrVenueNotPublished
 	}
 
+	// Определяем расписание на день недели
+	day := dayScheduleFor(venueFull.Weekdays, finalStartAt.Weekday())
+
 	if err := r.checkScheduleMatch(day, finalStartAt, finalEndAt); err != nil {
 		return err
 	}
 
-	if err := r.checkBookingConflicts(venueID, finalStartAt, finalEndAt, &id); err != nil {
+	if err := checkBookingRules(venueFull.BookingRules, finalStartAt, finalEndAt, time.Now()); err != nil {
+		return err
+	}
+
+	resolvedUnitID, err := r.checkBookingConflicts(venueFull, unitID, partySize, finalStartAt, finalEndAt, &id)
+	if err != nil {
 		return err
 	}
+	reservation.UnitID = resolvedUnitID
 
 	return nil
 }
 
-// GetVenueAvailability возвращает свободные слоты площадки на дату
-func (r *bookingService) GetVenueAvailability(venueID uint, date time.Time) ([]dto.AvailableSlot, error) {
+// GetVenueAvailability возвращает свободные слоты площадки на дату.
+// Если площадка разделена на единицы, unitID ограничивает выдачу одной единицей,
+// иначе возвращаются слоты всех активных единиц
+func (r *bookingService) GetVenueAvailability(venueID uint, unitID *uint, date time.Time) ([]dto.AvailableSlot, error) {
 	// Получаем данные площадки с расписанием
-	url := fmt.Sprintf("%s/venues/%d", r.venueURL, venueID)
+	url := fmt.Sprintf("%s/internal/venues/%d", r.venueURL, venueID)
 	var venueFull dto.ResponsVenueServFull
 	resp, err := r.client.R().SetResult(&venueFull).Get(url)
 	if err != nil {
@@ -497,59 +598,28 @@ func (r *bookingService) GetVenueAvailability(venueID uint, date time.Time) ([]d
 		return nil, fmt.Errorf("Сервер вернул ошибку: %d", resp.StatusCode())
 	}
 
-	// Определяем день недели
-	var day dto.DayScheduleDTO
-	switch date.Weekday() {
-	case time.Monday:
-		day = venueFull.Weekdays.Weekdays.Monday
-	case time.Tuesday:
-		day = venueFull.Weekdays.Weekdays.Tuesday
-	case time.Wednesday:
-		day = venueFull.Weekdays.Weekdays.Wednesday
-	case time.Thursday:
-		day = venueFull.Weekdays.Weekdays.Thursday
-	case time.Friday:
-		day = venueFull.Weekdays.Weekdays.Friday
-	case time.Saturday:
-		day = venueFull.Weekdays.Weekdays.Saturday
-	case time.Sunday:
-		day = venueFull.Weekdays.Weekdays.Sunday
-	}
+	// Определяем расписание на день недели
+	day := dayScheduleFor(venueFull.Weekdays, date.Weekday())
 
 	if !day.Enabled {
 		// площадка не работает в этот день — нет слотов
 		return []dto.AvailableSlot{}, nil
 	}
 
-	if day.StartTime == nil || day.EndTime == nil {
-		return nil, fmt.Errorf("в расписании площадки отсутствует время работы для выбранного дня")
-	}
-
-	tStart, err := time.Parse("15:04", *day.StartTime)
-	if err != nil {
-		return nil, fmt.Errorf("неверный формат start_time в расписании площадки: %w", err)
-	}
-	tEnd, err := time.Parse("15:04", *day.EndTime)
+	// Интервалы работы на дату (в UTC, как и брони)
+	windows, err := workWindows(day, time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC))
 	if err != nil {
-		return nil, fmt.Errorf("неверный формат end_time в расписании площадки: %w", err)
+		return nil, err
 	}
 
-	venueStart := time.Date(date.Year(), date.Month(), date.Day(), tStart.Hour(), tStart.Minute(), 0, 0, time.UTC)
-	venueEnd := time.Date(date.Year(), date.Month(), date.Day(), tEnd.Hour(), tEnd.Minute(), 0, 0, time.UTC)
-
 	// Получаем все брони площадки
 	bookings, err := r.repo.GetVenueBookings(venueID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Собираем интервал занятых времён в рабочем дне
-	type interval struct {
-		start time.Time
-		end   time.Time
-	}
-
-	var intervals []interval
+	// Отбираем неотменённые брони выбранного дня
+	var dayBookings []models.ReservationDetails
 
 	for _, b := range bookings {
 		if b.Status == models.Cancelled {
@@ -559,57 +629,37 @@ func (r *bookingService) GetVenueAvailability(venueID uint, date time.Time) ([]d
 		if b.StartAt.Year() != date.Year() || b.StartAt.YearDay() != date.YearDay() {
 			continue
 		}
-		// Если бронь вне рабочего времени — пропускаем или обрезаем
-		if b.EndAt.Before(venueStart) || b.StartAt.After(venueEnd) {
-			continue
-		}
-		s := b.StartAt
-		if s.Before(venueStart) {
-			s = venueStart
-		}
-		e := b.EndAt
-		if e.After(venueEnd) {
-			e = venueEnd
-		}
-		intervals = append(intervals, interval{start: s, end: e})
-	}
-
-	// Сортируем по start
-	sort.Slice(intervals, func(i, j int) bool {
-		return intervals[i].start.Before(intervals[j].start)
-	})
-
-	// Сливаем перекрывающиеся интервалы и определяем свободные промежутки
-	var slots []dto.AvailableSlot
-	prev := venueStart
-	for _, it := range intervals {
-		if it.start.After(prev) {
-			slots = append(slots, dto.AvailableSlot{StartAt: prev, EndAt: it.start})
-		}
-		if it.end.After(prev) {
-			prev = it.end
+		dayBookings = append(dayBookings, b)
+	}
+
+	// Слоты приводятся к правилам бронирования площадки: минимальный срок, горизонт, шаг начала и длительность
+	now := time.Now()
+	rules := venueFull.BookingRules
+
+	// Единицу можно выбрать только у площадки, разделённой на единицы (корты, половины поля)
+	if len(venueFull.Units) == 0 && unitID != nil {
+		return nil, errors.ErrUnitNotFound
+	}
+
+	// Слоты считаются отдельно для каждого интервала рабочего времени,
+	// брони учитываются только те, что пересекаются с интервалом
+	slots := []dto.AvailableSlot{}
+	for _, w := range windows {
+		windowBookings := bookingsInWindow(dayBookings, w)
+		switch {
+		case len(venueFull.Units) > 0:
+			unitWindowSlots, err := unitSlots(windowBookings, venueFull.Units, unitID, w.start, w.end)
+			if err != nil {
+				return nil, err
+			}
+			slots = append(slots, applyBookingRules(unitWindowSlots, rules, now, minDuration(rules))...)
+		case venueFull.Capacity > 1:
+			// Для площадок с вместимостью больше 1 возвращаем отрезки с количеством свободных мест.
+			// Короткие отрезки не отбрасываются: бронь может захватывать несколько соседних отрезков
+			slots = append(slots, applyBookingRules(capacitySlots(windowBookings, w.start, w.end, venueFull.Capacity), rules, now, 0)...)
+		default:
+			slots = append(slots, applyBookingRules(exclusiveSlots(windowBookings, w.start, w.end), rules, now, minDuration(rules))...)
 		}
 	}
-
-	if prev.Before(venueEnd) {
-		slots = append(slots, dto.AvailableSlot{StartAt: prev, EndAt: venueEnd})
-	}
-
-	// Если нет броней — вернуть один слот рабочего времени (если длительность >= 1ч)
-	if len(intervals) == 0 {
-		if venueEnd.Sub(venueStart) >= time.Hour {
-			return []dto.AvailableSlot{{StartAt: venueStart, EndAt: venueEnd}}, nil
-		}
-		return []dto.AvailableSlot{}, nil
-	}
-
-	// Отфильтруем слоты короче 1 часа (минимальная длительность брони)
-	var filtered []dto.AvailableSlot
-	for _, s := range slots {
-		if s.EndAt.Sub(s.StartAt) >= time.Hour {
-			filtered = append(filtered, s)
-		}
-	}
-
-	return filtered, nil
+	return slots, nil
 }
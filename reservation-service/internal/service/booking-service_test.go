@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reservation/internal/dto"
+	"reservation/internal/models"
+	"reservation/internal/repository"
+	"testing"
+	"time"
+)
+
+// fakeBookingRepo хранит одну бронь и не находит пересечений с другими
+type fakeBookingRepo struct {
+	repository.BookingRepo
+	booking models.ReservationDetails
+}
+
+func (r *fakeBookingRepo) GetByID(id uint) (*models.ReservationDetails, error) {
+	b := r.booking
+	return &b, nil
+}
+
+func (r *fakeBookingRepo) GetOverlappingBookings(venueID uint, startAt, endAt time.Time, excludeID *uint) ([]models.ReservationDetails, error) {
+	return nil, nil
+}
+
+func (r *fakeBookingRepo) Save(reservation *models.ReservationDetails) error {
+	r.booking = *reservation
+	return nil
+}
+
+// newPricingVenueServer отдаёт площадку 3, открытую каждый день с 08:00 до 22:00
+func newPricingVenueServer(t *testing.T, capacity int, units []dto.VenueUnitResp) *httptest.Server {
+	t.Helper()
+	open, closeAt := "08:00", "22:00"
+	day := dto.DayScheduleDTO{Enabled: true, StartTime: &open, EndTime: &closeAt}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/venues/3" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":         3,
+			"owner_id":   7,
+			"hour_price": 1000,
+			"capacity":   capacity,
+			"revision":   5,
+			"units":      units,
+			"weekdays": dto.WeekdaysDTO{
+				Monday: day, Tuesday: day, Wednesday: day, Thursday: day,
+				Friday: day, Saturday: day, Sunday: day,
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReservationUpdateRecomputesPrice(t *testing.T) {
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	startAt := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(time.Hour)
+	later := endAt.Add(time.Hour)
+	courtPrice := 1500.0
+	units := []dto.VenueUnitResp{{ID: 1, Name: "Корт 1"}, {ID: 2, Name: "Корт 2", HourPrice: &courtPrice}}
+	partySize, clientID, unitID, price := 3, uint(16), uint(2), 700.0
+
+	tests := []struct {
+		name         string
+		capacity     int
+		units        []dto.VenueUnitResp
+		update       dto.ReservationUpdate
+		wantPrice    float64
+		wantRevision int
+	}{
+		{name: "число мест", capacity: 10, update: dto.ReservationUpdate{PartySize: &partySize}, wantPrice: 3000, wantRevision: 5},
+		{name: "время", capacity: 1, update: dto.ReservationUpdate{EndAt: &later}, wantPrice: 2000, wantRevision: 5},
+		{name: "единица", capacity: 1, units: units, update: dto.ReservationUpdate{UnitID: &unitID}, wantPrice: 1500, wantRevision: 5},
+		// Места не умножают цену площадки, которая бронируется целиком
+		{name: "число мест без вместимости", capacity: 1, update: dto.ReservationUpdate{PartySize: &partySize}, wantPrice: 1000, wantRevision: 5},
+		{name: "явная цена", capacity: 1, update: dto.ReservationUpdate{EndAt: &later, Price: &price}, wantPrice: 700, wantRevision: 1},
+		{name: "без влияния на цену", capacity: 1, update: dto.ReservationUpdate{ClientID: &clientID}, wantPrice: 900, wantRevision: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newPricingVenueServer(t, tt.capacity, tt.units)
+			booking := models.ReservationDetails{
+				Base: models.Base{ID: 42}, ClientID: 15, VenueID: 3, OwnerID: 7,
+				StartAt: startAt, EndAt: endAt, Price: 900, PartySize: 1, Status: models.Pending, VenueRevision: 1,
+			}
+			if len(tt.units) > 0 {
+				booking.UnitID = &units[0].ID
+			}
+			repo := &fakeBookingRepo{booking: booking}
+			service := NewBookingServ(repo, nil, nil, server.URL, nil)
+
+			updated, err := service.ReservationUpdate(42, &tt.update)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated.Price != tt.wantPrice || updated.VenueRevision != tt.wantRevision {
+				t.Fatalf("цена %v, версия %d; ожидалось %v, %d", updated.Price, updated.VenueRevision, tt.wantPrice, tt.wantRevision)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"reservation/internal/config"
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"reservation/internal/models"
+	"reservation/internal/repository"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// checkInAudience отличает токены отметки о приходе от токенов авторизации,
+// даже если они подписаны одним ключом
+const checkInAudience = "booking-check-in"
+
+// CheckInClaims - содержимое токена из QR-кода
+type CheckInClaims struct {
+	BookingID uint `json:"booking_id"`
+	VenueID   uint `json:"venue_id"`
+	jwt.RegisteredClaims
+}
+
+type CheckInService interface {
+	IssueToken(bookingID uint, claims *models.Claims) (*dto.CheckInTokenResponse, error)
+	CheckIn(req *dto.CheckInRequest, claims *models.Claims) (*models.ReservationDetails, error)
+}
+
+type checkInService struct {
+	repo repository.BookingRepo
+	cfg  config.CheckInConfig
+}
+
+func NewCheckInService(repo repository.BookingRepo, cfg config.CheckInConfig) CheckInService {
+	return &checkInService{repo: repo, cfg: cfg}
+}
+
+// IssueToken выдаёт клиенту короткоживущий подписанный токен для QR-кода.
+// Токен выдаётся только по подтверждённой брони, которая ещё не закончилась
+func (s *checkInService) IssueToken(bookingID uint, claims *models.Claims) (*dto.CheckInTokenResponse, error) {
+	if claims == nil {
+		return nil, errors.ErrForbidden
+	}
+
+	booking, err := s.repo.GetByID(bookingID)
+	if err != nil {
+		return nil, errors.ErrReservationNotFound
+	}
+
+	if claims.Role != models.RoleAdmin && booking.ClientID != claims.UserID {
+		return nil, errors.ErrForbidden
+	}
+	if booking.Status != models.Confirmed {
+		return nil, errors.ErrCheckInNotAllowed
+	}
+	if booking.CheckedInAt != nil {
+		return nil, errors.ErrAlreadyCheckedIn
+	}
+
+	now := time.Now()
+	if !now.Before(booking.EndAt) {
+		return nil, errors.ErrCheckInWindow
+	}
+
+	expiresAt := now.Add(s.cfg.TokenTTL)
+	if expiresAt.After(booking.EndAt) {
+		expiresAt = booking.EndAt
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, CheckInClaims{
+		BookingID: booking.ID,
+		VenueID:   booking.VenueID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Audience:  jwt.ClaimStrings{checkInAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	signed, err := token.SignedString([]byte(s.cfg.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось подписать токен: %w", err)
+	}
+
+	return &dto.CheckInTokenResponse{Token: signed, ExpiresAt: expiresAt.UTC()}, nil
+}
+
+// CheckIn проверяет токен на стороне владельца площадки и отмечает приход.
+// Повторная отметка по той же брони и токены другой площадки отклоняются
+func (s *checkInService) CheckIn(req *dto.CheckInRequest, claims *models.Claims) (*models.ReservationDetails, error) {
+	if claims == nil {
+		return nil, errors.ErrForbidden
+	}
+	if claims.Role != models.RoleOwner && claims.Role != models.RoleAdmin {
+		return nil, errors.ErrForbidden
+	}
+
+	tokenClaims := &CheckInClaims{}
+	token, err := jwt.ParseWithClaims(req.Token, tokenClaims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(s.cfg.Secret), nil
+	})
+	if err != nil || !token.Valid || !tokenClaims.VerifyAudience(checkInAudience, true) {
+		return nil, errors.ErrCheckInToken
+	}
+
+	if tokenClaims.VenueID != req.VenueID {
+		return nil, errors.ErrWrongVenue
+	}
+
+	booking, err := s.repo.GetByID(tokenClaims.BookingID)
+	if err != nil {
+		return nil, errors.ErrReservationNotFound
+	}
+	if booking.VenueID != req.VenueID {
+		return nil, errors.ErrWrongVenue
+	}
+	if claims.Role != models.RoleAdmin && booking.OwnerID != claims.UserID {
+		return nil, errors.ErrNotOwner
+	}
+	if booking.CheckedInAt != nil {
+		return nil, errors.ErrAlreadyCheckedIn
+	}
+	if booking.Status != models.Confirmed {
+		return nil, errors.ErrCheckInNotAllowed
+	}
+
+	now := time.Now()
+	if now.Before(booking.StartAt.Add(-s.cfg.EarlyWindow)) || !now.Before(booking.EndAt) {
+		return nil, errors.ErrCheckInWindow
+	}
+
+	ok, err := s.repo.CheckIn(booking.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Бронь успели отметить (или изменить) параллельным запросом
+		return nil, errors.ErrAlreadyCheckedIn
+	}
+
+	booking.CheckedInAt = &now
+	return booking, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"reservation/internal/models"
+	"time"
+
+	"contracts/events"
+
+	"github.com/google/uuid"
+)
+
+func newEventMeta() events.Meta {
+	return events.Meta{EventID: uuid.NewString(), OccurredAt: time.Now()}
+}
+
+// bookingCreatedEvent переводит бронь в событие booking.created. Цена брони хранится в рублях,
+// в событии - в копейках
+func bookingCreatedEvent(b *models.ReservationDetails) events.BookingCreatedV1 {
+	return events.BookingCreatedV1{
+		BookingID: b.ID,
+		VenueID:   b.VenueID,
+		UnitID:    b.UnitID,
+		ClientID:  b.ClientID,
+		OwnerID:   b.OwnerID,
+		StartAt:   b.StartAt,
+		EndAt:     b.EndAt,
+		PartySize: b.PartySize,
+		Status:    string(b.Status),
+
+		AmountMinor: events.MinorUnits(b.Price),
+		Currency:    events.CurrencyRUB,
+
+		PrepaymentRequired: b.PrepaymentRequired,
+	}
+}
+
+func bookingCancelledEvent(b *models.ReservationDetails) events.BookingCancelledV1 {
+	return events.BookingCancelledV1{
+		BookingID: b.ID,
+		VenueID:   b.VenueID,
+		ClientID:  b.ClientID,
+		Reason:    b.ReasonForCancel,
+		Status:    string(b.Status),
+
+		UnitID:  b.UnitID,
+		StartAt: b.StartAt,
+		EndAt:   b.EndAt,
+	}
+}
+
+func bookingReminderEvent(b *models.ReservationDetails, offsetMinutes int) events.BookingReminderV1 {
+	return events.BookingReminderV1{
+		BookingID:     b.ID,
+		VenueID:       b.VenueID,
+		UnitID:        b.UnitID,
+		ClientID:      b.ClientID,
+		OwnerID:       b.OwnerID,
+		StartAt:       b.StartAt,
+		EndAt:         b.EndAt,
+		OffsetMinutes: offsetMinutes,
+	}
+}
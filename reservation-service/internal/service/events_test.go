@@ -0,0 +1,67 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"reservation/internal/models"
+	"testing"
+	"time"
+
+	"contracts/events"
+	"contracts/events/fixtures"
+)
+
+// fixtureBooking - бронь, из которой получаются эталонные сообщения contracts/events/fixtures
+func fixtureBooking() *models.ReservationDetails {
+	unitID := uint(3)
+	b := &models.ReservationDetails{
+		VenueID:         7,
+		ClientID:        15,
+		OwnerID:         4,
+		StartAt:         time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC),
+		EndAt:           time.Date(2026, 1, 25, 12, 0, 0, 0, time.UTC),
+		Price:           3000.5,
+		PartySize:       1,
+		UnitID:          &unitID,
+		Status:          models.Confirmed,
+		ReasonForCancel: "Изменение планов",
+	}
+	b.ID = 42
+	return b
+}
+
+func assertMatchesFixture(t *testing.T, meta events.Meta, evt events.Event, fixture string) {
+	t.Helper()
+	got, err := events.Encode(meta, evt)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(fixtures.Load(fixture), &w); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("событие не совпадает с %s:\n got: %s", fixture, got)
+	}
+}
+
+func TestBookingCreatedEventMatchesContract(t *testing.T) {
+	meta := events.Meta{EventID: "0b7e4f0e-2a57-4c1e-9a4a-3f1d2c9e8b10", OccurredAt: time.Date(2026, 1, 20, 9, 30, 0, 0, time.UTC)}
+	assertMatchesFixture(t, meta, bookingCreatedEvent(fixtureBooking()), "booking_created.v1.json")
+}
+
+func TestBookingCancelledEventMatchesContract(t *testing.T) {
+	b := fixtureBooking()
+	b.Status = models.Cancelled
+	meta := events.Meta{EventID: "5c1d7a52-8f0b-4d7e-b4c2-1e9f6a3d2b44", OccurredAt: time.Date(2026, 1, 21, 18, 0, 0, 0, time.UTC)}
+	assertMatchesFixture(t, meta, bookingCancelledEvent(b), "booking_cancelled.v1.json")
+}
+
+func TestBookingReminderEventMatchesContract(t *testing.T) {
+	meta := events.Meta{EventID: "booking-42-reminder-120-1769335200", OccurredAt: time.Date(2026, 1, 25, 8, 0, 0, 0, time.UTC)}
+	assertMatchesFixture(t, meta, bookingReminderEvent(fixtureBooking(), 120), "booking_reminder.v1.json")
+}
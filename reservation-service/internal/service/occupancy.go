@@ -0,0 +1,145 @@
+package service
+
+import (
+	"reservation/internal/dto"
+	"reservation/internal/models"
+	"sort"
+	"time"
+)
+
+// bookingPartySize возвращает количество мест, занятых бронью.
+// Старые брони без party_size считаются бронью одного места
+func bookingPartySize(b models.ReservationDetails) int {
+	if b.PartySize < 1 {
+		return 1
+	}
+	return b.PartySize
+}
+
+// peakOccupancy возвращает максимальное количество одновременно занятых мест в интервале [startAt, endAt)
+func peakOccupancy(bookings []models.ReservationDetails, startAt, endAt time.Time) int {
+	type change struct {
+		at    time.Time
+		delta int
+	}
+
+	var changes []change
+	for _, b := range bookings {
+		s, e := b.StartAt, b.EndAt
+		if s.Before(startAt) {
+			s = startAt
+		}
+		if e.After(endAt) {
+			e = endAt
+		}
+		if !s.Before(e) {
+			continue
+		}
+		size := bookingPartySize(b)
+		changes = append(changes, change{at: s, delta: size}, change{at: e, delta: -size})
+	}
+
+	// При совпадении времени сначала освобождаем места, затем занимаем:
+	// бронь, заканчивающаяся в 10:00, не пересекается с бронью, начинающейся в 10:00
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].at.Equal(changes[j].at) {
+			return changes[i].delta < changes[j].delta
+		}
+		return changes[i].at.Before(changes[j].at)
+	})
+
+	peak, current := 0, 0
+	for _, c := range changes {
+		current += c.delta
+		if current > peak {
+			peak = current
+		}
+	}
+	return peak
+}
+
+// capacitySlots разбивает рабочее время [windowStart, windowEnd) на отрезки
+// с одинаковым количеством свободных мест. Полностью занятые отрезки не возвращаются
+func capacitySlots(bookings []models.ReservationDetails, windowStart, windowEnd time.Time, capacity int) []dto.AvailableSlot {
+	points := []time.Time{windowStart, windowEnd}
+	for _, b := range bookings {
+		if b.StartAt.After(windowStart) && b.StartAt.Before(windowEnd) {
+			points = append(points, b.StartAt)
+		}
+		if b.EndAt.After(windowStart) && b.EndAt.Before(windowEnd) {
+			points = append(points, b.EndAt)
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Before(points[j])
+	})
+
+	slots := []dto.AvailableSlot{}
+	for i := 0; i+1 < len(points); i++ {
+		segStart, segEnd := points[i], points[i+1]
+		if !segStart.Before(segEnd) {
+			continue
+		}
+
+		remaining := capacity - peakOccupancy(bookings, segStart, segEnd)
+		if remaining <= 0 {
+			continue
+		}
+
+		// Склеиваем соседние отрезки с одинаковым количеством свободных мест
+		if n := len(slots); n > 0 && slots[n-1].EndAt.Equal(segStart) && slots[n-1].RemainingSpots == remaining {
+			slots[n-1].EndAt = segEnd
+			continue
+		}
+		slots = append(slots, dto.AvailableSlot{StartAt: segStart, EndAt: segEnd, RemainingSpots: remaining})
+	}
+
+	return slots
+}
+
+// exclusiveSlots возвращает свободные промежутки рабочего времени [windowStart, windowEnd)
+// для площадки, бронируемой целиком. Правила бронирования (минимальная длительность и т.д.)
+// применяются отдельно в applyBookingRules
+func exclusiveSlots(bookings []models.ReservationDetails, windowStart, windowEnd time.Time) []dto.AvailableSlot {
+	type interval struct {
+		start time.Time
+		end   time.Time
+	}
+
+	// Собираем интервалы занятых времён в рабочем дне, обрезая их по рабочему времени
+	var intervals []interval
+	for _, b := range bookings {
+		s := b.StartAt
+		if s.Before(windowStart) {
+			s = windowStart
+		}
+		e := b.EndAt
+		if e.After(windowEnd) {
+			e = windowEnd
+		}
+		intervals = append(intervals, interval{start: s, end: e})
+	}
+
+	// Сортируем по start
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	// Сливаем перекрывающиеся интервалы и определяем свободные промежутки
+	slots := []dto.AvailableSlot{}
+	prev := windowStart
+	for _, it := range intervals {
+		if it.start.After(prev) {
+			slots = append(slots, dto.AvailableSlot{StartAt: prev, EndAt: it.start})
+		}
+		if it.end.After(prev) {
+			prev = it.end
+		}
+	}
+
+	if prev.Before(windowEnd) {
+		slots = append(slots, dto.AvailableSlot{StartAt: prev, EndAt: windowEnd})
+	}
+
+	return slots
+}
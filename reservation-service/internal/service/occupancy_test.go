@@ -0,0 +1,80 @@
+package service
+
+import (
+	"reservation/internal/models"
+	"testing"
+	"time"
+)
+
+// seatBooking - бронь partySize мест с startMin до endMin минут от 00:00
+func seatBooking(startMin, endMin, partySize int) models.ReservationDetails {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	return models.ReservationDetails{
+		StartAt:   day.Add(time.Duration(startMin) * time.Minute),
+		EndAt:     day.Add(time.Duration(endMin) * time.Minute),
+		PartySize: partySize,
+	}
+}
+
+func TestPeakOccupancy(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	startAt, endAt := day.Add(10*time.Hour), day.Add(12*time.Hour)
+
+	tests := []struct {
+		name     string
+		bookings []models.ReservationDetails
+		want     int
+	}{
+		{name: "без броней", want: 0},
+		{name: "пересекающиеся брони", bookings: []models.ReservationDetails{seatBooking(600, 690, 2), seatBooking(630, 720, 3)}, want: 5},
+		{name: "брони встык", bookings: []models.ReservationDetails{seatBooking(600, 660, 3), seatBooking(660, 720, 4)}, want: 4},
+		{name: "бронь до интервала встык", bookings: []models.ReservationDetails{seatBooking(480, 600, 5)}, want: 0},
+		{name: "бронь после интервала встык", bookings: []models.ReservationDetails{seatBooking(720, 780, 5)}, want: 0},
+		{name: "брони, выходящие за интервал", bookings: []models.ReservationDetails{seatBooking(540, 630, 2), seatBooking(630, 780, 2)}, want: 2},
+		{name: "старая бронь без party_size", bookings: []models.ReservationDetails{seatBooking(600, 660, 0), seatBooking(600, 660, 2)}, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := peakOccupancy(tt.bookings, startAt, endAt); got != tt.want {
+				t.Fatalf("peakOccupancy = %d, ожидалось %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapacitySlots(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	windowStart, windowEnd := day.Add(10*time.Hour), day.Add(14*time.Hour)
+
+	type slot struct {
+		start, end, remaining int // Часы и свободные места
+	}
+	tests := []struct {
+		name     string
+		bookings []models.ReservationDetails
+		want     []slot
+	}{
+		{name: "без броней", want: []slot{{10, 14, 5}}},
+		{name: "часть мест занята", bookings: []models.ReservationDetails{seatBooking(660, 720, 2)}, want: []slot{{10, 11, 5}, {11, 12, 3}, {12, 14, 5}}},
+		{name: "все места заняты", bookings: []models.ReservationDetails{seatBooking(660, 720, 5)}, want: []slot{{10, 11, 5}, {12, 14, 5}}},
+		// Соседние отрезки с одинаковым числом мест склеиваются
+		{name: "брони встык", bookings: []models.ReservationDetails{seatBooking(660, 720, 2), seatBooking(720, 780, 2)}, want: []slot{{10, 11, 5}, {11, 13, 3}, {13, 14, 5}}},
+		{name: "бронь за границей рабочего времени", bookings: []models.ReservationDetails{seatBooking(540, 660, 4)}, want: []slot{{10, 11, 1}, {11, 14, 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []slot
+			for _, s := range capacitySlots(tt.bookings, windowStart, windowEnd, 5) {
+				got = append(got, slot{s.StartAt.Hour(), s.EndAt.Hour(), s.RemainingSpots})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("слоты %v, ожидалось %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("слоты %v, ожидалось %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"log"
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"reservation/internal/models"
+	"reservation/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReliabilityService interface {
+	GetClientReliability(clientID uint, claims *models.Claims) (*dto.ClientReliabilityResponse, error)
+	ForgiveIncident(id uint, reason string, claims *models.Claims) (*models.ClientIncident, error)
+}
+
+type reliabilityService struct {
+	repo repository.ReliabilityRepo
+}
+
+func NewReliabilityService(repo repository.ReliabilityRepo) ReliabilityService {
+	return &reliabilityService{repo: repo}
+}
+
+// GetClientReliability - сводка нарушений клиента, только для администратора
+func (s *reliabilityService) GetClientReliability(clientID uint, claims *models.Claims) (*dto.ClientReliabilityResponse, error) {
+	if claims == nil || claims.Role != models.RoleAdmin {
+		return nil, errors.ErrForbidden
+	}
+
+	incidents, err := s.repo.GetClientIncidents(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ClientReliabilityResponse{ClientID: clientID, Incidents: incidents}
+	for _, incident := range incidents {
+		switch {
+		case incident.ForgivenAt != nil:
+			resp.Forgiven++
+		case incident.Kind == models.IncidentNoShow:
+			resp.NoShows++
+		case incident.Kind == models.IncidentLateCancel:
+			resp.LateCancellations++
+		}
+	}
+	return resp, nil
+}
+
+// ForgiveIncident прощает нарушение: администратор - любое, владелец - на своей площадке
+func (s *reliabilityService) ForgiveIncident(id uint, reason string, claims *models.Claims) (*models.ClientIncident, error) {
+	if claims == nil || (claims.Role != models.RoleAdmin && claims.Role != models.RoleOwner) {
+		return nil, errors.ErrForbidden
+	}
+
+	incident, err := s.repo.GetIncident(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrIncidentNotFound
+		}
+		return nil, err
+	}
+	if claims.Role != models.RoleAdmin && incident.OwnerID != claims.UserID {
+		return nil, errors.ErrNotOwner
+	}
+
+	now := time.Now()
+	ok, err := s.repo.Forgive(id, claims.UserID, reason, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.ErrIncidentForgiven
+	}
+
+	incident.ForgivenAt = &now
+	incident.ForgivenBy = &claims.UserID
+	incident.ForgiveReason = reason
+	return incident, nil
+}
+
+// checkClientReliability применяет к клиенту политику надёжности площадки.
+// Возвращает true, если бронь можно создать только с предоплатой
+func (r *bookingService) checkClientReliability(rules dto.BookingRulesResp, claims *models.Claims) (bool, error) {
+	if rules.ReliabilityLimit <= 0 || claims.Role == models.RoleAdmin {
+		return false, nil
+	}
+
+	var since time.Time
+	if rules.ReliabilityPeriodDays > 0 {
+		since = time.Now().AddDate(0, 0, -rules.ReliabilityPeriodDays)
+	}
+	count, err := r.reliability.CountActiveIncidents(claims.UserID, since)
+	if err != nil {
+		return false, err
+	}
+	if count < int64(rules.ReliabilityLimit) {
+		return false, nil
+	}
+
+	if rules.ReliabilityAction == models.ReliabilityPrepay {
+		return true, nil
+	}
+	return false, errors.ErrClientBlocked
+}
+
+// lateCancelIncident возвращает нарушение, если клиент отменяет бронь позже срока из правил площадки
+func (r *bookingService) lateCancelIncident(reservation *models.ReservationDetails, now time.Time) *models.ClientIncident {
+	venue, err := r.GetVenue(reservation.VenueID)
+	if err != nil {
+		// Отмена не должна зависеть от доступности venue-service
+		log.Printf("Не удалось получить правила площадки %d для учёта поздней отмены: %v", reservation.VenueID, err)
+		return nil
+	}
+
+	hours := venue.BookingRules.LateCancelHours
+	if hours <= 0 || now.Before(reservation.StartAt.Add(-time.Duration(hours)*time.Hour)) {
+		return nil
+	}
+
+	return &models.ClientIncident{
+		ClientID:   reservation.ClientID,
+		VenueID:    reservation.VenueID,
+		OwnerID:    reservation.OwnerID,
+		BookingID:  reservation.ID,
+		Kind:       models.IncidentLateCancel,
+		OccurredAt: now,
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"reservation/internal/models"
+	"reservation/internal/repository"
+	"testing"
+	"time"
+)
+
+// fakeReliabilityRepo считает нарушения в памяти так же, как CountActiveIncidents в базе
+type fakeReliabilityRepo struct {
+	repository.ReliabilityRepo
+	incidents []models.ClientIncident
+}
+
+func (r *fakeReliabilityRepo) CountActiveIncidents(clientID uint, since time.Time) (int64, error) {
+	var count int64
+	for _, incident := range r.incidents {
+		if incident.ClientID != clientID || incident.ForgivenAt != nil || incident.OccurredAt.Before(since) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func TestCheckClientReliability(t *testing.T) {
+	const clientID = 15
+	now := time.Now()
+	forgivenAt := now.Add(-time.Hour)
+	incidents := []models.ClientIncident{
+		{ClientID: clientID, Kind: models.IncidentNoShow, OccurredAt: now.AddDate(0, 0, -3)},
+		{ClientID: clientID, Kind: models.IncidentLateCancel, OccurredAt: now.AddDate(0, 0, -10)},
+		{ClientID: clientID, Kind: models.IncidentNoShow, OccurredAt: now.AddDate(0, 0, -60)},
+		// Прощённое нарушение не учитывается
+		{ClientID: clientID, Kind: models.IncidentNoShow, OccurredAt: now.AddDate(0, 0, -1), ForgivenAt: &forgivenAt},
+		// Нарушение другого клиента
+		{ClientID: clientID + 1, Kind: models.IncidentNoShow, OccurredAt: now.AddDate(0, 0, -1)},
+	}
+	client := &models.Claims{UserID: clientID, Role: models.RoleClient}
+
+	tests := []struct {
+		name        string
+		rules       dto.BookingRulesResp
+		claims      *models.Claims
+		wantPrepay  bool
+		wantBlocked bool
+	}{
+		{name: "политика отключена", rules: dto.BookingRulesResp{}, claims: client},
+		{name: "ниже порога", rules: dto.BookingRulesResp{ReliabilityLimit: 4, ReliabilityAction: models.ReliabilityBlock}, claims: client},
+		{name: "порог достигнут, блокировка", rules: dto.BookingRulesResp{ReliabilityLimit: 3, ReliabilityAction: models.ReliabilityBlock}, claims: client, wantBlocked: true},
+		{name: "порог достигнут, предоплата", rules: dto.BookingRulesResp{ReliabilityLimit: 3, ReliabilityAction: models.ReliabilityPrepay}, claims: client, wantPrepay: true},
+		{name: "без действия - блокировка", rules: dto.BookingRulesResp{ReliabilityLimit: 3}, claims: client, wantBlocked: true},
+		{name: "старые нарушения вне периода", rules: dto.BookingRulesResp{ReliabilityLimit: 3, ReliabilityPeriodDays: 30, ReliabilityAction: models.ReliabilityBlock}, claims: client},
+		{name: "нарушения за период", rules: dto.BookingRulesResp{ReliabilityLimit: 2, ReliabilityPeriodDays: 30, ReliabilityAction: models.ReliabilityPrepay}, claims: client, wantPrepay: true},
+		{name: "администратор", rules: dto.BookingRulesResp{ReliabilityLimit: 1, ReliabilityAction: models.ReliabilityBlock}, claims: &models.Claims{UserID: clientID, Role: models.RoleAdmin}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &bookingService{reliability: &fakeReliabilityRepo{incidents: incidents}}
+
+			prepay, err := service.checkClientReliability(tt.rules, tt.claims)
+			if tt.wantBlocked {
+				if err != errors.ErrClientBlocked {
+					t.Fatalf("ошибка %v, ожидалась блокировка", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prepay != tt.wantPrepay {
+				t.Fatalf("предоплата = %v, ожидалось %v", prepay, tt.wantPrepay)
+			}
+		})
+	}
+}
+
+// newRulesVenueServer отдаёт площадку с заданными правилами бронирования по внутреннему пути venue-service
+func newRulesVenueServer(t *testing.T, rules dto.BookingRulesResp) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/venues/3" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(dto.ResponsVenueServ{ID: 3, OwnerID: 7, BookingRules: rules})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLateCancelIncident(t *testing.T) {
+	startAt := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	booking := &models.ReservationDetails{Base: models.Base{ID: 42}, ClientID: 15, VenueID: 3, OwnerID: 7, StartAt: startAt}
+
+	tests := []struct {
+		name       string
+		hours      int
+		venueID    uint
+		cancelAt   time.Time
+		wantRecord bool
+	}{
+		{name: "поздняя отмена", hours: 24, venueID: 3, cancelAt: startAt.Add(-2 * time.Hour), wantRecord: true},
+		{name: "ровно на границе срока", hours: 24, venueID: 3, cancelAt: startAt.Add(-24 * time.Hour), wantRecord: true},
+		{name: "заблаговременная отмена", hours: 24, venueID: 3, cancelAt: startAt.Add(-25 * time.Hour)},
+		{name: "поздние отмены не учитываются", hours: 0, venueID: 3, cancelAt: startAt.Add(-time.Hour)},
+		// Недоступность venue-service не мешает отмене и не создаёт нарушение
+		{name: "площадка недоступна", hours: 24, venueID: 4, cancelAt: startAt.Add(-time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRulesVenueServer(t, dto.BookingRulesResp{LateCancelHours: tt.hours})
+			service := NewBookingServ(nil, nil, nil, server.URL, nil).(*bookingService)
+
+			b := *booking
+			b.VenueID = tt.venueID
+			incident := service.lateCancelIncident(&b, tt.cancelAt)
+			if !tt.wantRecord {
+				if incident != nil {
+					t.Fatalf("нарушение %+v, ожидалось без нарушения", incident)
+				}
+				return
+			}
+			if incident == nil {
+				t.Fatal("нарушение не зафиксировано")
+			}
+			if incident.Kind != models.IncidentLateCancel || incident.BookingID != 42 || incident.ClientID != 15 ||
+				incident.VenueID != 3 || incident.OwnerID != 7 || !incident.OccurredAt.Equal(tt.cancelAt) {
+				t.Fatalf("нарушение %+v", incident)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reservation/internal/config"
+	"reservation/internal/kafka"
+	"reservation/internal/models"
+	"reservation/internal/repository"
+	"time"
+
+	"contracts/events"
+)
+
+// ReminderScheduler периодически отправляет события booking.reminder
+// для подтверждённых броней за заданные интервалы до начала
+type ReminderScheduler struct {
+	repo     repository.ReminderRepo
+	producer kafka.Producer
+	cfg      config.ReminderConfig
+}
+
+func NewReminderScheduler(repo repository.ReminderRepo, producer kafka.Producer, cfg config.ReminderConfig) *ReminderScheduler {
+	return &ReminderScheduler{repo: repo, producer: producer, cfg: cfg}
+}
+
+// Start запускает планировщик в отдельной горутине до отмены ctx
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	if len(s.cfg.Offsets) == 0 {
+		log.Println("Напоминания о бронях отключены: не заданы смещения")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			s.runOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *ReminderScheduler) runOnce(ctx context.Context) {
+	for _, offset := range s.cfg.Offsets {
+		now := time.Now()
+		due, err := s.repo.ClaimDueReminders(offset, now, s.cfg.BatchSize, func(b models.ReservationDetails) bool {
+			return s.skip(b, offset, now)
+		})
+		if err != nil {
+			log.Printf("Ошибка обработки напоминаний за %s: %v", offset, err)
+			continue
+		}
+
+		sent := 0
+		for _, b := range due {
+			if err := s.remind(ctx, b, offset); err != nil {
+				// Напоминание будет отправлено на следующем проходе
+				if err := s.repo.ReleaseReminder(b.ID, offset, b.StartAt); err != nil {
+					log.Printf("Ошибка снятия напоминания о брони %d: %v", b.ID, err)
+				}
+				continue
+			}
+			sent++
+		}
+		if sent > 0 {
+			log.Printf("Отправлено напоминаний за %s: %d", offset, sent)
+		}
+	}
+}
+
+// skip сообщает, что напоминание отправлять не нужно: бронь создана уже после момента
+// напоминания (например, за час до начала при смещении 24h) или момент давно прошёл -
+// после простоя сервиса устаревшие напоминания не отправляются разом
+func (s *ReminderScheduler) skip(b models.ReservationDetails, offset time.Duration, now time.Time) bool {
+	remindAt := b.StartAt.Add(-offset)
+	return b.CreatedAt.After(remindAt) || now.Sub(remindAt) > s.cfg.MaxDelay
+}
+
+// remind отправляет напоминание по брони
+func (s *ReminderScheduler) remind(ctx context.Context, b models.ReservationDetails, offset time.Duration) error {
+	offsetMinutes := int(offset.Minutes())
+	// EventID детерминирован (бронь, смещение, время начала), чтобы потребители могли отбрасывать повторы
+	meta := events.Meta{
+		EventID:    fmt.Sprintf("booking-%d-reminder-%d-%d", b.ID, offsetMinutes, b.StartAt.Unix()),
+		OccurredAt: time.Now(),
+	}
+
+	if err := s.producer.PublishBookingReminder(ctx, meta, bookingReminderEvent(&b, offsetMinutes)); err != nil {
+		log.Printf("Ошибка отправки напоминания о брони %d в Kafka: %v", b.ID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reservation/internal/config"
+	"reservation/internal/kafka"
+	"reservation/internal/models"
+	"reservation/internal/repository"
+	"testing"
+	"time"
+
+	"contracts/events"
+)
+
+// fakeReminderRepo отдаёт заданные брони и запоминает пропущенные и снятые напоминания
+type fakeReminderRepo struct {
+	bookings []models.ReservationDetails
+	skipped  []uint
+	released []uint
+}
+
+func (r *fakeReminderRepo) ClaimDueReminders(offset time.Duration, now time.Time, limit int, skip repository.ReminderSkipper) ([]models.ReservationDetails, error) {
+	var due []models.ReservationDetails
+	for _, b := range r.bookings {
+		if skip(b) {
+			r.skipped = append(r.skipped, b.ID)
+			continue
+		}
+		due = append(due, b)
+	}
+	r.bookings = nil
+	return due, nil
+}
+
+func (r *fakeReminderRepo) ReleaseReminder(bookingID uint, offset time.Duration, startAt time.Time) error {
+	r.released = append(r.released, bookingID)
+	return nil
+}
+
+// fakeReminderProducer запоминает отправленные напоминания, для брони failID возвращает ошибку
+type fakeReminderProducer struct {
+	kafka.Producer
+	failID uint
+	sent   []uint
+}
+
+func (p *fakeReminderProducer) PublishBookingReminder(ctx context.Context, meta events.Meta, evt events.BookingReminderV1) error {
+	if evt.BookingID == p.failID {
+		return errors.New("kafka недоступна")
+	}
+	p.sent = append(p.sent, evt.BookingID)
+	return nil
+}
+
+func TestReminderSchedulerRunOnce(t *testing.T) {
+	now := time.Now()
+	booking := func(id uint, createdAgo, startIn time.Duration) models.ReservationDetails {
+		b := *fixtureBooking()
+		b.ID = id
+		b.CreatedAt = now.Add(-createdAgo)
+		b.StartAt = now.Add(startIn)
+		b.EndAt = b.StartAt.Add(time.Hour)
+		return b
+	}
+
+	repo := &fakeReminderRepo{bookings: []models.ReservationDetails{
+		booking(1, 48*time.Hour, 110*time.Minute),  // Момент напоминания наступил 10 минут назад
+		booking(2, 5*time.Minute, 110*time.Minute), // Бронь создана позже момента напоминания
+		booking(3, 48*time.Hour, 30*time.Minute),   // Напоминание опоздало на полтора часа (простой сервиса)
+		booking(4, 48*time.Hour, 115*time.Minute),  // Kafka вернула ошибку
+	}}
+	producer := &fakeReminderProducer{failID: 4}
+	scheduler := NewReminderScheduler(repo, producer, config.ReminderConfig{Offsets: []time.Duration{2 * time.Hour}, MaxDelay: 30 * time.Minute})
+
+	scheduler.runOnce(context.Background())
+
+	if len(producer.sent) != 1 || producer.sent[0] != 1 {
+		t.Fatalf("отправлены напоминания %v, ожидалось [1]", producer.sent)
+	}
+	if len(repo.skipped) != 2 || repo.skipped[0] != 2 || repo.skipped[1] != 3 {
+		t.Fatalf("пропущены %v, ожидалось [2 3]", repo.skipped)
+	}
+	if len(repo.released) != 1 || repo.released[0] != 4 {
+		t.Fatalf("сняты %v, ожидалось [4]", repo.released)
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"time"
+)
+
+// defaultMinDuration - минимальная длительность брони, если площадка не задала свою
+const defaultMinDuration = time.Hour
+
+// minDuration возвращает минимальную длительность брони площадки
+func minDuration(rules dto.BookingRulesResp) time.Duration {
+	if rules.MinDurationMinutes <= 0 {
+		return defaultMinDuration
+	}
+	return time.Duration(rules.MinDurationMinutes) * time.Minute
+}
+
+// bookingHorizon возвращает самый поздний допустимый момент начала брони (нулевое время - без ограничения)
+func bookingHorizon(rules dto.BookingRulesResp, now time.Time) time.Time {
+	if rules.MaxAdvanceDays <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, rules.MaxAdvanceDays)
+}
+
+// checkBookingRules проверяет бронь на соответствие правилам площадки:
+// минимальное время до начала, горизонт бронирования, длительность и шаг начала
+func checkBookingRules(rules dto.BookingRulesResp, startAt, endAt, now time.Time) error {
+	if rules.MinLeadMinutes > 0 && startAt.Before(now.Add(time.Duration(rules.MinLeadMinutes)*time.Minute)) {
+		return fmt.Errorf("%w: бронь нужно создать минимум за %d мин. до начала", errors.ErrLeadTime, rules.MinLeadMinutes)
+	}
+
+	if horizon := bookingHorizon(rules, now); !horizon.IsZero() && startAt.After(horizon) {
+		return fmt.Errorf("%w: бронировать можно не более чем на %d дн. вперед", errors.ErrBookingHorizon, rules.MaxAdvanceDays)
+	}
+
+	duration := endAt.Sub(startAt)
+	if duration < minDuration(rules) {
+		return fmt.Errorf("%w: минимальная длительность - %d мин.", errors.ErrDuration, int(minDuration(rules).Minutes()))
+	}
+	if rules.MaxDurationMinutes > 0 && duration > time.Duration(rules.MaxDurationMinutes)*time.Minute {
+		return fmt.Errorf("%w: максимальная длительность - %d мин.", errors.ErrDuration, rules.MaxDurationMinutes)
+	}
+
+	if rules.StartStepMinutes > 1 && (startAt.Minute()%rules.StartStepMinutes != 0 || startAt.Second() != 0 || startAt.Nanosecond() != 0) {
+		return fmt.Errorf("%w: бронь может начинаться только с шагом %d мин.", errors.ErrStartAlignment, rules.StartStepMinutes)
+	}
+
+	return nil
+}
+
+// alignUp сдвигает время вперед до ближайшего допустимого начала брони
+func alignUp(t time.Time, stepMinutes int) time.Time {
+	if stepMinutes <= 1 {
+		if t.Second() != 0 || t.Nanosecond() != 0 {
+			return t.Truncate(time.Minute).Add(time.Minute)
+		}
+		return t
+	}
+	step := time.Duration(stepMinutes) * time.Minute
+	dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(dayStart)
+	if rem := offset % step; rem != 0 {
+		offset += step - rem
+	}
+	return dayStart.Add(offset)
+}
+
+// applyBookingRules приводит свободные слоты к правилам бронирования площадки:
+// отсекает время раньше минимального срока и позже горизонта бронирования,
+// выравнивает начало по шагу и убирает слоты короче minSlot (0 - без фильтра)
+func applyBookingRules(slots []dto.AvailableSlot, rules dto.BookingRulesResp, now time.Time, minSlot time.Duration) []dto.AvailableSlot {
+	earliest := now.Add(time.Duration(rules.MinLeadMinutes) * time.Minute)
+	horizon := bookingHorizon(rules, now)
+
+	filtered := []dto.AvailableSlot{}
+	for _, slot := range slots {
+		if slot.StartAt.Before(earliest) {
+			slot.StartAt = earliest
+		}
+		slot.StartAt = alignUp(slot.StartAt, rules.StartStepMinutes)
+
+		if !horizon.IsZero() && slot.StartAt.After(horizon) {
+			continue
+		}
+		if !slot.StartAt.Before(slot.EndAt) || slot.EndAt.Sub(slot.StartAt) < minSlot {
+			continue
+		}
+		filtered = append(filtered, slot)
+	}
+	return filtered
+}
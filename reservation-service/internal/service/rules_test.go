@@ -0,0 +1,70 @@
+package service
+
+import (
+	stderrors "errors"
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"testing"
+	"time"
+)
+
+func TestCheckBookingRules(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		rules      dto.BookingRulesResp
+		start, end time.Time
+		wantErr    error
+	}{
+		{name: "без правил", start: at(12, 0), end: at(13, 0)},
+		{name: "слишком поздно", rules: dto.BookingRulesResp{MinLeadMinutes: 120}, start: at(10, 0), end: at(11, 0), wantErr: errors.ErrLeadTime},
+		{name: "ровно за минимальный срок", rules: dto.BookingRulesResp{MinLeadMinutes: 120}, start: at(11, 0), end: at(12, 0)},
+		{name: "дальше горизонта", rules: dto.BookingRulesResp{MaxAdvanceDays: 7}, start: at(12, 0).AddDate(0, 0, 8), end: at(13, 0).AddDate(0, 0, 8), wantErr: errors.ErrBookingHorizon},
+		{name: "в пределах горизонта", rules: dto.BookingRulesResp{MaxAdvanceDays: 7}, start: at(12, 0).AddDate(0, 0, 6), end: at(13, 0).AddDate(0, 0, 6)},
+		{name: "короче часа по умолчанию", start: at(12, 0), end: at(12, 30), wantErr: errors.ErrDuration},
+		{name: "своя минимальная длительность", rules: dto.BookingRulesResp{MinDurationMinutes: 30}, start: at(12, 0), end: at(12, 30)},
+		{name: "длиннее максимума", rules: dto.BookingRulesResp{MaxDurationMinutes: 90}, start: at(12, 0), end: at(14, 0), wantErr: errors.ErrDuration},
+		{name: "начало вне шага", rules: dto.BookingRulesResp{StartStepMinutes: 30}, start: at(12, 15), end: at(13, 15), wantErr: errors.ErrStartAlignment},
+		{name: "начало по шагу", rules: dto.BookingRulesResp{StartStepMinutes: 30}, start: at(12, 30), end: at(13, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBookingRules(tt.rules, tt.start, tt.end, now)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAlignUp(t *testing.T) {
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, second, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		step int
+		want time.Time
+	}{
+		{name: "без шага, ровная минута", t: at(10, 7, 0), want: at(10, 7, 0)},
+		{name: "без шага, секунды", t: at(10, 7, 30), want: at(10, 8, 0)},
+		{name: "уже по шагу", t: at(10, 30, 0), step: 30, want: at(10, 30, 0)},
+		{name: "до следующего шага", t: at(10, 10, 0), step: 30, want: at(10, 30, 0)},
+		{name: "шаг отсчитывается от полуночи", t: at(10, 10, 0), step: 45, want: at(10, 30, 0)},
+		{name: "секунды после шага", t: at(10, 30, 1), step: 30, want: at(11, 0, 0)},
+		{name: "переход на следующий день", t: at(23, 50, 0), step: 30, want: at(24, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alignUp(tt.t, tt.step); !got.Equal(tt.want) {
+				t.Fatalf("alignUp = %s, ожидалось %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"reservation/internal/dto"
+	"reservation/internal/models"
+	"strings"
+	"time"
+)
+
+// workWindow - интервал рабочего времени площадки на конкретную дату
+type workWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+// dayScheduleFor возвращает расписание площадки на день недели
+func dayScheduleFor(weekdays dto.WeekdaysDTO, weekday time.Weekday) dto.DayScheduleDTO {
+	switch weekday {
+	case time.Monday:
+		return weekdays.Monday
+	case time.Tuesday:
+		return weekdays.Tuesday
+	case time.Wednesday:
+		return weekdays.Wednesday
+	case time.Thursday:
+		return weekdays.Thursday
+	case time.Friday:
+		return weekdays.Friday
+	case time.Saturday:
+		return weekdays.Saturday
+	default:
+		return weekdays.Sunday
+	}
+}
+
+// dayIntervals возвращает интервалы работы дня в формате "HH:MM".
+// Расписания без intervals (старый формат) считаются одним интервалом start_time-end_time
+func dayIntervals(day dto.DayScheduleDTO) ([]dto.TimeIntervalDTO, error) {
+	if len(day.Intervals) > 0 {
+		return day.Intervals, nil
+	}
+	if day.StartTime == nil || day.EndTime == nil {
+		return nil, fmt.Errorf("в расписании площадки отсутствует время работы для выбранного дня")
+	}
+	return []dto.TimeIntervalDTO{{Start: *day.StartTime, End: *day.EndTime}}, nil
+}
+
+// workWindows переводит интервалы работы дня в отрезки времени на дату date в её часовом поясе
+func workWindows(day dto.DayScheduleDTO, date time.Time) ([]workWindow, error) {
+	intervals, err := dayIntervals(day)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := make([]workWindow, 0, len(intervals))
+	for _, interval := range intervals {
+		tStart, err := time.Parse("15:04", interval.Start)
+		if err != nil {
+			return nil, fmt.Errorf("неверный формат начала интервала в расписании площадки: %w", err)
+		}
+		tEnd, err := time.Parse("15:04", interval.End)
+		if err != nil {
+			return nil, fmt.Errorf("неверный формат окончания интервала в расписании площадки: %w", err)
+		}
+		windows = append(windows, workWindow{
+			start: time.Date(date.Year(), date.Month(), date.Day(), tStart.Hour(), tStart.Minute(), 0, 0, date.Location()),
+			end:   time.Date(date.Year(), date.Month(), date.Day(), tEnd.Hour(), tEnd.Minute(), 0, 0, date.Location()),
+		})
+	}
+	return windows, nil
+}
+
+// bookingsInWindow отбирает брони, пересекающиеся с интервалом рабочего времени
+func bookingsInWindow(bookings []models.ReservationDetails, w workWindow) []models.ReservationDetails {
+	var result []models.ReservationDetails
+	for _, b := range bookings {
+		if b.StartAt.Before(w.end) && b.EndAt.After(w.start) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// formatIntervals выводит интервалы работы для сообщений об ошибках: "07:00-08:30, 17:00-23:00"
+func formatIntervals(intervals []dto.TimeIntervalDTO) string {
+	parts := make([]string, 0, len(intervals))
+	for _, interval := range intervals {
+		parts = append(parts, interval.Start+"-"+interval.End)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"reservation/internal/dto"
+	"testing"
+	"time"
+)
+
+// splitDay - день с перерывом: утром 07:00-08:30 и вечером 17:00-23:00
+func splitDay() dto.DayScheduleDTO {
+	start, end := "07:00", "23:00"
+	return dto.DayScheduleDTO{
+		Enabled:   true,
+		StartTime: &start,
+		EndTime:   &end,
+		Intervals: []dto.TimeIntervalDTO{{Start: "07:00", End: "08:30"}, {Start: "17:00", End: "23:00"}},
+	}
+}
+
+func TestWorkWindows(t *testing.T) {
+	date := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.UTC)
+	}
+	legacyStart, legacyEnd := "09:00", "21:00"
+	badStart := "9 утра"
+
+	tests := []struct {
+		name    string
+		day     dto.DayScheduleDTO
+		want    []workWindow
+		wantErr bool
+	}{
+		{name: "несколько интервалов", day: splitDay(), want: []workWindow{{at(7, 0), at(8, 30)}, {at(17, 0), at(23, 0)}}},
+		{name: "старый формат без intervals", day: dto.DayScheduleDTO{Enabled: true, StartTime: &legacyStart, EndTime: &legacyEnd}, want: []workWindow{{at(9, 0), at(21, 0)}}},
+		{name: "нет времени работы", day: dto.DayScheduleDTO{Enabled: true}, wantErr: true},
+		{name: "неверный формат", day: dto.DayScheduleDTO{Enabled: true, StartTime: &badStart, EndTime: &legacyEnd}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := workWindows(tt.day, date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("интервалы %v, ожидалось %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !got[i].start.Equal(tt.want[i].start) || !got[i].end.Equal(tt.want[i].end) {
+					t.Fatalf("интервалы %v, ожидалось %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckScheduleMatch(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.UTC)
+	}
+	closed := splitDay()
+	closed.Enabled = false
+
+	tests := []struct {
+		name       string
+		day        dto.DayScheduleDTO
+		start, end time.Time
+		wantErr    bool
+	}{
+		{name: "в утреннем интервале", day: splitDay(), start: at(7, 0), end: at(8, 30)},
+		{name: "в вечернем интервале", day: splitDay(), start: at(21, 30), end: at(23, 0)},
+		{name: "выходит за конец интервала", day: splitDay(), start: at(8, 0), end: at(9, 0), wantErr: true},
+		{name: "через перерыв", day: splitDay(), start: at(8, 0), end: at(17, 30), wantErr: true},
+		{name: "в перерыве", day: splitDay(), start: at(12, 0), end: at(13, 0), wantErr: true},
+		{name: "до открытия", day: splitDay(), start: at(6, 30), end: at(7, 30), wantErr: true},
+		{name: "выходной", day: closed, start: at(7, 0), end: at(8, 0), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &bookingService{}
+			if err := service.checkScheduleMatch(tt.day, tt.start, tt.end); (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"log"
+	"reservation/internal/repository"
+	"time"
+)
+
+// BookingStatusScheduler периодически закрывает завершившиеся подтверждённые брони:
+// без отметки о приходе по выданному токену - no_show, остальные - completed
+type BookingStatusScheduler struct {
+	repo     repository.BookingRepo
+	interval time.Duration
+}
+
+func NewBookingStatusScheduler(repo repository.BookingRepo, interval time.Duration) *BookingStatusScheduler {
+	return &BookingStatusScheduler{repo: repo, interval: interval}
+}
+
+// Start запускает планировщик в отдельной горутине до отмены ctx
+func (s *BookingStatusScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.runOnce()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *BookingStatusScheduler) runOnce() {
+	completed, noShow, err := s.repo.CloseFinishedBookings(time.Now())
+	if err != nil {
+		log.Printf("Ошибка закрытия завершившихся броней: %v", err)
+		return
+	}
+	if completed > 0 || noShow > 0 {
+		log.Printf("Завершено броней: %d, неявок: %d", completed, noShow)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"reservation/internal/models"
+	"sort"
+	"time"
+)
+
+// findUnit ищет единицу площадки по id
+func findUnit(units []dto.VenueUnitResp, id uint) *dto.VenueUnitResp {
+	for i := range units {
+		if units[i].ID == id {
+			return &units[i]
+		}
+	}
+	return nil
+}
+
+// unitIsActive сообщает, доступна ли единица для бронирования
+func unitIsActive(unit dto.VenueUnitResp) bool {
+	return unit.IsActive == nil || *unit.IsActive
+}
+
+// unitHourPrice возвращает цену часа единицы: собственную или цену площадки
+func unitHourPrice(venueHourPrice float64, units []dto.VenueUnitResp, unitID *uint) float64 {
+	if unitID == nil {
+		return venueHourPrice
+	}
+	if unit := findUnit(units, *unitID); unit != nil && unit.HourPrice != nil {
+		return *unit.HourPrice
+	}
+	return venueHourPrice
+}
+
+// bookingPrice считает цену брони по цене часа единицы или площадки.
+// На площадках с вместимостью больше 1 цена указана за одно место
+func bookingPrice(venue *dto.ResponsVenueServ, unitID *uint, partySize int, startAt, endAt time.Time) float64 {
+	price := unitHourPrice(venue.HourPrice, venue.Units, unitID) * endAt.Sub(startAt).Hours()
+	if venue.Capacity > 1 {
+		price *= float64(partySize)
+	}
+	return price
+}
+
+// sameUnit сообщает, указывают ли ссылки на одну и ту же единицу (nil - вся площадка)
+func sameUnit(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// blocksUnit сообщает, блокирует ли бронь единицу unitID.
+// Бронь без единицы занимает всю площадку, бронь родителя блокирует его части и наоборот
+func blocksUnit(b models.ReservationDetails, units []dto.VenueUnitResp, unitID uint) bool {
+	if b.UnitID == nil || *b.UnitID == unitID {
+		return true
+	}
+
+	unit := findUnit(units, unitID)
+	if unit != nil && unit.ParentID != nil && *unit.ParentID == *b.UnitID {
+		return true
+	}
+
+	booked := findUnit(units, *b.UnitID)
+	return booked != nil && booked.ParentID != nil && *booked.ParentID == unitID
+}
+
+// bookingsForUnit оставляет только брони, блокирующие единицу unitID
+func bookingsForUnit(bookings []models.ReservationDetails, units []dto.VenueUnitResp, unitID uint) []models.ReservationDetails {
+	var filtered []models.ReservationDetails
+	for _, b := range bookings {
+		if blocksUnit(b, units, unitID) {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered
+}
+
+// unitCandidates возвращает активные единицы в порядке выбора "любой свободной":
+// сначала неделимые единицы (корт, половина поля), затем составные (всё поле),
+// чтобы бронь не занимала больше, чем нужно
+func unitCandidates(units []dto.VenueUnitResp) []dto.VenueUnitResp {
+	hasParts := make(map[uint]bool)
+	for _, u := range units {
+		if u.ParentID != nil {
+			hasParts[*u.ParentID] = true
+		}
+	}
+
+	var candidates []dto.VenueUnitResp
+	for _, u := range units {
+		if unitIsActive(u) {
+			candidates = append(candidates, u)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if hasParts[candidates[i].ID] != hasParts[candidates[j].ID] {
+			return !hasParts[candidates[i].ID]
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+	return candidates
+}
+
+// unitSlots возвращает свободные слоты единиц площадки в рабочем времени [windowStart, windowEnd).
+// Если unitID задан - только для этой единицы, иначе для всех активных единиц
+func unitSlots(bookings []models.ReservationDetails, units []dto.VenueUnitResp, unitID *uint, windowStart, windowEnd time.Time) ([]dto.AvailableSlot, error) {
+	var targets []dto.VenueUnitResp
+	if unitID != nil {
+		unit := findUnit(units, *unitID)
+		if unit == nil {
+			return nil, errors.ErrUnitNotFound
+		}
+		targets = []dto.VenueUnitResp{*unit}
+	} else {
+		targets = unitCandidates(units)
+	}
+
+	slots := []dto.AvailableSlot{}
+	for _, unit := range targets {
+		if !unitIsActive(unit) {
+			continue
+		}
+		id := unit.ID
+		for _, slot := range exclusiveSlots(bookingsForUnit(bookings, units, id), windowStart, windowEnd) {
+			slot.UnitID = &id
+			slots = append(slots, slot)
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		return slots[i].StartAt.Before(slots[j].StartAt)
+	})
+	return slots, nil
+}
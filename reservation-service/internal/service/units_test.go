@@ -0,0 +1,156 @@
+package service
+
+import (
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"reservation/internal/models"
+	"testing"
+	"time"
+)
+
+// testUnits - поле из двух половин, отдельный корт и отключённый корт.
+// Порядок перемешан, чтобы проверить сортировку кандидатов
+func testUnits() []dto.VenueUnitResp {
+	field, inactive := uint(1), false
+	return []dto.VenueUnitResp{
+		{ID: 4, Name: "Корт"},
+		{ID: 1, Name: "Поле"},
+		{ID: 3, ParentID: &field, Name: "Половина Б"},
+		{ID: 5, Name: "Старый корт", IsActive: &inactive},
+		{ID: 2, ParentID: &field, Name: "Половина А"},
+	}
+}
+
+func unitBooking(unitID *uint, startHour, endHour int) models.ReservationDetails {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	return models.ReservationDetails{
+		UnitID:  unitID,
+		StartAt: day.Add(time.Duration(startHour) * time.Hour),
+		EndAt:   day.Add(time.Duration(endHour) * time.Hour),
+	}
+}
+
+func unitRef(id uint) *uint {
+	return &id
+}
+
+func TestBlocksUnit(t *testing.T) {
+	tests := []struct {
+		name   string
+		booked *uint
+		unitID uint
+		want   bool
+	}{
+		{name: "бронь всей площадки", booked: nil, unitID: 2, want: true},
+		{name: "та же единица", booked: unitRef(4), unitID: 4, want: true},
+		{name: "родитель блокирует часть", booked: unitRef(1), unitID: 2, want: true},
+		{name: "часть блокирует родителя", booked: unitRef(3), unitID: 1, want: true},
+		{name: "соседняя половина свободна", booked: unitRef(2), unitID: 3},
+		{name: "другая единица", booked: unitRef(4), unitID: 2},
+		{name: "часть не блокирует чужой корт", booked: unitRef(2), unitID: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blocksUnit(unitBooking(tt.booked, 10, 11), testUnits(), tt.unitID); got != tt.want {
+				t.Fatalf("blocksUnit = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// "Любая свободная единица" сначала предлагает неделимые единицы, отключённые не предлагаются
+func TestUnitCandidates(t *testing.T) {
+	var got []uint
+	for _, u := range unitCandidates(testUnits()) {
+		got = append(got, u.ID)
+	}
+	want := []uint{2, 3, 4, 1}
+	if len(got) != len(want) {
+		t.Fatalf("кандидаты %v, ожидалось %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("кандидаты %v, ожидалось %v", got, want)
+		}
+	}
+}
+
+func TestUnitSlots(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	windowStart, windowEnd := day.Add(10*time.Hour), day.Add(14*time.Hour)
+	// Половина А занята 10-11, всё поле 12-13
+	bookings := []models.ReservationDetails{unitBooking(unitRef(2), 10, 11), unitBooking(unitRef(1), 12, 13)}
+
+	type slot struct {
+		unitID     uint
+		start, end int
+	}
+	tests := []struct {
+		name     string
+		bookings []models.ReservationDetails
+		unitID   *uint
+		want     []slot
+		wantErr  error
+	}{
+		{name: "половина занята сама и через поле", bookings: bookings, unitID: unitRef(2), want: []slot{{2, 11, 12}, {2, 13, 14}}},
+		{name: "поле занято через половину", bookings: bookings, unitID: unitRef(1), want: []slot{{1, 11, 12}, {1, 13, 14}}},
+		{name: "соседняя половина занята только полем", bookings: bookings, unitID: unitRef(3), want: []slot{{3, 10, 12}, {3, 13, 14}}},
+		{name: "брони встык", bookings: []models.ReservationDetails{unitBooking(unitRef(4), 10, 11), unitBooking(unitRef(4), 11, 12)}, unitID: unitRef(4), want: []slot{{4, 12, 14}}},
+		{name: "отключённая единица", bookings: bookings, unitID: unitRef(5), want: nil},
+		{name: "неизвестная единица", bookings: bookings, unitID: unitRef(9), wantErr: errors.ErrUnitNotFound},
+		{
+			name: "все единицы", bookings: bookings,
+			want: []slot{{3, 10, 12}, {4, 10, 14}, {2, 11, 12}, {1, 11, 12}, {2, 13, 14}, {3, 13, 14}, {1, 13, 14}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots, err := unitSlots(tt.bookings, testUnits(), tt.unitID, windowStart, windowEnd)
+			if err != tt.wantErr {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+			var got []slot
+			for _, s := range slots {
+				got = append(got, slot{*s.UnitID, s.StartAt.Hour(), s.EndAt.Hour()})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("слоты %v, ожидалось %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("слоты %v, ожидалось %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBookingPrice(t *testing.T) {
+	courtPrice := 1500.0
+	units := []dto.VenueUnitResp{{ID: 1}, {ID: 2, HourPrice: &courtPrice}}
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		capacity  int
+		unitID    *uint
+		partySize int
+		hours     float64
+		want      float64
+	}{
+		{name: "цена площадки", capacity: 1, partySize: 1, hours: 2, want: 2000},
+		{name: "единица без своей цены", capacity: 1, unitID: unitRef(1), partySize: 1, hours: 1.5, want: 1500},
+		{name: "цена единицы", capacity: 1, unitID: unitRef(2), partySize: 1, hours: 2, want: 3000},
+		{name: "места умножают цену", capacity: 20, partySize: 3, hours: 1, want: 3000},
+		{name: "места без вместимости", capacity: 1, partySize: 3, hours: 1, want: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venue := &dto.ResponsVenueServ{HourPrice: 1000, Capacity: tt.capacity, Units: units}
+			end := start.Add(time.Duration(tt.hours * float64(time.Hour)))
+			if got := bookingPrice(venue, tt.unitID, tt.partySize, start, end); got != tt.want {
+				t.Fatalf("цена %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package transport
 import (
 	"net/http"
 	"reservation/internal/dto"
+	"reservation/internal/errors"
 	"reservation/internal/middleware"
 	"reservation/internal/models"
 	"reservation/internal/service"
@@ -23,11 +24,19 @@ func NewBookingHandler(bookingService service.BookingService) *BookingHandler {
 func (r *BookingHandler) Register(c *gin.Engine, jwtSecret string) {
 	c.POST("/bookings", middleware.AuthMiddleware(jwtSecret), r.CreateReservation)
 	c.POST("/bookings/:id/cancel", middleware.AuthMiddleware(jwtSecret), r.CancelReservation)
+	c.GET("/bookings/export", middleware.AuthMiddleware(jwtSecret), r.ExportOwnerBookings)
 	c.GET("/bookings/:id", r.GetByID)
 	c.GET("/bookings", middleware.AuthMiddleware(jwtSecret), r.GetUserReservations)
 	c.PUT("/bookings/:id", middleware.AuthMiddleware(jwtSecret), r.UpdateReservation)
 	c.GET("/venues/:id/bookings", middleware.AuthMiddleware(jwtSecret), r.GetVenueBookings)
+	c.GET("/venues/:id/bookings/export", middleware.AuthMiddleware(jwtSecret), r.ExportVenueBookings)
 	c.GET("/venues/:id/availability", r.GetVenueAvailability)
+
+	// Число будущих броней для других сервисов (venue-service). Gateway этот путь не проксирует
+	internal := c.Group("/internal")
+	{
+		internal.GET("/venues/:id/bookings/upcoming", r.GetVenueUpcomingBookings)
+	}
 }
 
 func (r *BookingHandler) CreateReservation(c *gin.Context) {
@@ -53,6 +62,10 @@ func (r *BookingHandler) CreateReservation(c *gin.Context) {
 
 	reservation, err := r.bookingService.CreateReservation(&req, claims)
 	if err != nil {
+		if err == errors.ErrClientBlocked || err == errors.ErrVenueNotPublished {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
 	}
@@ -61,6 +74,11 @@ func (r *BookingHandler) CreateReservation(c *gin.Context) {
 }
 
 func (r *BookingHandler) CancelReservation(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
 	var dto dto.ReservationCancel
 	if err := c.ShouldBindJSON(&dto); err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
@@ -74,7 +92,7 @@ func (r *BookingHandler) CancelReservation(c *gin.Context) {
 		return
 	}
 
-	reservation, err := r.bookingService.ReservationCancel(uint(id), dto.Reason)
+	reservation, err := r.bookingService.ReservationCancel(uint(id), dto.Reason, claims)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -185,6 +203,23 @@ func (r *BookingHandler) GetVenueBookings(c *gin.Context) {
 	c.JSON(200, bookings)
 }
 
+// GetVenueUpcomingBookings возвращает число будущих броней площадки по внутреннему пути
+func (r *BookingHandler) GetVenueUpcomingBookings(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid venue ID"})
+		return
+	}
+
+	count, err := r.bookingService.CountUpcomingBookings(uint(id))
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, dto.UpcomingBookingsResponse{VenueID: uint(id), Count: count})
+}
+
 func (r *BookingHandler) GetVenueAvailability(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
@@ -205,7 +240,18 @@ func (r *BookingHandler) GetVenueAvailability(c *gin.Context) {
 		}
 	}
 
-	slots, err := r.bookingService.GetVenueAvailability(uint(id), date)
+	var unitID *uint
+	if unitStr := c.Query("unit_id"); unitStr != "" {
+		parsed, err := strconv.ParseUint(unitStr, 10, 64)
+		if err != nil || parsed == 0 {
+			c.JSON(400, gin.H{"error": "invalid unit ID"})
+			return
+		}
+		u := uint(parsed)
+		unitID = &u
+	}
+
+	slots, err := r.bookingService.GetVenueAvailability(uint(id), unitID, date)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,157 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"reservation/internal/middleware"
+	"reservation/internal/models"
+	"reservation/internal/service"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+)
+
+// qrSize - размер PNG с QR-кодом в пикселях
+const qrSize = 320
+
+type CheckInHandler struct {
+	checkInService service.CheckInService
+}
+
+func NewCheckInHandler(checkInService service.CheckInService) *CheckInHandler {
+	return &CheckInHandler{checkInService: checkInService}
+}
+
+func (h *CheckInHandler) Register(c *gin.Engine, jwtSecret string) {
+	c.GET("/bookings/:id/check-in-token", middleware.AuthMiddleware(jwtSecret), h.GetToken)
+	c.POST("/bookings/check-in", middleware.AuthMiddleware(jwtSecret), h.CheckIn)
+}
+
+// GetToken выдаёт токен отметки о приходе: format=json (по умолчанию), png или svg с QR-кодом
+func (h *CheckInHandler) GetToken(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(400, gin.H{"error": "invalid booking ID"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "png" && format != "svg" {
+		c.JSON(400, gin.H{"error": "invalid format, use json, png or svg"})
+		return
+	}
+
+	token, err := h.checkInService.IssueToken(uint(id), claims)
+	if err != nil {
+		c.JSON(checkInErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// QR-код меняется при каждом запросе и не должен кэшироваться
+	c.Header("Cache-Control", "no-store")
+
+	switch format {
+	case "png":
+		png, err := qrcode.Encode(token.Token, qrcode.Medium, qrSize)
+		if err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(200, "image/png", png)
+	case "svg":
+		svg, err := qrSVG(token.Token)
+		if err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(200, "image/svg+xml", []byte(svg))
+	default:
+		c.JSON(200, token)
+	}
+}
+
+// CheckIn - отметка о приходе сотрудником площадки по токену из QR-кода
+func (h *CheckInHandler) CheckIn(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	booking, err := h.checkInService.CheckIn(&req, claims)
+	if err != nil {
+		c.JSON(checkInErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, booking)
+}
+
+func claimsFromContext(c *gin.Context) (*models.Claims, bool) {
+	claimsVal, ok := c.Get("claims")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+
+	claims, ok := claimsVal.(*models.Claims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid claims"})
+		return nil, false
+	}
+	return claims, true
+}
+
+func checkInErrorStatus(err error) int {
+	switch err {
+	case errors.ErrForbidden, errors.ErrNotOwner:
+		return http.StatusForbidden
+	case errors.ErrReservationNotFound:
+		return http.StatusNotFound
+	case errors.ErrCheckInToken:
+		return http.StatusUnauthorized
+	case errors.ErrAlreadyCheckedIn:
+		return http.StatusConflict
+	case errors.ErrCheckInNotAllowed, errors.ErrCheckInWindow, errors.ErrWrongVenue:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// qrSVG рисует QR-код в SVG: каждый тёмный модуль - квадрат 1x1 в общем path
+func qrSVG(content string) (string, error) {
+	qr, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+	bitmap := qr.Bitmap()
+	size := len(bitmap)
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" shape-rendering="crispEdges">`+
+		`<rect width="100%%" height="100%%" fill="#fff"/><path fill="#000" d="%s"/></svg>`,
+		size, size, qrSize, qrSize, path.String()), nil
+}
@@ -0,0 +1,164 @@
+package transport
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"reservation/internal/errors"
+	"reservation/internal/models"
+	"strconv"
+	"time"
+
+	"pkg/export"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bookingExportColumns - колонки выгрузки броней, выбираются параметром columns
+var bookingExportColumns = []export.Column[models.ReservationDetails]{
+	{Key: "id", Title: "ID", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return strconv.FormatUint(uint64(b.ID), 10)
+	}},
+	{Key: "venue_id", Title: "Площадка", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return strconv.FormatUint(uint64(b.VenueID), 10)
+	}},
+	{Key: "unit_id", Title: "Единица площадки", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		if b.UnitID == nil {
+			return ""
+		}
+		return strconv.FormatUint(uint64(*b.UnitID), 10)
+	}},
+	{Key: "client_id", Title: "Клиент", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return strconv.FormatUint(uint64(b.ClientID), 10)
+	}},
+	{Key: "owner_id", Title: "Владелец", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return strconv.FormatUint(uint64(b.OwnerID), 10)
+	}},
+	{Key: "start_at", Title: "Начало", Value: func(b models.ReservationDetails, l export.Locale) string {
+		return l.Time(b.StartAt)
+	}},
+	{Key: "end_at", Title: "Окончание", Value: func(b models.ReservationDetails, l export.Locale) string {
+		return l.Time(b.EndAt)
+	}},
+	{Key: "duration_minutes", Title: "Длительность, мин", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return strconv.Itoa(int(b.EndAt.Sub(b.StartAt).Minutes()))
+	}},
+	{Key: "party_size", Title: "Мест", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return strconv.Itoa(b.PartySize)
+	}},
+	{Key: "price", Title: "Стоимость", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return strconv.FormatFloat(b.Price, 'f', 2, 64)
+	}},
+	{Key: "venue_revision", Title: "Версия цены площадки", Numeric: true, Value: func(b models.ReservationDetails, _ export.Locale) string {
+		if b.VenueRevision == 0 {
+			return ""
+		}
+		return strconv.Itoa(b.VenueRevision)
+	}},
+	{Key: "status", Title: "Статус", Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return string(b.Status)
+	}},
+	{Key: "reason_for_cancel", Title: "Причина отмены", Value: func(b models.ReservationDetails, _ export.Locale) string {
+		return b.ReasonForCancel
+	}},
+	{Key: "created_at", Title: "Создана", Value: func(b models.ReservationDetails, l export.Locale) string {
+		return l.Time(b.CreatedAt)
+	}},
+}
+
+// ExportVenueBookings выгружает брони площадки за период в CSV или XLSX
+func (r *BookingHandler) ExportVenueBookings(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(400, gin.H{"error": "invalid venue ID"})
+		return
+	}
+
+	r.exportBookings(c, uint(id), fmt.Sprintf("venue-%d-bookings", id))
+}
+
+// ExportOwnerBookings выгружает брони всех площадок текущего владельца за период
+func (r *BookingHandler) ExportOwnerBookings(c *gin.Context) {
+	r.exportBookings(c, 0, "bookings")
+}
+
+func (r *BookingHandler) exportBookings(c *gin.Context, venueID uint, filename string) {
+	claimsVal, ok := c.Get("claims")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	claims, ok := claimsVal.(*models.Claims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid claims"})
+		return
+	}
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	locale, err := export.ParseLocale(c.Query("locale"), c.Query("tz"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	columns, err := export.SelectColumns(bookingExportColumns, c.Query("columns"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Даты периода задаются в часовом поясе выгрузки, to включительно
+	var from, to time.Time
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.ParseInLocation("2006-01-02", fromStr, locale.Location())
+		if err != nil {
+			c.JSON(400, gin.H{"error": "invalid from date format, use YYYY-MM-DD"})
+			return
+		}
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.ParseInLocation("2006-01-02", toStr, locale.Location())
+		if err != nil {
+			c.JSON(400, gin.H{"error": "invalid to date format, use YYYY-MM-DD"})
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		c.JSON(400, gin.H{"error": "from must not be after to"})
+		return
+	}
+
+	stream, err := r.bookingService.ExportBookings(venueID, from, to, claims)
+	if err != nil {
+		if err == errors.ErrForbidden || err == errors.ErrNotOwner {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, format.Filename(filename)))
+	c.Status(http.StatusOK)
+
+	table, err := export.NewTable(c.Writer, format, "Бронирования", columns, locale)
+	if err != nil {
+		log.Printf("Ошибка начала выгрузки броней: %v", err)
+		return
+	}
+
+	// Ответ уже начат, поэтому ошибки дальше только логируются: клиент получит оборванный файл
+	if err := stream(table.Write); err != nil {
+		log.Printf("Ошибка выгрузки броней: %v", err)
+		return
+	}
+	if err := table.Close(); err != nil {
+		log.Printf("Ошибка завершения выгрузки броней: %v", err)
+	}
+}
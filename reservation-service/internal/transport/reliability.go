@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"net/http"
+	"reservation/internal/dto"
+	"reservation/internal/errors"
+	"reservation/internal/middleware"
+	"reservation/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReliabilityHandler struct {
+	reliabilityService service.ReliabilityService
+}
+
+func NewReliabilityHandler(reliabilityService service.ReliabilityService) *ReliabilityHandler {
+	return &ReliabilityHandler{reliabilityService: reliabilityService}
+}
+
+func (h *ReliabilityHandler) Register(c *gin.Engine, jwtSecret string) {
+	c.GET("/reliability/clients/:id", middleware.AuthMiddleware(jwtSecret), h.GetClientReliability)
+	c.POST("/reliability/incidents/:id/forgive", middleware.AuthMiddleware(jwtSecret), h.ForgiveIncident)
+}
+
+// GetClientReliability - неявки и поздние отмены клиента (для администратора)
+func (h *ReliabilityHandler) GetClientReliability(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(400, gin.H{"error": "invalid client ID"})
+		return
+	}
+
+	reliability, err := h.reliabilityService.GetClientReliability(uint(id), claims)
+	if err != nil {
+		c.JSON(reliabilityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, reliability)
+}
+
+// ForgiveIncident прощает нарушение клиента, оно перестаёт учитываться политикой площадок
+func (h *ReliabilityHandler) ForgiveIncident(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(400, gin.H{"error": "invalid incident ID"})
+		return
+	}
+
+	var req dto.ForgiveIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	incident, err := h.reliabilityService.ForgiveIncident(uint(id), req.Reason, claims)
+	if err != nil {
+		c.JSON(reliabilityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, incident)
+}
+
+func reliabilityErrorStatus(err error) int {
+	switch err {
+	case errors.ErrForbidden, errors.ErrNotOwner:
+		return http.StatusForbidden
+	case errors.ErrIncidentNotFound:
+		return http.StatusNotFound
+	case errors.ErrIncidentForgiven:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -9,9 +9,17 @@ import (
 func RegisterRoutes(
 	r *gin.Engine,
 	reservationServ service.BookingService,
+	checkInServ service.CheckInService,
+	reliabilityServ service.ReliabilityService,
 	jwtSecret string,
 ){
 	reservationHandler := NewBookingHandler( reservationServ)
 
 	reservationHandler.Register(r, jwtSecret)
+
+	checkInHandler := NewCheckInHandler(checkInServ)
+	checkInHandler.Register(r, jwtSecret)
+
+	reliabilityHandler := NewReliabilityHandler(reliabilityServ)
+	reliabilityHandler.Register(r, jwtSecret)
 }
\ No newline at end of file
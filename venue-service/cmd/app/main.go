@@ -1,13 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
+	"venue-service/internal/clients"
 	"venue-service/internal/config"
+	"venue-service/internal/kafka"
 	"venue-service/internal/repository"
 	"venue-service/internal/services"
+	"venue-service/internal/storage"
 	"venue-service/internal/transport"
+	kafkaconsumer "venue-service/internal/transport/kafka"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -27,13 +35,79 @@ func main() {
 	}
 
 	venueRepo := repository.NewVenueRepository(db, logger)
-	venueService := services.NewVenueService(venueRepo, logger)
+	moderationPolicy := services.ModerationPolicy{
+		// Существенные правки одобренной площадки отправляют её на повторную модерацию
+		ReapproveMaterialEdits: config.GetEnv("VENUE_REAPPROVE_MATERIAL_EDITS", "false") == "true",
+	}
+	venueTypeRepo := repository.NewVenueTypeRepository(db, logger)
+	venueTypeService := services.NewVenueTypeService(venueTypeRepo, logger)
+	venueService := services.NewVenueService(venueRepo, venueTypeRepo, moderationPolicy, logger)
+	venueImportService := services.NewVenueImportService(venueRepo, venueTypeRepo, moderationPolicy, logger)
+
+	// Без Kafka избранное и сохранённые поиски работают, но события saved_search.matched не отправляются
+	var kafkaBrokers []string
+	if brokers := config.GetEnv("KAFKA_BROKERS", ""); brokers != "" {
+		kafkaBrokers = strings.Split(brokers, ",")
+	}
+	var producer kafka.Producer
+	if len(kafkaBrokers) > 0 {
+		producer = kafka.NewProducer(kafkaBrokers)
+		defer func() {
+			if err := producer.Close(); err != nil {
+				log.Printf("Ошибка закрытия Kafka продюсера: %v", err)
+			}
+		}()
+	} else {
+		logger.Warn("KAFKA_BROKERS не задан, события сохранённых поисков отключены", "layer", "config")
+	}
+	favoriteRepo := repository.NewFavoriteRepository(db, logger)
+	favoriteService := services.NewFavoriteService(venueRepo, favoriteRepo, logger)
+	savedSearchRepo := repository.NewSavedSearchRepository(db, logger)
+	savedSearchService := services.NewSavedSearchService(venueRepo, savedSearchRepo, producer, logger)
+	consumer := kafkaconsumer.NewConsumer(savedSearchService, kafkaBrokers, logger)
+	consumer.Start(context.Background())
+
+	moderationService := services.NewVenueModerationService(venueRepo, savedSearchService, logger)
+	unitRepo := repository.NewVenueUnitRepository(db, logger)
+	unitService := services.NewVenueUnitService(venueRepo, unitRepo, logger)
+	reviewRepo := repository.NewReviewRepository(db, logger)
+	reservationClient := clients.NewReservationClient(config.GetEnv("RESERVATION_SERVICE_URL", "http://localhost:8081"))
+	reviewService := services.NewVenueReviewService(venueRepo, reviewRepo, reservationClient, logger)
+	jwtSecret := config.GetEnv("JWT_SECRET", "")
+	if jwtSecret == "" {
+		log.Fatal("JWT_SECRET не задан")
+	}
+
+	mediaCfg, err := config.LoadMediaConfig()
+	if err != nil {
+		log.Fatalf("LoadMediaConfig: %v", err)
+	}
+	mediaStorage, err := config.NewStorage(mediaCfg)
+	if err != nil {
+		log.Fatalf("NewStorage: %v", err)
+	}
+	photoRepo := repository.NewVenuePhotoRepository(db, logger)
+	photoService := services.NewVenuePhotoService(venueRepo, photoRepo, mediaStorage, logger)
+	// Удалённые площадки хранятся столько дней, прежде чем администратор сможет удалить их окончательно
+	retentionDays, err := strconv.Atoi(config.GetEnv("VENUE_PURGE_RETENTION_DAYS", "30"))
+	if err != nil || retentionDays < 0 {
+		log.Fatal("VENUE_PURGE_RETENTION_DAYS должен быть неотрицательным числом")
+	}
+	deletedVenueService := services.NewDeletedVenueService(venueRepo, reservationClient, mediaStorage, time.Duration(retentionDays)*24*time.Hour, logger)
+	media := transport.MediaConfig{
+		URL:            mediaStorage.URL,
+		MaxUploadBytes: mediaCfg.MaxUploadBytes,
+	}
+	if local, ok := mediaStorage.(*storage.LocalStorage); ok {
+		media.LocalRoot = local.Root()
+	}
+
 	r := gin.Default()
 
 	// Отключаем доверие прокси для локальной разработки
 	r.SetTrustedProxies(nil)
 
-	transport.RegisterRoutes(r, logger, venueService)
+	transport.RegisterRoutes(r, logger, venueService, unitService, reviewService, photoService, moderationService, venueTypeService, venueImportService, deletedVenueService, favoriteService, savedSearchService, media, jwtSecret)
 
 	if err := r.Run(fmt.Sprintf(":%s", config.GetEnv("PORT", "8080"))); err != nil {
 		log.Fatalf("Ошибка запуска сервера: %v", err)
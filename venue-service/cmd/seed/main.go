@@ -2,17 +2,35 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"venue-service/internal/config"
 	"venue-service/internal/seed"
 
 	"github.com/joho/godotenv"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
 func main() {
+	defaults := seed.DefaultGenerateConfig()
+
 	forceFlag := flag.Bool("force", false, "Принудительно перезаписать данные (удалить существующие и создать новые)")
+	generateFlag := flag.Bool("generate", false, "Сгенерировать согласованные данные для баз всех сервисов")
+	resetFlag := flag.Bool("reset", false, "Перед генерацией очистить базы всех сервисов")
+	seedFlag := flag.Uint64("seed", defaults.Seed, "Seed генератора: одинаковый seed и параметры дают одинаковые данные")
+	ownersFlag := flag.Int("owners", defaults.Owners, "Количество владельцев площадок")
+	clientsFlag := flag.Int("clients", defaults.Clients, "Количество клиентов")
+	districtsFlag := flag.String("districts", strings.Join(defaults.Districts, ","), "Районы через запятую")
+	venuesFlag := flag.Int("venues-per-district", defaults.VenuesPerDistrict, "Количество площадок в каждом районе")
+	bookingsFlag := flag.Int("bookings", defaults.Bookings, "Количество броней")
+	weeksBackFlag := flag.Int("weeks-back", defaults.WeeksBack, "За сколько недель до даты отсчёта начинаются брони")
+	weeksAheadFlag := flag.Int("weeks-ahead", defaults.WeeksAhead, "На сколько недель после даты отсчёта есть брони")
+	anchorFlag := flag.String("anchor", defaults.Anchor.Format(time.DateOnly), "Дата отсчёта (YYYY-MM-DD): брони до неё завершены, после - предстоят")
 	flag.Parse()
 
 	if err := godotenv.Load(); err != nil {
@@ -27,6 +45,61 @@ func main() {
 		log.Fatalf("ConnectDB: %v", err)
 	}
 
+	if *generateFlag {
+		anchor, err := time.Parse(time.DateOnly, *anchorFlag)
+		if err != nil {
+			log.Fatalf("Неверная дата -anchor: %v", err)
+		}
+		cfg := seed.GenerateConfig{
+			Seed:              *seedFlag,
+			Owners:            *ownersFlag,
+			Clients:           *clientsFlag,
+			Districts:         splitList(*districtsFlag),
+			VenuesPerDistrict: *venuesFlag,
+			Bookings:          *bookingsFlag,
+			WeeksBack:         *weeksBackFlag,
+			WeeksAhead:        *weeksAheadFlag,
+			Anchor:            anchor,
+		}
+
+		dbs := seed.Databases{Venues: db}
+		for _, target := range []struct {
+			db     **gorm.DB
+			env    string
+			port   string
+			dbName string
+		}{
+			{&dbs.Users, "SEED_USER_DB_DSN", "5433", "user_db"},
+			{&dbs.Reservations, "SEED_RESERVATION_DB_DSN", "5436", "reservation_db"},
+			{&dbs.Payments, "SEED_PAYMENT_DB_DSN", "5435", "payment_db"},
+		} {
+			conn, err := gorm.Open(postgres.Open(serviceDSN(target.env, target.port, target.dbName)))
+			if err != nil {
+				logger.Error("Ошибка подключения к БД", "layer", "config", "db", target.dbName, "error", err)
+				log.Fatalf("ConnectDB %s: %v", target.dbName, err)
+			}
+			*target.db = conn
+		}
+
+		logger.Info("Генерация данных", "seed", cfg.Seed, "anchor", *anchorFlag, "reset", *resetFlag)
+		plan, err := seed.Generate(cfg)
+		if err != nil {
+			logger.Error("Ошибка генерации данных", "error", err)
+			os.Exit(1)
+		}
+		if *resetFlag {
+			if err := seed.Reset(dbs, logger); err != nil {
+				logger.Error("Ошибка очистки баз", "error", err)
+				os.Exit(1)
+			}
+		}
+		if err := seed.Apply(dbs, plan, logger); err != nil {
+			logger.Error("Ошибка записи данных", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if *forceFlag {
 		logger.Info("Запуск сидов с принудительным перезаписыванием")
 		if err := seed.SeedVenuesForce(db, logger); err != nil {
@@ -44,3 +117,30 @@ func main() {
 	}
 	logger.Info("Сиды успешно выполнены")
 }
+
+// serviceDSN берёт строку подключения к базе другого сервиса из env. По умолчанию -
+// порт базы из docker-compose на хосте DB_HOST с учётными данными DB_USER/DB_PASSWORD
+func serviceDSN(env, port, dbName string) string {
+	if dsn := os.Getenv(env); dsn != "" {
+		return dsn
+	}
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		config.GetEnv("DB_HOST", "localhost"),
+		config.GetEnv("DB_USER", "postgres"),
+		config.GetEnv("DB_PASSWORD", "postgres"),
+		dbName,
+		port,
+		config.GetEnv("DB_SSLMODE", "disable"),
+	)
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
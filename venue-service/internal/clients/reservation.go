@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrBookingNotFound = errors.New("booking not found")
+
+// Booking - данные брони из reservation-service, нужные venue-service
+type Booking struct {
+	ID       uint   `json:"id"`
+	VenueID  uint   `json:"venue_id"`
+	ClientID uint   `json:"client_id"`
+	Status   string `json:"status"`
+}
+
+type ReservationClient interface {
+	GetBooking(id uint) (*Booking, error)
+	// CountUpcomingBookings - число действующих броней площадки, которые ещё не закончились
+	CountUpcomingBookings(venueID uint) (int64, error)
+}
+
+type reservationClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewReservationClient(baseURL string) ReservationClient {
+	return &reservationClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (c *reservationClient) GetBooking(id uint) (*Booking, error) {
+	resp, err := c.client.Get(fmt.Sprintf("%s/bookings/%d", c.baseURL, id))
+	if err != nil {
+		return nil, fmt.Errorf("reservation-service недоступен: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrBookingNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reservation-service вернул статус %d", resp.StatusCode)
+	}
+
+	var booking Booking
+	if err := json.NewDecoder(resp.Body).Decode(&booking); err != nil {
+		return nil, fmt.Errorf("неверный ответ reservation-service: %w", err)
+	}
+	return &booking, nil
+}
+
+func (c *reservationClient) CountUpcomingBookings(venueID uint) (int64, error) {
+	resp, err := c.client.Get(fmt.Sprintf("%s/internal/venues/%d/bookings/upcoming", c.baseURL, venueID))
+	if err != nil {
+		return 0, fmt.Errorf("reservation-service недоступен: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("reservation-service вернул статус %d", resp.StatusCode)
+	}
+
+	var upcoming struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&upcoming); err != nil {
+		return 0, fmt.Errorf("неверный ответ reservation-service: %w", err)
+	}
+	return upcoming.Count, nil
+}
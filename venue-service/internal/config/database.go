@@ -7,6 +7,7 @@ import (
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func ConnectDB() (*gorm.DB, error) {
@@ -47,10 +48,35 @@ func ConnectDB() (*gorm.DB, error) {
 		}
 	}
 
-	if err := db.AutoMigrate(&models.Venue{}); err != nil {
+	// Применимость удобств к типам раньше была зашита в код, колонка amenities заполняется при её появлении
+	var hasTypeAmenities bool
+	err = sqlDB.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_name = 'venue_types' AND column_name = 'amenities'
+		)
+	`).Scan(&hasTypeAmenities)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки колонки amenities: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.VenueTypeDefinition{}, &models.Venue{}, &models.VenueUnit{}, &models.Review{}, &models.VenueAmenity{}, &models.VenuePhoto{}, &models.VenueRevision{}, &models.FavoriteVenue{}, &models.SavedSearch{}); err != nil {
 		return nil, fmt.Errorf("ошибка при миграции базы данных: %w", err)
 	}
 
+	// Типы площадок, которые раньше были зашиты в код. Изменения администратора не перезаписываются
+	defaultTypes := models.DefaultVenueTypes()
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&defaultTypes).Error; err != nil {
+		return nil, fmt.Errorf("ошибка заполнения типов площадок: %w", err)
+	}
+	if !hasTypeAmenities {
+		for _, venueType := range defaultTypes {
+			if err := db.Model(&models.VenueTypeDefinition{}).Where("code = ?", venueType.Code).Update("amenities", venueType.Amenities).Error; err != nil {
+				return nil, fmt.Errorf("ошибка заполнения удобств типов площадок: %w", err)
+			}
+		}
+	}
+
 	return db, nil
 }
 
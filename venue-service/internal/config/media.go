@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"venue-service/internal/storage"
+)
+
+// MediaConfig настройки хранилища фотографий площадок
+type MediaConfig struct {
+	Storage        string // Бэкенд хранилища, пока только local
+	Root           string // Каталог файлов для local
+	BaseURL        string // Префикс публичных URL файлов
+	MaxUploadBytes int64
+}
+
+func LoadMediaConfig() (MediaConfig, error) {
+	maxMB, err := strconv.Atoi(GetEnv("MEDIA_MAX_UPLOAD_MB", "10"))
+	if err != nil || maxMB < 1 {
+		return MediaConfig{}, fmt.Errorf("MEDIA_MAX_UPLOAD_MB должен быть положительным числом")
+	}
+	return MediaConfig{
+		Storage:        GetEnv("MEDIA_STORAGE", "local"),
+		Root:           GetEnv("MEDIA_ROOT", "./media"),
+		BaseURL:        GetEnv("MEDIA_BASE_URL", "/api/media"),
+		MaxUploadBytes: int64(maxMB) << 20,
+	}, nil
+}
+
+// NewStorage создает хранилище по настройкам. Сюда добавляются другие бэкенды (например, S3-совместимый)
+func NewStorage(cfg MediaConfig) (storage.Storage, error) {
+	switch cfg.Storage {
+	case "local":
+		return storage.NewLocalStorage(cfg.Root, cfg.BaseURL)
+	default:
+		return nil, fmt.Errorf("неизвестное хранилище медиа: %s", cfg.Storage)
+	}
+}
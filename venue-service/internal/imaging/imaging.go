@@ -0,0 +1,146 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+var ErrUnsupportedImage = errors.New("поддерживаются только изображения JPEG и PNG")
+
+const (
+	// MaxDimension ограничивает сторону изображения, чтобы не распаковывать огромные картинки в память
+	MaxDimension = 8000
+	// MinDimension - меньше этого фотографии бесполезны для карточки площадки
+	MinDimension = 200
+
+	jpegQuality = 85
+)
+
+// Размеры уменьшенных копий по длинной стороне
+var Variants = []Variant{
+	{Name: "medium", MaxSide: 1280},
+	{Name: "thumb", MaxSide: 320},
+}
+
+type Variant struct {
+	Name    string
+	MaxSide int
+}
+
+// Image проверенное исходное изображение
+type Image struct {
+	ContentType string
+	Ext         string
+	Width       int
+	Height      int
+	img         image.Image
+}
+
+// Decode проверяет тип по содержимому (а не по имени файла) и размеры изображения
+func Decode(data []byte) (*Image, error) {
+	contentType := http.DetectContentType(data)
+	var ext string
+	switch contentType {
+	case "image/jpeg":
+		ext = "jpg"
+	case "image/png":
+		ext = "png"
+	default:
+		return nil, ErrUnsupportedImage
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать изображение: %w", err)
+	}
+	if cfg.Width > MaxDimension || cfg.Height > MaxDimension {
+		return nil, fmt.Errorf("изображение больше %dx%d", MaxDimension, MaxDimension)
+	}
+	if cfg.Width < MinDimension || cfg.Height < MinDimension {
+		return nil, fmt.Errorf("изображение меньше %dx%d", MinDimension, MinDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать изображение: %w", err)
+	}
+	return &Image{
+		ContentType: contentType,
+		Ext:         ext,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		img:         img,
+	}, nil
+}
+
+// Thumbnails строит уменьшенные копии в JPEG по списку Variants.
+// Каждая следующая копия строится из предыдущей: так быстрее и без заметной потери качества
+func (im *Image) Thumbnails() (map[string][]byte, error) {
+	result := make(map[string][]byte, len(Variants))
+	src := im.img
+	for _, v := range Variants {
+		w, h := fit(src.Bounds().Dx(), src.Bounds().Dy(), v.MaxSide)
+		src = downscale(src, w, h)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, src, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		result[v.Name] = buf.Bytes()
+	}
+	return result, nil
+}
+
+// fit вписывает размеры в квадрат maxSide с сохранением пропорций, не увеличивая изображение
+func fit(w, h, maxSide int) (int, int) {
+	if w <= maxSide && h <= maxSide {
+		return w, h
+	}
+	if w >= h {
+		return maxSide, max(1, h*maxSide/w)
+	}
+	return max(1, w*maxSide/h), maxSide
+}
+
+// downscale уменьшает изображение усреднением по площади.
+// Прозрачные пиксели PNG накладываются на белый фон, так как результат кодируется в JPEG
+func downscale(src image.Image, w, h int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*sh/h
+		y1 := max(b.Min.Y+(y+1)*sh/h, y0+1)
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*sw/w
+			x1 := max(b.Min.X+(x+1)*sw/w, x0+1)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					bl += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			// Цвета premultiplied: фон добавляется в долю, не закрытую альфой
+			bg := 0xffff - a/n
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r/n + bg) >> 8),
+				G: uint8((g/n + bg) >> 8),
+				B: uint8((bl/n + bg) >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
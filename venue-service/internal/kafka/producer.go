@@ -0,0 +1,51 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"contracts/events"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Producer публикует события сохранённых поисков в формате общих контрактов (contracts/events)
+type Producer interface {
+	PublishSavedSearchMatched(ctx context.Context, meta events.Meta, evt events.SavedSearchMatchedV1) error
+	Close() error
+}
+
+type kafkaGoProducer struct {
+	writer *kafkago.Writer
+}
+
+func NewProducer(brokers []string) Producer {
+	return &kafkaGoProducer{
+		writer: &kafkago.Writer{
+			Addr:         kafkago.TCP(brokers...),
+			Balancer:     &kafkago.Hash{}, // Сообщения с одним ключом попадают в одну партицию
+			RequiredAcks: kafkago.RequireOne,
+		},
+	}
+}
+
+// PublishSavedSearchMatched отправляет событие с ключом по клиенту,
+// чтобы события одного клиента читались по порядку
+func (p *kafkaGoProducer) PublishSavedSearchMatched(ctx context.Context, meta events.Meta, evt events.SavedSearchMatchedV1) error {
+	b, err := events.Encode(meta, evt)
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafkago.Message{
+		Topic: events.TopicSavedSearchMatched,
+		Key:   []byte(fmt.Sprintf("%d", evt.ClientID)),
+		Value: b,
+		Time:  time.Now(),
+	})
+}
+
+func (p *kafkaGoProducer) Close() error {
+	return p.writer.Close()
+}
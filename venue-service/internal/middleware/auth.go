@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"venue-service/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	errNoAuthHeader      = errors.New("отсутствует заголовок Authorization")
+	errInvalidAuthHeader = errors.New("неверный формат заголовка Authorization")
+	errInvalidToken      = errors.New("неверный токен")
+)
+
+// AuthMiddleware проверяет JWT из заголовка Authorization и сохраняет claims в контексте
+func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := parseClaims(c, jwtSecret)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set("claims", claims)
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware сохраняет claims, если передан валидный токен.
+// Для публичных маршрутов, где авторизованный пользователь видит больше
+func OptionalAuthMiddleware(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := parseClaims(c, jwtSecret)
+		if err == nil {
+			c.Set("claims", claims)
+		} else if !errors.Is(err, errNoAuthHeader) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.Next()
+	}
+}
+
+func parseClaims(c *gin.Context, jwtSecret string) (*models.Claims, error) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return nil, errNoAuthHeader
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, errInvalidAuthHeader
+	}
+
+	claims := &models.Claims{}
+	token, err := jwt.ParseWithClaims(parts[1], claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(jwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, errInvalidToken
+	}
+	return claims, nil
+}
+
+// ClaimsFromContext возвращает claims, сохранённые AuthMiddleware
+func ClaimsFromContext(c *gin.Context) (*models.Claims, bool) {
+	value, ok := c.Get("claims")
+	if !ok {
+		return nil, false
+	}
+	claims, ok := value.(*models.Claims)
+	return claims, ok
+}
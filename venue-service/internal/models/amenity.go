@@ -0,0 +1,150 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// AmenityGroup группа удобств. В группах Exclusive у площадки может быть не больше одного значения
+type AmenityGroup struct {
+	Code      string `json:"code"`
+	Label     string `json:"label"`
+	Exclusive bool   `json:"exclusive"`
+}
+
+// Amenity элемент каталога удобств и характеристик площадки.
+// TypeSpecific - удобство применимо только к типам площадок, у которых оно указано в venue_types.amenities,
+// остальные применимы ко всем типам
+type Amenity struct {
+	Code         string `json:"code"`
+	Label        string `json:"label"`
+	Group        string `json:"group"`
+	TypeSpecific bool   `json:"type_specific,omitempty"`
+}
+
+const (
+	AmenityGroupPlacement  = "placement"
+	AmenityGroupSurface    = "surface"
+	AmenityGroupFacilities = "facilities"
+)
+
+var amenityGroups = []AmenityGroup{
+	{Code: AmenityGroupPlacement, Label: "Расположение", Exclusive: true},
+	{Code: AmenityGroupSurface, Label: "Покрытие", Exclusive: true},
+	{Code: AmenityGroupFacilities, Label: "Удобства"},
+}
+
+// amenityCatalog каталог удобств. Коды хранятся в venue_amenities и venue_types.amenities, менять их нельзя
+var amenityCatalog = []Amenity{
+	{Code: "indoor", Label: "В помещении", Group: AmenityGroupPlacement},
+	{Code: "outdoor", Label: "На открытом воздухе", Group: AmenityGroupPlacement, TypeSpecific: true},
+
+	{Code: "surface_grass", Label: "Натуральная трава", Group: AmenityGroupSurface, TypeSpecific: true},
+	{Code: "surface_artificial", Label: "Искусственная трава", Group: AmenityGroupSurface, TypeSpecific: true},
+	{Code: "surface_parquet", Label: "Паркет", Group: AmenityGroupSurface, TypeSpecific: true},
+	{Code: "surface_clay", Label: "Грунт", Group: AmenityGroupSurface, TypeSpecific: true},
+	{Code: "surface_hard", Label: "Хард", Group: AmenityGroupSurface, TypeSpecific: true},
+
+	{Code: "lighting", Label: "Освещение", Group: AmenityGroupFacilities, TypeSpecific: true},
+	{Code: "changing_rooms", Label: "Раздевалки", Group: AmenityGroupFacilities},
+	{Code: "showers", Label: "Душевые", Group: AmenityGroupFacilities},
+	{Code: "parking", Label: "Парковка", Group: AmenityGroupFacilities},
+	{Code: "equipment_rental", Label: "Прокат инвентаря", Group: AmenityGroupFacilities},
+}
+
+// AmenityGroups возвращает группы удобств
+func AmenityGroups() []AmenityGroup {
+	return amenityGroups
+}
+
+// AmenityCatalog возвращает весь каталог удобств
+func AmenityCatalog() []Amenity {
+	return amenityCatalog
+}
+
+// FindAmenity ищет удобство по коду
+func FindAmenity(code string) (Amenity, bool) {
+	for _, a := range amenityCatalog {
+		if a.Code == code {
+			return a, true
+		}
+	}
+	return Amenity{}, false
+}
+
+// AppliesTo сообщает, применимо ли удобство к типу площадки
+func (a Amenity) AppliesTo(vt *VenueTypeDefinition) bool {
+	return !a.TypeSpecific || slices.Contains(vt.Amenities, a.Code)
+}
+
+// AmenitiesFor возвращает удобства, применимые к типу площадки
+func AmenitiesFor(vt *VenueTypeDefinition) []Amenity {
+	var result []Amenity
+	for _, a := range amenityCatalog {
+		if a.AppliesTo(vt) {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// ValidateAmenities проверяет набор удобств площадки: коды из каталога, применимы к типу,
+// без повторов и не больше одного значения в исключающих группах.
+// Возвращает коды в отсортированном виде
+func ValidateAmenities(vt *VenueTypeDefinition, codes []string) ([]string, error) {
+	seen := make(map[string]bool, len(codes))
+	groupValue := make(map[string]string)
+	result := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		amenity, ok := FindAmenity(code)
+		if !ok {
+			return nil, fmt.Errorf("неизвестное удобство: %s", code)
+		}
+		if !amenity.AppliesTo(vt) {
+			return nil, fmt.Errorf("удобство %s неприменимо к типу площадки %s", code, vt.Code)
+		}
+		for _, g := range amenityGroups {
+			if g.Code != amenity.Group || !g.Exclusive {
+				continue
+			}
+			if prev, ok := groupValue[g.Code]; ok {
+				return nil, fmt.Errorf("удобства %s и %s взаимоисключающие", prev, code)
+			}
+			groupValue[g.Code] = code
+		}
+		result = append(result, code)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// validateTypeAmenities проверяет удобства, которые администратор делает применимыми к типу площадки:
+// только коды каталога, доступные не всем типам
+func validateTypeAmenities(codes []string) error {
+	for _, code := range codes {
+		amenity, ok := FindAmenity(code)
+		if !ok {
+			return fmt.Errorf("неизвестное удобство: %s", code)
+		}
+		if !amenity.TypeSpecific {
+			return fmt.Errorf("удобство %s применимо ко всем типам площадок, указывать его не нужно", code)
+		}
+	}
+	return nil
+}
+
+// VenueAmenity удобство, отмеченное у площадки
+type VenueAmenity struct {
+	VenueID uint   `json:"venue_id" gorm:"column:venue_id;primaryKey"`
+	Code    string `json:"code" gorm:"column:code;type:varchar(50);primaryKey;index"`
+}
+
+func (VenueAmenity) TableName() string {
+	return "venue_amenities"
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// FavoriteVenue - площадка в избранном пользователя
+type FavoriteVenue struct {
+	UserID    uint      `json:"user_id" gorm:"column:user_id;primaryKey;autoIncrement:false"`
+	VenueID   uint      `json:"venue_id" gorm:"column:venue_id;primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (FavoriteVenue) TableName() string {
+	return "favorite_venues"
+}
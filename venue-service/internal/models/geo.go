@@ -0,0 +1,42 @@
+package models
+
+import "math"
+
+// GeoPoint точка поиска ближайших площадок.
+// RadiusKm = 0 - без ограничения по расстоянию, только сортировка по удалённости
+type GeoPoint struct {
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+	RadiusKm float64 `json:"radius_km,omitempty"`
+}
+
+// BoundingBox прямоугольная область карты.
+// Если MinLng > MaxLng, область пересекает 180-й меридиан
+type BoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLng float64 `json:"max_lng"`
+}
+
+// earthRadiusKm - средний радиус Земли, тот же, что в SQL-формуле расстояния репозитория
+const earthRadiusKm = 6371.0
+
+// DistanceKm возвращает расстояние от точки до координат в км по формуле гаверсинусов
+func (p GeoPoint) DistanceKm(lat, lng float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	h := math.Pow(math.Sin(toRad(lat-p.Lat)/2), 2) +
+		math.Cos(toRad(p.Lat))*math.Cos(toRad(lat))*math.Pow(math.Sin(toRad(lng-p.Lng)/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Contains сообщает, лежат ли координаты внутри области, с учётом пересечения 180-го меридиана
+func (b BoundingBox) Contains(lat, lng float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLng <= b.MaxLng {
+		return lng >= b.MinLng && lng <= b.MaxLng
+	}
+	return lng >= b.MinLng || lng <= b.MaxLng
+}
@@ -0,0 +1,67 @@
+package models
+
+// VenueStatus - этап модерации площадки. В публичной выдаче только опубликованные площадки
+type VenueStatus string
+
+const (
+	VenueDraft     VenueStatus = "draft"     // Черновик, виден только владельцу
+	VenueSubmitted VenueStatus = "submitted" // Отправлена на модерацию
+	VenueApproved  VenueStatus = "approved"  // Одобрена, владелец может опубликовать
+	VenueRejected  VenueStatus = "rejected"  // Отклонена с причиной, после правок отправляется повторно
+	VenuePublished VenueStatus = "published" // Опубликована, доступна для поиска и бронирования
+	VenueSuspended VenueStatus = "suspended" // Приостановлена администратором
+)
+
+func (s VenueStatus) IsValid() bool {
+	switch s {
+	case VenueDraft, VenueSubmitted, VenueApproved, VenueRejected, VenuePublished, VenueSuspended:
+		return true
+	default:
+		return false
+	}
+}
+
+// ModerationAction - действие, переводящее площадку между статусами
+type ModerationAction string
+
+const (
+	ActionSubmit    ModerationAction = "submit"
+	ActionApprove   ModerationAction = "approve"
+	ActionReject    ModerationAction = "reject"
+	ActionPublish   ModerationAction = "publish"
+	ActionSuspend   ModerationAction = "suspend"
+	ActionReinstate ModerationAction = "reinstate"
+)
+
+// ModerationTransition описывает действие: из каких статусов, в какой и кому доступно
+type ModerationTransition struct {
+	From           []VenueStatus
+	To             VenueStatus
+	AdminOnly      bool // Иначе действие доступно и владельцу площадки
+	ReasonRequired bool // Причина сохраняется в ModerationComment
+}
+
+var moderationTransitions = map[ModerationAction]ModerationTransition{
+	ActionSubmit:    {From: []VenueStatus{VenueDraft, VenueRejected}, To: VenueSubmitted},
+	ActionApprove:   {From: []VenueStatus{VenueSubmitted}, To: VenueApproved, AdminOnly: true},
+	ActionReject:    {From: []VenueStatus{VenueSubmitted}, To: VenueRejected, AdminOnly: true, ReasonRequired: true},
+	ActionPublish:   {From: []VenueStatus{VenueApproved}, To: VenuePublished},
+	ActionSuspend:   {From: []VenueStatus{VenuePublished}, To: VenueSuspended, AdminOnly: true, ReasonRequired: true},
+	ActionReinstate: {From: []VenueStatus{VenueSuspended}, To: VenuePublished, AdminOnly: true},
+}
+
+// FindTransition возвращает описание действия модерации
+func FindTransition(action ModerationAction) (ModerationTransition, bool) {
+	t, ok := moderationTransitions[action]
+	return t, ok
+}
+
+// Allows сообщает, можно ли выполнить действие из статуса from
+func (t ModerationTransition) Allows(from VenueStatus) bool {
+	for _, s := range t.From {
+		if s == from {
+			return true
+		}
+	}
+	return false
+}
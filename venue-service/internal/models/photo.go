@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// MaxVenuePhotos - сколько фотографий можно загрузить для одной площадки
+const MaxVenuePhotos = 30
+
+// VenuePhoto - фотография площадки. Файлы лежат в хранилище медиа по ключам,
+// URL строится хранилищем при выдаче. У площадки не больше одной обложки (IsCover)
+type VenuePhoto struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	VenueID     uint      `json:"venue_id" gorm:"column:venue_id;not null;index;uniqueIndex:idx_venue_photos_cover,where:is_cover"`
+	Position    int       `json:"position" gorm:"column:position;not null;default:0"`
+	IsCover     bool      `json:"is_cover" gorm:"column:is_cover;not null;default:false"`
+	ContentType string    `json:"content_type" gorm:"column:content_type;type:varchar(50);not null"`
+	Width       int       `json:"width" gorm:"column:width;not null"`
+	Height      int       `json:"height" gorm:"column:height;not null"`
+	SizeBytes   int64     `json:"size_bytes" gorm:"column:size_bytes;not null"`
+	OriginalKey string    `json:"-" gorm:"column:original_key;type:varchar(255);not null"`
+	MediumKey   string    `json:"-" gorm:"column:medium_key;type:varchar(255);not null"`
+	ThumbKey    string    `json:"-" gorm:"column:thumb_key;type:varchar(255);not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (VenuePhoto) TableName() string {
+	return "venue_photos"
+}
+
+// Keys возвращает ключи всех файлов фотографии
+func (p *VenuePhoto) Keys() []string {
+	return []string{p.OriginalKey, p.MediumKey, p.ThumbKey}
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MinReviewRating     = 1
+	MaxReviewRating     = 5
+	MaxReviewTextLength = 2000
+)
+
+// Review - отзыв клиента о площадке по завершённой брони (один отзыв на бронь).
+// Скрытые администратором отзывы не показываются и не учитываются в рейтинге площадки
+type Review struct {
+	gorm.Model
+	VenueID      uint       `json:"venue_id" gorm:"column:venue_id;not null;index"`
+	BookingID    uint       `json:"booking_id" gorm:"column:booking_id;not null;uniqueIndex"`
+	ClientID     uint       `json:"client_id" gorm:"column:client_id;not null;index"`
+	Rating       int        `json:"rating" gorm:"column:rating;not null;check:rating >= 1 AND rating <= 5"`
+	Text         string     `json:"text" gorm:"column:text;type:text"`
+	Reply        *string    `json:"reply,omitempty" gorm:"column:reply;type:text"` // Ответ владельца (только один)
+	RepliedAt    *time.Time `json:"replied_at,omitempty" gorm:"column:replied_at"`
+	IsHidden     bool       `json:"is_hidden" gorm:"column:is_hidden;not null;default:false"`
+	HiddenReason string     `json:"hidden_reason,omitempty" gorm:"column:hidden_reason;type:varchar(255)"`
+}
+
+func (Review) TableName() string {
+	return "reviews"
+}
+
+// validateReview проверяет валидность данных отзыва
+func (r *Review) validateReview() error {
+	if r.Rating < MinReviewRating || r.Rating > MaxReviewRating {
+		return fmt.Errorf("оценка должна быть от %d до %d", MinReviewRating, MaxReviewRating)
+	}
+	if utf8.RuneCountInString(r.Text) > MaxReviewTextLength {
+		return fmt.Errorf("текст отзыва не должен превышать %d символов", MaxReviewTextLength)
+	}
+	if r.Reply != nil && utf8.RuneCountInString(*r.Reply) > MaxReviewTextLength {
+		return fmt.Errorf("ответ на отзыв не должен превышать %d символов", MaxReviewTextLength)
+	}
+	return nil
+}
+
+func (r *Review) BeforeCreate(tx *gorm.DB) error {
+	return r.validateReview()
+}
+
+func (r *Review) BeforeUpdate(tx *gorm.DB) error {
+	return r.validateReview()
+}
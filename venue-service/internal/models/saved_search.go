@@ -0,0 +1,184 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MaxSavedSearches         = 20 // Сохранённых поисков на пользователя
+	MaxSavedSearchNameLength = 100
+)
+
+// SavedSearch - сохранённый поиск площадок. Когда под него подходит новая площадка
+// или освободившееся после отмены время, клиенту отправляется событие saved_search.matched
+type SavedSearch struct {
+	gorm.Model
+	UserID uint              `json:"user_id" gorm:"column:user_id;not null;index"`
+	Name   string            `json:"name" gorm:"column:name;type:varchar(100);not null"`
+	Filter SavedSearchFilter `json:"filter" gorm:"column:filter;type:jsonb;serializer:json"`
+	Time   TimePreference    `json:"time" gorm:"column:time_preference;type:jsonb;serializer:json"`
+}
+
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// SavedSearchFilter - фильтр списка площадок (как в GET /venues), без сортировки и пагинации
+type SavedSearchFilter struct {
+	Districts  []string    `json:"districts,omitempty"`
+	VenueTypes []VenueType `json:"venue_types,omitempty"`
+	MinPrice   int         `json:"min_price,omitempty"`
+	MaxPrice   int         `json:"max_price,omitempty"`
+	MinRating  float64     `json:"min_rating,omitempty"`
+
+	Geo  *GeoPoint    `json:"geo,omitempty"`
+	BBox *BoundingBox `json:"bbox,omitempty"`
+
+	Amenities      []string `json:"amenities,omitempty"`
+	AmenitiesMatch string   `json:"amenities_match,omitempty"`
+}
+
+// Matches проверяет площадку по фильтру так же, как GET /venues, но без запроса к базе.
+// Удобства берутся из venue.Amenities, поэтому площадка должна быть загружена вместе с ними
+func (f SavedSearchFilter) Matches(venue *Venue) bool {
+	if len(f.Districts) > 0 && !slices.Contains(f.Districts, venue.District) {
+		return false
+	}
+	if len(f.VenueTypes) > 0 && !slices.Contains(f.VenueTypes, venue.VenueType) {
+		return false
+	}
+	if f.MinPrice > 0 && venue.HourPrice < f.MinPrice {
+		return false
+	}
+	if f.MaxPrice > 0 && venue.HourPrice > f.MaxPrice {
+		return false
+	}
+	if f.MinRating > 0 && venue.Rating < f.MinRating {
+		return false
+	}
+	if !f.matchesAmenities(venue.Amenities) {
+		return false
+	}
+
+	// Гео-фильтры: площадки без координат не подходят
+	if f.Geo == nil && f.BBox == nil {
+		return true
+	}
+	if venue.Latitude == nil || venue.Longitude == nil {
+		return false
+	}
+	lat, lng := *venue.Latitude, *venue.Longitude
+	if f.BBox != nil && !f.BBox.Contains(lat, lng) {
+		return false
+	}
+	return f.Geo == nil || f.Geo.RadiusKm <= 0 || f.Geo.DistanceKm(lat, lng) <= f.Geo.RadiusKm
+}
+
+// matchesAmenities: "any" - есть хотя бы одно удобство, иначе нужны все
+func (f SavedSearchFilter) matchesAmenities(amenities []VenueAmenity) bool {
+	if len(f.Amenities) == 0 {
+		return true
+	}
+	found := 0
+	for _, code := range f.Amenities {
+		if slices.ContainsFunc(amenities, func(a VenueAmenity) bool { return a.Code == code }) {
+			found++
+		}
+	}
+	if f.AmenitiesMatch == "any" {
+		return found > 0
+	}
+	return found == len(f.Amenities)
+}
+
+// TimePreference - удобное клиенту время. Пустые дни - любой день, пустые From и To - весь день
+type TimePreference struct {
+	Days []time.Weekday `json:"days,omitempty"`
+	From string         `json:"from,omitempty"` // "HH:MM"
+	To   string         `json:"to,omitempty"`   // "HH:MM", не включая
+}
+
+// Validate проверяет название и удобное время
+func (s *SavedSearch) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("название поиска обязательно")
+	}
+	if utf8.RuneCountInString(s.Name) > MaxSavedSearchNameLength {
+		return fmt.Errorf("название поиска не должно превышать %d символов", MaxSavedSearchNameLength)
+	}
+	return s.Time.validate()
+}
+
+func (s *SavedSearch) BeforeCreate(tx *gorm.DB) error {
+	return s.Validate()
+}
+
+func (s *SavedSearch) BeforeUpdate(tx *gorm.DB) error {
+	return s.Validate()
+}
+
+func (p TimePreference) validate() error {
+	for _, day := range p.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("неверный день недели: %d", day)
+		}
+	}
+	for _, value := range []string{p.From, p.To} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(TimeLayout, value); err != nil {
+			return fmt.Errorf("неверный формат времени: %s", value)
+		}
+	}
+	if p.From != "" && p.To != "" && p.From >= p.To {
+		return fmt.Errorf("from должно быть раньше to")
+	}
+	return nil
+}
+
+// MatchesSlot сообщает, попадает ли интервал брони в удобное время хотя бы частично.
+// День недели и часы берутся в том часовом поясе, в котором задан start
+func (p TimePreference) MatchesSlot(start, end time.Time) bool {
+	if !p.allowsDay(start.Weekday()) {
+		return false
+	}
+	endClock := end.In(start.Location()).Format(TimeLayout)
+	if y, m, d := start.Date(); !end.Before(time.Date(y, m, d+1, 0, 0, 0, 0, start.Location())) {
+		endClock = "24:00"
+	}
+	return p.overlaps(start.Format(TimeLayout), endClock)
+}
+
+// MatchesSchedule сообщает, работает ли площадка в удобное время хотя бы в один из удобных дней
+func (p TimePreference) MatchesSchedule(w Weekdays) bool {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if !p.allowsDay(day) {
+			continue
+		}
+		for _, window := range w.Day(day).Windows() {
+			if p.overlaps(window.Start, window.End) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p TimePreference) allowsDay(day time.Weekday) bool {
+	return len(p.Days) == 0 || slices.Contains(p.Days, day)
+}
+
+// overlaps сравнивает время "HH:MM" как строки: формат с ведущими нулями сохраняет порядок
+func (p TimePreference) overlaps(start, end string) bool {
+	to := p.To
+	if to == "" {
+		to = "24:00"
+	}
+	return start < to && end > p.From
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimeLayout - формат времени в расписании
+const TimeLayout = "15:04"
+
+// TimeInterval - интервал работы площадки внутри дня в формате "HH:MM"
+type TimeInterval struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// TimeIntervals хранится в колонке jsonb
+type TimeIntervals []TimeInterval
+
+func (ti TimeIntervals) Value() (driver.Value, error) {
+	if len(ti) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(ti)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (ti *TimeIntervals) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*ti = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("неподдерживаемый тип интервалов расписания: %T", value)
+	}
+	return json.Unmarshal(data, ti)
+}
+
+// validate проверяет, что интервалы корректны, идут по порядку и между ними есть перерыв
+func (ti TimeIntervals) validate() error {
+	var prevEnd time.Time
+	for i, interval := range ti {
+		start, err := time.Parse(TimeLayout, interval.Start)
+		if err != nil {
+			return fmt.Errorf("неверное время начала интервала: %s", interval.Start)
+		}
+		end, err := time.Parse(TimeLayout, interval.End)
+		if err != nil {
+			return fmt.Errorf("неверное время окончания интервала: %s", interval.End)
+		}
+		if !start.Before(end) {
+			return fmt.Errorf("интервал %s-%s: время начала должно быть раньше времени окончания", interval.Start, interval.End)
+		}
+		if i > 0 && !start.After(prevEnd) {
+			return fmt.Errorf("интервал %s-%s пересекается с предыдущим или примыкает к нему", interval.Start, interval.End)
+		}
+		prevEnd = end
+	}
+	return nil
+}
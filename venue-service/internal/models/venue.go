@@ -7,8 +7,10 @@ import (
 	"gorm.io/gorm"
 )
 
+// VenueType - код типа площадки из справочника venue_types (см. VenueTypeDefinition)
 type VenueType string
 
+// Типы площадок, с которых начинался справочник
 const (
 	VenueFootball   VenueType = "football"
 	VenueBasketball VenueType = "basketball"
@@ -17,13 +19,9 @@ const (
 	VenueSwimming   VenueType = "swimming"
 )
 
+// IsValid проверяет формат кода. Есть ли такой тип в справочнике, проверяет сервис
 func (vt VenueType) IsValid() bool {
-	switch vt {
-	case VenueFootball, VenueBasketball, VenueTennis, VenueGym, VenueSwimming:
-		return true
-	default:
-		return false
-	}
+	return venueTypeCodePattern.MatchString(string(vt))
 }
 
 func (vt VenueType) String() string {
@@ -31,11 +29,63 @@ func (vt VenueType) String() string {
 }
 
 // DaySchedule структура для расписания одного дня недели
-// Если Enabled = false, то StartTime и EndTime должны быть nil
+// Если Enabled = false, то StartTime, EndTime и Intervals должны быть пустыми.
+// Если день разбит на несколько интервалов (например, 07:00-08:30 и 17:00-23:00),
+// StartTime и EndTime хранят начало первого и конец последнего интервала
 type DaySchedule struct {
 	Enabled   bool       `json:"enabled" gorm:"column:enabled;default:true"`              // Включен ли день для бронирования
 	StartTime *time.Time `json:"start_time,omitempty" gorm:"column:start_time;type:time"` // Время начала работы (nil если disabled)
 	EndTime   *time.Time `json:"end_time,omitempty" gorm:"column:end_time;type:time"`     // Время окончания работы (nil если disabled)
+
+	Intervals TimeIntervals `json:"intervals,omitempty" gorm:"column:intervals;type:jsonb"` // Интервалы работы по порядку (пусто - один интервал StartTime-EndTime)
+}
+
+// Windows возвращает интервалы работы дня. Для расписаний в старом формате
+// (без Intervals) возвращается единственный интервал StartTime-EndTime
+func (d DaySchedule) Windows() []TimeInterval {
+	if !d.Enabled {
+		return nil
+	}
+	if len(d.Intervals) > 0 {
+		return d.Intervals
+	}
+	if d.StartTime == nil || d.EndTime == nil {
+		return nil
+	}
+	return []TimeInterval{{Start: d.StartTime.Format(TimeLayout), End: d.EndTime.Format(TimeLayout)}}
+}
+
+// validate проверяет расписание дня: интервалы идут по порядку, не пересекаются
+// и не примыкают друг к другу, а StartTime и EndTime совпадают с их границами
+func (d DaySchedule) validate() error {
+	if !d.Enabled {
+		if d.StartTime != nil || d.EndTime != nil || len(d.Intervals) > 0 {
+			return fmt.Errorf("время работы должно быть пустым, если день выключен")
+		}
+		return nil
+	}
+
+	// Если день включен, StartTime и EndTime должны быть заданы
+	if d.StartTime == nil || d.EndTime == nil {
+		return fmt.Errorf("время начала и окончания должны быть указаны")
+	}
+	// Проверка, что время начала раньше времени окончания
+	// После проверки на nil безопасно разыменовывать указатели
+	if !d.StartTime.Before(*d.EndTime) {
+		return fmt.Errorf("время начала должно быть раньше времени окончания")
+	}
+	if len(d.Intervals) == 0 {
+		return nil
+	}
+
+	if err := d.Intervals.validate(); err != nil {
+		return err
+	}
+	first, last := d.Intervals[0], d.Intervals[len(d.Intervals)-1]
+	if d.StartTime.Format(TimeLayout) != first.Start || d.EndTime.Format(TimeLayout) != last.End {
+		return fmt.Errorf("время начала и окончания должны совпадать с началом первого и концом последнего интервала")
+	}
+	return nil
 }
 
 // Weekdays структура для дней недели, когда можно делать бронирования
@@ -50,14 +100,116 @@ type Weekdays struct {
 	Sunday    DaySchedule `json:"sunday" gorm:"embedded;embeddedPrefix:sunday_"`       // Воскресенье
 }
 
+// DefaultMinDurationMinutes - минимальная длительность брони по умолчанию
+const DefaultMinDurationMinutes = 60
+
+// ReliabilityAction - что делать с ненадёжным клиентом при новой брони
+type ReliabilityAction string
+
+const (
+	ReliabilityBlock  ReliabilityAction = "block"  // Бронирование запрещено
+	ReliabilityPrepay ReliabilityAction = "prepay" // Бронь создаётся только с предоплатой
+)
+
+// BookingRules правила бронирования площадки, задаются владельцем.
+// Нулевые значения MaxAdvanceDays, MaxDurationMinutes и StartStepMinutes означают отсутствие ограничения
+type BookingRules struct {
+	MinLeadMinutes     int `json:"min_lead_minutes" gorm:"column:min_lead_minutes;not null;default:0"`          // Минимальное время до начала брони
+	MaxAdvanceDays     int `json:"max_advance_days" gorm:"column:max_advance_days;not null;default:0"`          // На сколько дней вперед можно бронировать
+	MinDurationMinutes int `json:"min_duration_minutes" gorm:"column:min_duration_minutes;not null;default:60"` // Минимальная длительность брони
+	MaxDurationMinutes int `json:"max_duration_minutes" gorm:"column:max_duration_minutes;not null;default:0"`  // Максимальная длительность брони
+	StartStepMinutes   int `json:"start_step_minutes" gorm:"column:start_step_minutes;not null;default:0"`      // Шаг начала брони в минутах (например, 30 - только :00 и :30)
+
+	// Подтверждённая бронь без отметки о приходе по QR-коду завершается неявкой (no_show)
+	CheckInRequired bool `json:"check_in_required" gorm:"column:check_in_required;not null;default:false"`
+
+	// Политика надёжности клиентов, ReliabilityLimit = 0 - отключена.
+	// Неявки и поздние отмены клиента считаются по всем площадкам
+	LateCancelHours       int               `json:"late_cancel_hours" gorm:"column:late_cancel_hours;not null;default:0"`             // Отмена клиентом позже чем за N часов до начала считается поздней (0 - не учитывается)
+	ReliabilityLimit      int               `json:"reliability_limit" gorm:"column:reliability_limit;not null;default:0"`             // Число нарушений, начиная с которого применяется ReliabilityAction
+	ReliabilityPeriodDays int               `json:"reliability_period_days" gorm:"column:reliability_period_days;not null;default:0"` // За сколько последних дней считаются нарушения (0 - за всё время)
+	ReliabilityAction     ReliabilityAction `json:"reliability_action,omitempty" gorm:"column:reliability_action;type:varchar(20)"`
+}
+
+// DefaultBookingRules возвращает правила бронирования по умолчанию
+func DefaultBookingRules() BookingRules {
+	return BookingRules{MinDurationMinutes: DefaultMinDurationMinutes}
+}
+
+// WithDefaults подставляет минимальную длительность брони, если она не задана (равна 0)
+func (br BookingRules) WithDefaults(minDurationMinutes int) BookingRules {
+	if br.MinDurationMinutes == 0 {
+		br.MinDurationMinutes = minDurationMinutes
+	}
+	return br
+}
+
+// Validate проверяет согласованность правил бронирования
+func (br BookingRules) Validate() error {
+	if br.MinLeadMinutes < 0 || br.MaxAdvanceDays < 0 || br.MaxDurationMinutes < 0 || br.StartStepMinutes < 0 {
+		return fmt.Errorf("параметры правил бронирования не могут быть отрицательными")
+	}
+	if br.MinDurationMinutes < 1 {
+		return fmt.Errorf("минимальная длительность бронирования должна быть не меньше 1 минуты")
+	}
+	if br.MaxDurationMinutes > 0 && br.MaxDurationMinutes < br.MinDurationMinutes {
+		return fmt.Errorf("максимальная длительность бронирования не может быть меньше минимальной")
+	}
+	if br.StartStepMinutes > 0 && 60%br.StartStepMinutes != 0 {
+		return fmt.Errorf("шаг начала бронирования должен быть делителем 60 минут")
+	}
+	if br.LateCancelHours < 0 || br.ReliabilityLimit < 0 || br.ReliabilityPeriodDays < 0 {
+		return fmt.Errorf("параметры политики надёжности не могут быть отрицательными")
+	}
+	if br.ReliabilityLimit > 0 && br.ReliabilityAction != ReliabilityBlock && br.ReliabilityAction != ReliabilityPrepay {
+		return fmt.Errorf("действие политики надёжности должно быть block или prepay")
+	}
+	return nil
+}
+
 type Venue struct {
 	gorm.Model
-	VenueType VenueType `json:"venue_type" gorm:"column:venue_type;type:varchar(50);not null"`
-	OwnerID   uint      `json:"owner_id" gorm:"column:owner_id;not null;index"`
-	IsActive  bool      `json:"is_active" gorm:"column:is_active;default:true"`
-	HourPrice int       `json:"hour_price" gorm:"column:hour_price;not null;check:hour_price >= 0"`
-	District  string    `json:"district" gorm:"column:district;type:varchar(50);not null"`
-	Weekdays  Weekdays  `json:"weekdays" gorm:"embedded"` // Дни недели для бронирования с расписанием
+	VenueType VenueType   `json:"venue_type" gorm:"column:venue_type;type:varchar(50);not null"`
+	OwnerID   uint        `json:"owner_id" gorm:"column:owner_id;not null;index"`
+	IsActive  bool        `json:"is_active" gorm:"column:is_active;default:true"`
+	HourPrice int         `json:"hour_price" gorm:"column:hour_price;not null;index;check:hour_price >= 0"`
+	District  string      `json:"district" gorm:"column:district;type:varchar(50);not null"`
+	Capacity  int         `json:"capacity" gorm:"column:capacity;not null;default:1;check:capacity >= 1"` // Количество мест (1 - площадка бронируется целиком)
+	Weekdays  Weekdays    `json:"weekdays" gorm:"embedded"`                                               // Дни недели для бронирования с расписанием
+	Units     []VenueUnit `json:"units,omitempty" gorm:"foreignKey:VenueID"`                              // Бронируемые единицы (корты, дорожки)
+
+	Amenities []VenueAmenity `json:"amenities,omitempty" gorm:"foreignKey:VenueID;constraint:OnDelete:CASCADE"` // Удобства и характеристики из каталога
+	Photos    []VenuePhoto   `json:"photos,omitempty" gorm:"foreignKey:VenueID"`                                // Фотографии по Position
+
+	BookingRules BookingRules `json:"booking_rules" gorm:"embedded;embeddedPrefix:booking_"` // Правила бронирования
+
+	// Версия цены и расписания, история изменений хранится в venue_revisions
+	Revision  int             `json:"revision" gorm:"column:revision;not null;default:1"`
+	Revisions []VenueRevision `json:"-" gorm:"foreignKey:VenueID;constraint:OnDelete:CASCADE"`
+
+	// Версия записи для ETag и If-Match. Увеличивается при любом изменении площадки, которое видно
+	// в её карточке: полей, статуса, удобств, фотографий, единиц и рейтинга
+	Version int `json:"version" gorm:"column:version;not null;default:1"`
+
+	// Агрегаты видимых отзывов, пересчитываются при изменении отзывов
+	Rating      float64 `json:"rating" gorm:"column:rating;not null;default:0;index"`
+	RatingCount int     `json:"rating_count" gorm:"column:rating_count;not null;default:0"`
+
+	// Адрес и координаты площадки (WGS 84). Координаты задаются парой или не задаются вовсе.
+	// Составной индекс по (latitude, longitude) используется для отсечения по ограничивающему прямоугольнику
+	Address   string   `json:"address" gorm:"column:address;type:varchar(255);not null;default:''"`
+	Latitude  *float64 `json:"latitude,omitempty" gorm:"column:latitude;index:idx_venues_geo,priority:1"`
+	Longitude *float64 `json:"longitude,omitempty" gorm:"column:longitude;index:idx_venues_geo,priority:2"`
+
+	// Расстояние до точки поиска в км, заполняется только при поиске по координатам
+	DistanceKm *float64 `json:"distance_km,omitempty" gorm:"column:distance_km;->;-:migration"`
+
+	// Модерация. Площадки, созданные до её появления, считаются опубликованными
+	Status            VenueStatus `json:"status" gorm:"column:status;type:varchar(20);not null;default:'published';index"`
+	ModerationComment string      `json:"moderation_comment" gorm:"column:moderation_comment;type:text;not null;default:''"` // Причина отклонения или приостановки
+	SubmittedAt       *time.Time  `json:"submitted_at,omitempty" gorm:"column:submitted_at"`                                 // Когда площадка последний раз отправлена на модерацию
+	ReviewedAt        *time.Time  `json:"reviewed_at,omitempty" gorm:"column:reviewed_at"`                                   // Когда администратор последний раз принял решение
+	ReviewedBy        *uint       `json:"reviewed_by,omitempty" gorm:"column:reviewed_by"`
 }
 
 func (Venue) TableName() string {
@@ -70,6 +222,28 @@ func (v *Venue) validateVenue() error {
 		return fmt.Errorf("неверный тип площадки: %s", v.VenueType)
 	}
 
+	if v.Status != "" && !v.Status.IsValid() {
+		return fmt.Errorf("неверный статус площадки: %s", v.Status)
+	}
+
+	if v.Capacity < 1 {
+		return fmt.Errorf("вместимость площадки должна быть не меньше 1")
+	}
+
+	if err := v.BookingRules.Validate(); err != nil {
+		return err
+	}
+
+	if (v.Latitude == nil) != (v.Longitude == nil) {
+		return fmt.Errorf("широта и долгота должны быть указаны вместе")
+	}
+	if v.Latitude != nil && (*v.Latitude < -90 || *v.Latitude > 90) {
+		return fmt.Errorf("широта должна быть в диапазоне от -90 до 90")
+	}
+	if v.Longitude != nil && (*v.Longitude < -180 || *v.Longitude > 180) {
+		return fmt.Errorf("долгота должна быть в диапазоне от -180 до 180")
+	}
+
 	// Проверяем расписание для каждого дня недели
 	days := []struct {
 		name     string
@@ -85,30 +259,25 @@ func (v *Venue) validateVenue() error {
 	}
 
 	for _, day := range days {
-		if day.schedule.Enabled {
-			// Если день включен, StartTime и EndTime должны быть заданы
-			startTime := day.schedule.StartTime
-			endTime := day.schedule.EndTime
-			if startTime == nil || endTime == nil {
-				return fmt.Errorf("для %s время начала и окончания должны быть указаны", day.name)
-			}
-			// Проверка, что время начала раньше времени окончания
-			// После проверки на nil безопасно разыменовывать указатели
-			if !startTime.Before(*endTime) {
-				return fmt.Errorf("для %s время начала должно быть раньше времени окончания", day.name)
-			}
-		} else {
-			// Если день выключен, StartTime и EndTime должны быть nil
-			if day.schedule.StartTime != nil || day.schedule.EndTime != nil {
-				return fmt.Errorf("для %s время начала и окончания должны быть nil, если день выключен", day.name)
-			}
+		if err := day.schedule.validate(); err != nil {
+			return fmt.Errorf("%s: %w", day.name, err)
 		}
 	}
 
 	return nil
 }
 
+// Validate проверяет площадку по тем же правилам, что и при сохранении
+func (v *Venue) Validate() error {
+	return v.validateVenue()
+}
+
 func (v *Venue) BeforeCreate(tx *gorm.DB) error {
+	// Площадки без указанной вместимости бронируются целиком
+	if v.Capacity == 0 {
+		v.Capacity = 1
+	}
+	v.BookingRules = v.BookingRules.WithDefaults(DefaultMinDurationMinutes)
 	return v.validateVenue()
 }
 
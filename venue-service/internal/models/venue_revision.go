@@ -0,0 +1,94 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Что изменилось в версии площадки
+const (
+	RevisionChangeHourPrice = "hour_price"
+	RevisionChangeSchedule  = "schedule"
+)
+
+// VenueRevision - версия цены и расписания площадки. Новая версия появляется при каждом
+// изменении hour_price или расписания, номер совпадает с Venue.Revision после изменения.
+// Reservation-service сохраняет номер версии, по которой рассчитана цена брони
+type VenueRevision struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	VenueID   uint      `json:"venue_id" gorm:"column:venue_id;not null;uniqueIndex:idx_venue_revisions_revision,priority:1"`
+	Revision  int       `json:"revision" gorm:"column:revision;not null;uniqueIndex:idx_venue_revisions_revision,priority:2"`
+	HourPrice int       `json:"hour_price" gorm:"column:hour_price;not null"`
+	Weekdays  Weekdays  `json:"weekdays" gorm:"embedded"`
+	Changes   []string  `json:"changes" gorm:"column:changes;type:jsonb;serializer:json"` // Пусто - исходное состояние площадки
+	ChangedBy *uint     `json:"changed_by,omitempty" gorm:"column:changed_by"`            // Пусто, если автор неизвестен (площадки до появления истории)
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (VenueRevision) TableName() string {
+	return "venue_revisions"
+}
+
+// NewVenueRevision снимает версию с текущих цены и расписания площадки
+func NewVenueRevision(venue *Venue, changedBy *uint, changes []string) VenueRevision {
+	return VenueRevision{
+		VenueID:   venue.ID,
+		Revision:  venue.Revision,
+		HourPrice: venue.HourPrice,
+		Weekdays:  venue.Weekdays,
+		Changes:   changes,
+		ChangedBy: changedBy,
+	}
+}
+
+// RevisionChanges возвращает, что из цены и расписания отличается у площадок
+func RevisionChanges(before, after *Venue) []string {
+	var changes []string
+	if before.HourPrice != after.HourPrice {
+		changes = append(changes, RevisionChangeHourPrice)
+	}
+	if !before.Weekdays.Equal(after.Weekdays) {
+		changes = append(changes, RevisionChangeSchedule)
+	}
+	return changes
+}
+
+// Day возвращает расписание дня недели
+func (w Weekdays) Day(day time.Weekday) DaySchedule {
+	switch day {
+	case time.Monday:
+		return w.Monday
+	case time.Tuesday:
+		return w.Tuesday
+	case time.Wednesday:
+		return w.Wednesday
+	case time.Thursday:
+		return w.Thursday
+	case time.Friday:
+		return w.Friday
+	case time.Saturday:
+		return w.Saturday
+	default:
+		return w.Sunday
+	}
+}
+
+// Equal сравнивает расписания по интервалам работы, а не по способу записи
+// (один интервал через StartTime/EndTime равен тому же интервалу в Intervals)
+func (w Weekdays) Equal(other Weekdays) bool {
+	days := [][2]DaySchedule{
+		{w.Monday, other.Monday},
+		{w.Tuesday, other.Tuesday},
+		{w.Wednesday, other.Wednesday},
+		{w.Thursday, other.Thursday},
+		{w.Friday, other.Friday},
+		{w.Saturday, other.Saturday},
+		{w.Sunday, other.Sunday},
+	}
+	for _, day := range days {
+		if day[0].Enabled != day[1].Enabled || !slices.Equal(day[0].Windows(), day[1].Windows()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// DefaultLocale - язык, на котором название типа площадки обязано быть задано
+const DefaultLocale = "ru"
+
+var venueTypeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// VenueTypeDefinition - тип площадки из справочника venue_types, которым управляют администраторы.
+// Code хранится в venues.venue_type, поэтому после создания не меняется
+type VenueTypeDefinition struct {
+	Code      VenueType      `json:"code" gorm:"column:code;type:varchar(50);primaryKey"`
+	Names     LocalizedNames `json:"names" gorm:"column:names;type:jsonb;not null"` // Названия по языкам, ru обязателен
+	Icon      string         `json:"icon" gorm:"column:icon;type:varchar(255);not null;default:''"`
+	SortOrder int            `json:"sort_order" gorm:"column:sort_order;not null;default:0"`
+	IsActive  bool           `json:"is_active" gorm:"column:is_active;not null;default:true"` // Неактивный тип нельзя выбрать для новой площадки
+
+	// Продаются ли отдельные места (вместимость больше 1), а не вся площадка целиком
+	Shared bool `json:"shared" gorm:"column:shared;not null;default:false"`
+	// Минимальная длительность брони для новых площадок этого типа
+	DefaultMinDurationMinutes int `json:"default_min_duration_minutes" gorm:"column:default_min_duration_minutes;not null;default:60"`
+	// Удобства каталога с TypeSpecific, которые можно указать у площадок этого типа
+	Amenities AmenityCodes `json:"amenities" gorm:"column:amenities;type:jsonb;not null;default:'[]'"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (VenueTypeDefinition) TableName() string {
+	return "venue_types"
+}
+
+// Name возвращает название на языке locale, при его отсутствии - на языке по умолчанию
+func (d VenueTypeDefinition) Name(locale string) string {
+	if name := d.Names[locale]; name != "" {
+		return name
+	}
+	if name := d.Names[DefaultLocale]; name != "" {
+		return name
+	}
+	return d.Code.String()
+}
+
+// Validate проверяет данные типа площадки
+func (d VenueTypeDefinition) Validate() error {
+	if !d.Code.IsValid() {
+		return fmt.Errorf("код типа площадки должен состоять из латинских букв, цифр и _ и начинаться с буквы: %s", d.Code)
+	}
+	if d.Names[DefaultLocale] == "" {
+		return fmt.Errorf("название типа площадки на языке %s обязательно", DefaultLocale)
+	}
+	if d.DefaultMinDurationMinutes < 1 {
+		return fmt.Errorf("минимальная длительность брони должна быть положительной")
+	}
+	return validateTypeAmenities(d.Amenities)
+}
+
+// DefaultVenueTypes - типы площадок, которые были в коде до появления справочника.
+// Добавляются при миграции, если их ещё нет
+func DefaultVenueTypes() []VenueTypeDefinition {
+	return []VenueTypeDefinition{
+		{Code: VenueFootball, Names: LocalizedNames{"ru": "Футбол", "en": "Football"}, Icon: "football", SortOrder: 10, IsActive: true, DefaultMinDurationMinutes: DefaultMinDurationMinutes,
+			Amenities: AmenityCodes{"outdoor", "surface_grass", "surface_artificial", "surface_parquet", "lighting"}},
+		{Code: VenueBasketball, Names: LocalizedNames{"ru": "Баскетбол", "en": "Basketball"}, Icon: "basketball", SortOrder: 20, IsActive: true, DefaultMinDurationMinutes: DefaultMinDurationMinutes,
+			Amenities: AmenityCodes{"outdoor", "surface_parquet", "surface_hard", "lighting"}},
+		{Code: VenueTennis, Names: LocalizedNames{"ru": "Теннис", "en": "Tennis"}, Icon: "tennis", SortOrder: 30, IsActive: true, DefaultMinDurationMinutes: DefaultMinDurationMinutes,
+			Amenities: AmenityCodes{"outdoor", "surface_grass", "surface_artificial", "surface_parquet", "surface_clay", "surface_hard", "lighting"}},
+		{Code: VenueGym, Names: LocalizedNames{"ru": "Тренажерный зал", "en": "Gym"}, Icon: "gym", SortOrder: 40, IsActive: true, Shared: true, DefaultMinDurationMinutes: DefaultMinDurationMinutes},
+		{Code: VenueSwimming, Names: LocalizedNames{"ru": "Плавание", "en": "Swimming"}, Icon: "swimming", SortOrder: 50, IsActive: true, Shared: true, DefaultMinDurationMinutes: DefaultMinDurationMinutes,
+			Amenities: AmenityCodes{"outdoor"}},
+	}
+}
+
+// LocalizedNames - названия по кодам языков, хранятся в колонке jsonb
+type LocalizedNames map[string]string
+
+func (n LocalizedNames) Value() (driver.Value, error) {
+	if n == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (n *LocalizedNames) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*n = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("неподдерживаемый тип названий: %T", value)
+	}
+	return json.Unmarshal(data, n)
+}
+
+// AmenityCodes - коды удобств, хранятся в колонке jsonb
+type AmenityCodes []string
+
+func (c AmenityCodes) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c *AmenityCodes) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("неподдерживаемый тип удобств: %T", value)
+	}
+	return json.Unmarshal(data, c)
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// VenueUnit - бронируемая единица внутри площадки (корт, дорожка, половина поля).
+// Расписание наследуется от площадки, цену можно переопределить.
+// Если задан ParentID, единица является частью родительской: бронь родителя
+// блокирует все его части, а бронь любой части блокирует родителя
+type VenueUnit struct {
+	gorm.Model
+	VenueID   uint   `json:"venue_id" gorm:"column:venue_id;not null;index"`
+	ParentID  *uint  `json:"parent_id,omitempty" gorm:"column:parent_id;index"`
+	Name      string `json:"name" gorm:"column:name;type:varchar(100);not null"`
+	HourPrice *int   `json:"hour_price,omitempty" gorm:"column:hour_price;check:hour_price >= 0"` // nil - используется цена площадки
+	IsActive  bool   `json:"is_active" gorm:"column:is_active;default:true"`
+}
+
+func (VenueUnit) TableName() string {
+	return "venue_units"
+}
+
+// validateUnit проверяет валидность данных единицы площадки
+func (u *VenueUnit) validateUnit() error {
+	if u.Name == "" {
+		return fmt.Errorf("название единицы площадки обязательно")
+	}
+	if u.HourPrice != nil && *u.HourPrice < 0 {
+		return fmt.Errorf("цена единицы площадки не может быть отрицательной")
+	}
+	if u.ParentID != nil && u.ID != 0 && *u.ParentID == u.ID {
+		return fmt.Errorf("единица площадки не может быть частью самой себя")
+	}
+	return nil
+}
+
+func (u *VenueUnit) BeforeCreate(tx *gorm.DB) error {
+	return u.validateUnit()
+}
+
+func (u *VenueUnit) BeforeUpdate(tx *gorm.DB) error {
+	return u.validateUnit()
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"log/slog"
+	"venue-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FavoriteRepository interface {
+	Add(userID, venueID uint) error
+	Remove(userID, venueID uint) error
+	GetVenues(userID uint, page, limit int) ([]models.Venue, int64, error)
+	FavoriteVenueIDs(userID uint, venueIDs []uint) ([]uint, error)
+}
+
+type favoriteRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewFavoriteRepository(db *gorm.DB, logger *slog.Logger) FavoriteRepository {
+	return &favoriteRepository{
+		db:     db,
+		logger: logger.With("layer", "repository"),
+	}
+}
+
+// Add добавляет площадку в избранное, повторное добавление ничего не меняет
+func (r *favoriteRepository) Add(userID, venueID uint) error {
+	favorite := models.FavoriteVenue{UserID: userID, VenueID: venueID}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&favorite).Error; err != nil {
+		r.logger.Error("Ошибка добавления площадки в избранное", "user_id", userID, "venue_id", venueID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *favoriteRepository) Remove(userID, venueID uint) error {
+	if err := r.db.Where("user_id = ? AND venue_id = ?", userID, venueID).Delete(&models.FavoriteVenue{}).Error; err != nil {
+		r.logger.Error("Ошибка удаления площадки из избранного", "user_id", userID, "venue_id", venueID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetVenues возвращает опубликованные площадки из избранного, недавно добавленные первыми.
+// Снятые с публикации и удалённые площадки остаются в избранном, но не показываются
+func (r *favoriteRepository) GetVenues(userID uint, page, limit int) ([]models.Venue, int64, error) {
+	query := r.db.Model(&models.Venue{}).
+		Joins("JOIN favorite_venues fv ON fv.venue_id = venues.id AND fv.user_id = ?", userID).
+		Where("venues.status = ?", models.VenuePublished)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.logger.Error("Ошибка подсчета избранных площадок", "user_id", userID, "error", err)
+		return nil, 0, err
+	}
+
+	query = query.Order("fv.created_at DESC").Order("venues.id DESC").Offset((page - 1) * limit).Limit(limit)
+	query = query.Preload("Amenities", func(db *gorm.DB) *gorm.DB {
+		return db.Order("code ASC")
+	}).Preload("Photos", "is_cover = ?", true)
+
+	var venues []models.Venue
+	if err := query.Find(&venues).Error; err != nil {
+		r.logger.Error("Ошибка получения избранных площадок", "user_id", userID, "error", err)
+		return nil, 0, err
+	}
+	return venues, total, nil
+}
+
+// FavoriteVenueIDs возвращает те из venueIDs, что есть в избранном пользователя
+func (r *favoriteRepository) FavoriteVenueIDs(userID uint, venueIDs []uint) ([]uint, error) {
+	var ids []uint
+	if len(venueIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.Model(&models.FavoriteVenue{}).
+		Where("user_id = ? AND venue_id IN ?", userID, venueIDs).
+		Pluck("venue_id", &ids).Error
+	if err != nil {
+		r.logger.Error("Ошибка получения избранных площадок", "user_id", userID, "error", err)
+		return nil, err
+	}
+	return ids, nil
+}
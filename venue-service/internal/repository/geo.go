@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"math"
+	"venue-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// earthRadiusKm - средний радиус Земли, используется в формуле гаверсинусов
+const earthRadiusKm = 6371.0
+
+// kmPerDegreeLat - длина одного градуса широты в км
+const kmPerDegreeLat = 111.045
+
+// distanceExpr - SQL-выражение расстояния от площадки до точки в км (формула гаверсинусов).
+// least() защищает asin от выхода за 1 из-за погрешности вычислений
+const distanceExpr = `(2 * ? * asin(least(1, sqrt(
+	power(sin(radians(latitude - ?) / 2), 2) +
+	cos(radians(?)) * cos(radians(latitude)) * power(sin(radians(longitude - ?) / 2), 2)
+))))`
+
+func distanceArgs(p models.GeoPoint) []interface{} {
+	return []interface{}{earthRadiusKm, p.Lat, p.Lat, p.Lng}
+}
+
+// boundsForRadius возвращает прямоугольник, гарантированно содержащий круг радиуса p.RadiusKm.
+// По нему запрос отсекает лишние строки по индексу до точного расчёта расстояния
+func boundsForRadius(p models.GeoPoint) models.BoundingBox {
+	dLat := p.RadiusKm / kmPerDegreeLat
+	box := models.BoundingBox{
+		MinLat: math.Max(p.Lat-dLat, -90),
+		MaxLat: math.Min(p.Lat+dLat, 90),
+		MinLng: -180,
+		MaxLng: 180,
+	}
+
+	// У полюсов и для больших радиусов ограничение по долготе не имеет смысла
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+	cosLat := math.Min(math.Cos(box.MinLat*math.Pi/180), math.Cos(box.MaxLat*math.Pi/180))
+	dLng := p.RadiusKm / (kmPerDegreeLat * cosLat)
+	if dLng >= 180 {
+		return box
+	}
+
+	box.MinLng = p.Lng - dLng
+	box.MaxLng = p.Lng + dLng
+	if box.MinLng < -180 {
+		box.MinLng += 360
+	}
+	if box.MaxLng > 180 {
+		box.MaxLng -= 360
+	}
+	return box
+}
+
+// applyBoundingBox ограничивает выборку площадками внутри прямоугольника
+func applyBoundingBox(query *gorm.DB, box models.BoundingBox) *gorm.DB {
+	query = query.Where("latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if box.MinLng <= box.MaxLng {
+		return query.Where("longitude BETWEEN ? AND ?", box.MinLng, box.MaxLng)
+	}
+	return query.Where("(longitude >= ? OR longitude <= ?)", box.MinLng, box.MaxLng)
+}
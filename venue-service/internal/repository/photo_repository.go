@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"errors"
+	"log/slog"
+	"venue-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrPhotoLimit = errors.New("photo limit reached")
+
+type VenuePhotoRepository interface {
+	GetByVenueID(venueID uint) ([]models.VenuePhoto, error)
+	GetByID(venueID, id uint) (*models.VenuePhoto, error)
+	Create(photo *models.VenuePhoto, limit int) error
+	Delete(photo *models.VenuePhoto) error
+	Reorder(venueID uint, ids []uint) error
+	SetCover(venueID, id uint) error
+}
+
+type venuePhotoRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewVenuePhotoRepository(db *gorm.DB, logger *slog.Logger) VenuePhotoRepository {
+	return &venuePhotoRepository{
+		db:     db,
+		logger: logger.With("layer", "repository"),
+	}
+}
+
+func (r *venuePhotoRepository) GetByVenueID(venueID uint) ([]models.VenuePhoto, error) {
+	var photos []models.VenuePhoto
+	if err := r.db.Where("venue_id = ?", venueID).Order("position ASC").Order("id ASC").Find(&photos).Error; err != nil {
+		r.logger.Error("Ошибка получения фотографий площадки", "venue_id", venueID, "error", err)
+		return nil, err
+	}
+	return photos, nil
+}
+
+func (r *venuePhotoRepository) GetByID(venueID, id uint) (*models.VenuePhoto, error) {
+	var photo models.VenuePhoto
+	if err := r.db.Where("venue_id = ?", venueID).First(&photo, id).Error; err != nil {
+		r.logger.Error("Ошибка получения фотографии", "venue_id", venueID, "id", id, "error", err)
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// lockVenue блокирует строку площадки, чтобы параллельные изменения галереи шли по очереди.
+// Блокировка берётся увеличением версии площадки: галерея входит в её карточку
+func lockVenue(tx *gorm.DB, venueID uint) error {
+	return touchVenue(tx, venueID)
+}
+
+// Create добавляет фотографию в конец галереи. Первая фотография становится обложкой
+func (r *venuePhotoRepository) Create(photo *models.VenuePhoto, limit int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockVenue(tx, photo.VenueID); err != nil {
+			return err
+		}
+
+		var stats struct {
+			Count       int64
+			MaxPosition int
+		}
+		if err := tx.Model(&models.VenuePhoto{}).Select("count(*) AS count, coalesce(max(position), -1) AS max_position").
+			Where("venue_id = ?", photo.VenueID).Scan(&stats).Error; err != nil {
+			return err
+		}
+		if stats.Count >= int64(limit) {
+			return ErrPhotoLimit
+		}
+
+		photo.Position = stats.MaxPosition + 1
+		photo.IsCover = stats.Count == 0
+		return tx.Create(photo).Error
+	})
+	if err != nil && !errors.Is(err, ErrPhotoLimit) {
+		r.logger.Error("Ошибка сохранения фотографии", "venue_id", photo.VenueID, "error", err)
+	}
+	return err
+}
+
+// Delete удаляет фотографию. Если это была обложка, обложкой становится первая оставшаяся
+func (r *venuePhotoRepository) Delete(photo *models.VenuePhoto) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockVenue(tx, photo.VenueID); err != nil {
+			return err
+		}
+		result := tx.Where("venue_id = ?", photo.VenueID).Delete(&models.VenuePhoto{}, photo.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if !photo.IsCover {
+			return nil
+		}
+
+		var next models.VenuePhoto
+		err := tx.Where("venue_id = ?", photo.VenueID).Order("position ASC").Order("id ASC").First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&next).Update("is_cover", true).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.Error("Ошибка удаления фотографии", "venue_id", photo.VenueID, "id", photo.ID, "error", err)
+	}
+	return err
+}
+
+// Reorder проставляет позиции по порядку ids. ids должны совпадать с фотографиями площадки
+func (r *venuePhotoRepository) Reorder(venueID uint, ids []uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockVenue(tx, venueID); err != nil {
+			return err
+		}
+		for position, id := range ids {
+			if err := tx.Model(&models.VenuePhoto{}).Where("venue_id = ? AND id = ?", venueID, id).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("Ошибка изменения порядка фотографий", "venue_id", venueID, "error", err)
+	}
+	return err
+}
+
+// SetCover делает фотографию обложкой, снимая отметку с предыдущей
+func (r *venuePhotoRepository) SetCover(venueID, id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockVenue(tx, venueID); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.VenuePhoto{}).Where("venue_id = ? AND is_cover", venueID).
+			Update("is_cover", false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.VenuePhoto{}).Where("venue_id = ? AND id = ?", venueID, id).Update("is_cover", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.Error("Ошибка установки обложки", "venue_id", venueID, "id", id, "error", err)
+	}
+	return err
+}
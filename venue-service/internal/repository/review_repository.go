@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+	"venue-service/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type ReviewRepository interface {
+	GetByID(venueID, id uint) (*models.Review, error)
+	GetByVenueID(venueID uint, includeHidden bool, page, limit int) ([]models.Review, int64, error)
+	ExistsForBooking(bookingID uint) (bool, error)
+	Create(review *models.Review) error
+	Update(review *models.Review, fields map[string]interface{}) error
+	SetReply(id uint, text string, at time.Time) (bool, error)
+}
+
+type reviewRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewReviewRepository(db *gorm.DB, logger *slog.Logger) ReviewRepository {
+	return &reviewRepository{
+		db:     db,
+		logger: logger.With("layer", "repository"),
+	}
+}
+
+func (r *reviewRepository) GetByID(venueID, id uint) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.Where("venue_id = ?", venueID).First(&review, id).Error; err != nil {
+		r.logger.Error("Ошибка получения отзыва", "venue_id", venueID, "id", id, "error", err)
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepository) GetByVenueID(venueID uint, includeHidden bool, page, limit int) ([]models.Review, int64, error) {
+	query := r.db.Model(&models.Review{}).Where("venue_id = ?", venueID)
+	if !includeHidden {
+		query = query.Where("is_hidden = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Ошибка подсчета отзывов площадки", "venue_id", venueID, "error", err)
+		return nil, 0, err
+	}
+
+	var reviews []models.Review
+	if err := query.Order("id DESC").Limit(limit).Offset((page - 1) * limit).Find(&reviews).Error; err != nil {
+		r.logger.Error("Ошибка получения отзывов площадки", "venue_id", venueID, "error", err)
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+func (r *reviewRepository) ExistsForBooking(bookingID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Review{}).Where("booking_id = ?", bookingID).Count(&count).Error; err != nil {
+		r.logger.Error("Ошибка проверки отзыва по брони", "booking_id", bookingID, "error", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create сохраняет отзыв и в той же транзакции пересчитывает рейтинг площадки
+func (r *reviewRepository) Create(review *models.Review) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		return refreshVenueRating(tx, review.VenueID)
+	})
+	if err != nil {
+		r.logger.Error("Ошибка создания отзыва", "venue_id", review.VenueID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// Update обновляет указанные поля отзыва и пересчитывает рейтинг площадки
+func (r *reviewRepository) Update(review *models.Review, fields map[string]interface{}) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(review).Updates(fields).Error; err != nil {
+			return err
+		}
+		return refreshVenueRating(tx, review.VenueID)
+	})
+	if err != nil {
+		r.logger.Error("Ошибка обновления отзыва", "id", review.ID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// SetReply сохраняет ответ владельца на отзыв. Условие reply IS NULL в самом UPDATE
+// не даёт перезаписать ответ при одновременных запросах. false - ответ уже есть
+func (r *reviewRepository) SetReply(id uint, text string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Review{}).
+		Where("id = ? AND reply IS NULL", id).
+		Updates(map[string]interface{}{
+			"reply":      text,
+			"replied_at": at,
+		})
+	if result.Error != nil {
+		r.logger.Error("Ошибка сохранения ответа на отзыв", "id", id, "error", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// refreshVenueRating пересчитывает средний рейтинг и количество видимых отзывов площадки.
+// Используется прямой UPDATE, чтобы не запускать хуки валидации площадки
+func refreshVenueRating(tx *gorm.DB, venueID uint) error {
+	return tx.Exec(`
+		UPDATE venues SET
+			rating = COALESCE((SELECT ROUND(AVG(rating)::numeric, 2) FROM reviews WHERE venue_id = ? AND is_hidden = false AND deleted_at IS NULL), 0),
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE venue_id = ? AND is_hidden = false AND deleted_at IS NULL),
+			version = version + 1
+		WHERE id = ?`, venueID, venueID, venueID).Error
+}
+
+// IsUniqueViolation сообщает, что запись нарушила уникальный индекс (код PostgreSQL 23505)
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
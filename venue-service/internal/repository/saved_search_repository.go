@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"log/slog"
+	"venue-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SavedSearchRepository interface {
+	Create(search *models.SavedSearch) error
+	GetByID(id uint) (*models.SavedSearch, error)
+	GetByUserID(userID uint) ([]models.SavedSearch, error)
+	CountByUserID(userID uint) (int64, error)
+	Update(search *models.SavedSearch) error
+	Delete(id uint) error
+	// ForEach обходит все сохранённые поиски пачками по возрастанию id, не загружая их в память разом
+	ForEach(batchSize int, fn func([]models.SavedSearch) error) error
+}
+
+type savedSearchRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewSavedSearchRepository(db *gorm.DB, logger *slog.Logger) SavedSearchRepository {
+	return &savedSearchRepository{
+		db:     db,
+		logger: logger.With("layer", "repository"),
+	}
+}
+
+func (r *savedSearchRepository) Create(search *models.SavedSearch) error {
+	if err := r.db.Create(search).Error; err != nil {
+		r.logger.Error("Ошибка создания сохранённого поиска", "user_id", search.UserID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) GetByID(id uint) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.First(&search, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			r.logger.Error("Ошибка получения сохранённого поиска", "id", id, "error", err)
+		}
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r *savedSearchRepository) GetByUserID(userID uint) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&searches).Error; err != nil {
+		r.logger.Error("Ошибка получения сохранённых поисков", "user_id", userID, "error", err)
+		return nil, err
+	}
+	return searches, nil
+}
+
+func (r *savedSearchRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.SavedSearch{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		r.logger.Error("Ошибка подсчета сохранённых поисков", "user_id", userID, "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *savedSearchRepository) Update(search *models.SavedSearch) error {
+	if err := r.db.Save(search).Error; err != nil {
+		r.logger.Error("Ошибка обновления сохранённого поиска", "id", search.ID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) Delete(id uint) error {
+	if err := r.db.Delete(&models.SavedSearch{}, id).Error; err != nil {
+		r.logger.Error("Ошибка удаления сохранённого поиска", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) ForEach(batchSize int, fn func([]models.SavedSearch) error) error {
+	var batch []models.SavedSearch
+	err := r.db.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+	if err != nil {
+		r.logger.Error("Ошибка обхода сохранённых поисков", "error", err)
+		return err
+	}
+	return nil
+}
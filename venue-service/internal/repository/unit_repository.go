@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"errors"
+	"log/slog"
+	"venue-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type VenueUnitRepository interface {
+	GetByID(venueID, id uint) (*models.VenueUnit, error)
+	GetByVenueID(venueID uint) ([]models.VenueUnit, error)
+	CountChildren(id uint) (int64, error)
+	Create(unit *models.VenueUnit) error
+	Update(unit *models.VenueUnit) error
+	Delete(venueID, id uint) error
+}
+
+type venueUnitRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewVenueUnitRepository(db *gorm.DB, logger *slog.Logger) VenueUnitRepository {
+	return &venueUnitRepository{
+		db:     db,
+		logger: logger.With("layer", "repository"),
+	}
+}
+
+func (r *venueUnitRepository) GetByID(venueID, id uint) (*models.VenueUnit, error) {
+	var unit models.VenueUnit
+	if err := r.db.Where("venue_id = ?", venueID).First(&unit, id).Error; err != nil {
+		r.logger.Error("Ошибка получения единицы площадки", "venue_id", venueID, "id", id, "error", err)
+		return nil, err
+	}
+	return &unit, nil
+}
+
+func (r *venueUnitRepository) GetByVenueID(venueID uint) ([]models.VenueUnit, error) {
+	var units []models.VenueUnit
+	if err := r.db.Where("venue_id = ?", venueID).Order("id ASC").Find(&units).Error; err != nil {
+		r.logger.Error("Ошибка получения единиц площадки", "venue_id", venueID, "error", err)
+		return nil, err
+	}
+	return units, nil
+}
+
+func (r *venueUnitRepository) CountChildren(id uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.VenueUnit{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		r.logger.Error("Ошибка подсчета частей единицы площадки", "id", id, "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *venueUnitRepository) Create(unit *models.VenueUnit) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchVenue(tx, unit.VenueID); err != nil {
+			return err
+		}
+		return tx.Create(unit).Error
+	})
+	if err != nil {
+		r.logger.Error("Ошибка создания единицы площадки", "venue_id", unit.VenueID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *venueUnitRepository) Update(unit *models.VenueUnit) error {
+	// Мапа позволяет обнулять цену (возврат к цене площадки) и parent_id
+	updateData := map[string]interface{}{
+		"name":       unit.Name,
+		"parent_id":  unit.ParentID,
+		"hour_price": unit.HourPrice,
+		"is_active":  unit.IsActive,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchVenue(tx, unit.VenueID); err != nil {
+			return err
+		}
+		return tx.Model(unit).Updates(updateData).Error
+	})
+	if err != nil {
+		r.logger.Error("Ошибка обновления единицы площадки", "id", unit.ID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *venueUnitRepository) Delete(venueID, id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("venue_id = ?", venueID).Delete(&models.VenueUnit{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return touchVenue(tx, venueID)
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.Error("Ошибка удаления единицы площадки", "venue_id", venueID, "id", id, "error", err)
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"venue-service/internal/models"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// VenueCursor позиция в списке площадок: ключи сортировки последней выданной записи.
+// Следующая страница начинается строго после неё, поэтому новые площадки
+// не сдвигают уже просмотренные записи
+type VenueCursor struct {
+	Sort        string  `json:"s"`
+	ID          uint    `json:"id"`
+	HourPrice   int     `json:"p,omitempty"`
+	Rating      float64 `json:"r,omitempty"`
+	RatingCount int     `json:"c,omitempty"`
+	DistanceKm  float64 `json:"d,omitempty"`
+}
+
+// CursorAfter возвращает курсор, указывающий на площадку venue в сортировке sort
+func CursorAfter(sort string, venue models.Venue) VenueCursor {
+	cursor := VenueCursor{
+		Sort:        sort,
+		ID:          venue.ID,
+		HourPrice:   venue.HourPrice,
+		Rating:      venue.Rating,
+		RatingCount: venue.RatingCount,
+	}
+	if venue.DistanceKm != nil {
+		cursor.DistanceKm = *venue.DistanceKm
+	}
+	return cursor
+}
+
+// Encode кодирует курсор в непрозрачную строку для клиента
+func (c VenueCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeVenueCursor разбирает курсор и проверяет, что он выдан для той же сортировки
+func DecodeVenueCursor(value, sort string) (*VenueCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor VenueCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 || cursor.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
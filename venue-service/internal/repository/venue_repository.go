@@ -1,29 +1,100 @@
 package repository
 
 import (
+	"errors"
 	"log/slog"
+	"time"
 	"venue-service/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Варианты сортировки списка площадок
+const (
+	SortNewest   = "newest"
+	SortPrice    = "price"
+	SortRating   = "rating"
+	SortDistance = "distance" // Только вместе с Geo
+)
+
+// Режимы фильтра по удобствам
+const (
+	AmenitiesMatchAll = "all" // Есть все перечисленные удобства
+	AmenitiesMatchAny = "any" // Есть хотя бы одно из перечисленных
 )
 
 type VenueFilter struct {
-	District  string
-	VenueType models.VenueType
-	HourPrice int
-	IsActive  *bool
-	OwnerID   uint
-	Page      int
-	Limit     int
+	Districts  []string
+	VenueTypes []models.VenueType
+	HourPrice  int
+	IsActive   *bool
+	OwnerID    uint
+	Page       int
+	Limit      int
+
+	MinHourPrice int
+	MaxHourPrice int
+	MinRating    float64
+	Sort         string
+
+	Geo  *models.GeoPoint    // Поиск вокруг точки, заполняет DistanceKm
+	BBox *models.BoundingBox // Площадки в видимой области карты
+
+	Amenities      []string // Коды удобств без повторов
+	AmenitiesMatch string   // all (по умолчанию) или any
+
+	After *VenueCursor // Если задан, выдача начинается после него, Page не используется
+
+	Statuses []models.VenueStatus // Пусто - любые статусы модерации
+	IDs      []uint               // Пусто - любые площадки
 }
 
+var (
+	// ErrStatusChanged - статус площадки изменился параллельно, действие модерации не применено
+	ErrStatusChanged = errors.New("venue status changed concurrently")
+	// ErrRevisionChanged - цену или расписание площадки изменил параллельный запрос
+	ErrRevisionChanged = errors.New("venue revision changed concurrently")
+	// ErrVersionChanged - площадку изменил параллельный запрос после того, как её прочитали
+	ErrVersionChanged = errors.New("venue version changed concurrently")
+)
+
 type VenueRepository interface {
 	GetByID(id uint) (*models.Venue, error)
-	GetList(filter VenueFilter) ([]models.Venue, error)
-	GetByOwnerID(ownerID uint) ([]models.Venue, error)
+	GetList(filter VenueFilter) ([]models.Venue, int64, error)
+	GetByOwnerID(ownerID uint, statuses []models.VenueStatus) ([]models.Venue, error)
+	GetModerationQueue(status models.VenueStatus, page, limit int) ([]models.Venue, int64, error)
 	Create(venue *models.Venue) error
 	Update(venue *models.Venue) error
 	Delete(id uint) error
+	SetAmenities(venueID uint, codes []string) error
+	UpdateStatus(venue *models.Venue, from models.VenueStatus) error
+	UpdateWithRevision(venue *models.Venue, previous, revision *models.VenueRevision) error
+	GetRevisions(venueID uint, page, limit int) ([]models.VenueRevision, int64, error)
+	GetRevision(venueID uint, revision int) (*models.VenueRevision, error)
+	Import(batch *VenueImport) error
+
+	// Удалённые площадки (soft delete)
+	GetDeleted(page, limit int) ([]models.Venue, int64, error)
+	GetDeletedByID(id uint) (*models.Venue, error)
+	Restore(id uint) error
+	Purge(id uint, deletedBefore time.Time) ([]models.VenuePhoto, error)
+}
+
+// VenueImport - площадки из файла импорта, сохраняются одной транзакцией
+type VenueImport struct {
+	Create []*models.Venue
+	Update []VenueImportUpdate
+}
+
+// VenueImportUpdate - изменение существующей площадки при импорте
+type VenueImportUpdate struct {
+	Venue     *models.Venue
+	Previous  *models.VenueRevision // Previous и Revision заданы, если изменились цена или расписание
+	Revision  *models.VenueRevision
+	Amenities []string
+	// Непусто - площадка отправляется на повторную модерацию, если всё ещё в этом статусе
+	ResubmitFrom models.VenueStatus
 }
 
 type venueRepository struct {
@@ -48,20 +119,28 @@ func (r *venueRepository) Create(venue *models.Venue) error {
 
 func (r *venueRepository) GetByID(id uint) (*models.Venue, error) {
 	var venue models.Venue
-	if err := r.db.First(&venue, id).Error; err != nil {
+	if err := r.db.Preload("Units", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("Amenities", func(db *gorm.DB) *gorm.DB {
+		return db.Order("code ASC")
+	}).Preload("Photos", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC").Order("id ASC")
+	}).First(&venue, id).Error; err != nil {
 		r.logger.Error("Ошибка получения площадки по ID", "id", id, "error", err)
 		return nil, err
 	}
 	return &venue, nil
 }
 
-func (r *venueRepository) GetList(filter VenueFilter) ([]models.Venue, error) {
+// GetList возвращает страницу площадок и общее число подходящих под фильтр.
+// На страницу выбирается до Limit+1 записей: лишняя означает, что есть следующая страница
+func (r *venueRepository) GetList(filter VenueFilter) ([]models.Venue, int64, error) {
 	query := r.db.Model(&models.Venue{})
-	if filter.District != "" {
-		query = query.Where("district = ?", filter.District)
+	if len(filter.Districts) > 0 {
+		query = query.Where("district IN ?", filter.Districts)
 	}
-	if filter.VenueType != "" {
-		query = query.Where("venue_type = ?", filter.VenueType)
+	if len(filter.VenueTypes) > 0 {
+		query = query.Where("venue_type IN ?", filter.VenueTypes)
 	}
 	if filter.HourPrice > 0 {
 		query = query.Where("hour_price = ?", filter.HourPrice)
@@ -72,71 +151,344 @@ func (r *venueRepository) GetList(filter VenueFilter) ([]models.Venue, error) {
 	if filter.OwnerID > 0 {
 		query = query.Where("owner_id = ?", filter.OwnerID)
 	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.MinHourPrice > 0 {
+		query = query.Where("hour_price >= ?", filter.MinHourPrice)
+	}
+	if filter.MaxHourPrice > 0 {
+		query = query.Where("hour_price <= ?", filter.MaxHourPrice)
+	}
+	if filter.MinRating > 0 {
+		query = query.Where("rating >= ?", filter.MinRating)
+	}
+
+	if len(filter.Amenities) > 0 {
+		if filter.AmenitiesMatch == AmenitiesMatchAny {
+			query = query.Where("EXISTS (SELECT 1 FROM venue_amenities va WHERE va.venue_id = venues.id AND va.code IN ?)", filter.Amenities)
+		} else {
+			query = query.Where("(SELECT count(*) FROM venue_amenities va WHERE va.venue_id = venues.id AND va.code IN ?) = ?", filter.Amenities, len(filter.Amenities))
+		}
+	}
+
+	// Гео-фильтры: площадки без координат в выдачу не попадают
+	if filter.Geo != nil || filter.BBox != nil {
+		query = query.Where("latitude IS NOT NULL AND longitude IS NOT NULL")
+	}
+	if filter.BBox != nil {
+		query = applyBoundingBox(query, *filter.BBox)
+	}
+	if filter.Geo != nil && filter.Geo.RadiusKm > 0 {
+		// Сначала грубое отсечение по индексу, затем точное расстояние
+		query = applyBoundingBox(query, boundsForRadius(*filter.Geo))
+		query = query.Where(distanceExpr+" <= ?", append(distanceArgs(*filter.Geo), filter.Geo.RadiusKm)...)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.logger.Error("Ошибка подсчета площадок", "error", err)
+		return nil, 0, err
+	}
+
+	if filter.Geo != nil {
+		query = query.Select("venues.*, "+distanceExpr+" AS distance_km", distanceArgs(*filter.Geo)...)
+	}
+
+	// Продолжение после курсора: строки строго после последней выданной в порядке сортировки
+	if c := filter.After; c != nil {
+		switch filter.Sort {
+		case SortDistance:
+			if filter.Geo != nil {
+				query = query.Where("("+distanceExpr+", id) > (?, ?)", append(distanceArgs(*filter.Geo), c.DistanceKm, c.ID)...)
+			}
+		case SortPrice:
+			query = query.Where("(hour_price, id) > (?, ?)", c.HourPrice, c.ID)
+		case SortRating:
+			query = query.Where("(rating, rating_count, id) < (?, ?, ?)", c.Rating, c.RatingCount, c.ID)
+		default:
+			query = query.Where("id < ?", c.ID)
+		}
+	}
 
 	// Пагинация
 	if filter.Limit > 0 {
-		query = query.Limit(filter.Limit)
-		if filter.Page > 0 {
+		query = query.Limit(filter.Limit + 1)
+		if filter.After == nil && filter.Page > 0 {
 			offset := (filter.Page - 1) * filter.Limit
 			query = query.Offset(offset)
 		}
 	}
 
-	// Сортировка по ID (новые сначала)
-	query = query.Order("id DESC")
+	// Сортировка по цене, рейтингу, расстоянию или по ID (новые сначала).
+	// id в последнем ключе делает порядок стабильным между страницами
+	switch filter.Sort {
+	case SortDistance:
+		query = query.Order("distance_km ASC").Order("id ASC")
+	case SortPrice:
+		query = query.Order("hour_price ASC").Order("id ASC")
+	case SortRating:
+		query = query.Order("rating DESC").Order("rating_count DESC").Order("id DESC")
+	default:
+		query = query.Order("id DESC")
+	}
+
+	// Для карточек в списке достаточно обложки, галерея отдается в деталях площадки
+	query = query.Preload("Amenities", func(db *gorm.DB) *gorm.DB {
+		return db.Order("code ASC")
+	}).Preload("Photos", "is_cover = ?", true)
 
 	var venues []models.Venue
 	if err := query.Find(&venues).Error; err != nil {
 		r.logger.Error("Ошибка получения списка площадок", "error", err)
-		return nil, err
+		return nil, 0, err
 	}
-	return venues, nil
+	return venues, total, nil
 }
 
-func (r *venueRepository) GetByOwnerID(ownerID uint) ([]models.Venue, error) {
+// GetByOwnerID возвращает площадки владельца. statuses ограничивает статусы модерации, пусто - все
+func (r *venueRepository) GetByOwnerID(ownerID uint, statuses []models.VenueStatus) ([]models.Venue, error) {
+	query := r.db.Where("owner_id = ?", ownerID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
 	var venues []models.Venue
-	if err := r.db.Where("owner_id = ?", ownerID).Order("id DESC").Find(&venues).Error; err != nil {
+	if err := query.Order("id DESC").Find(&venues).Error; err != nil {
 		r.logger.Error("Ошибка получения площадок владельца", "owner_id", ownerID, "error", err)
 		return nil, err
 	}
 	return venues, nil
 }
 
+// Update сохраняет площадку, если её версия не изменилась с момента чтения (venue.Version),
+// и увеличивает версию. Иначе возвращает ErrVersionChanged
 func (r *venueRepository) Update(venue *models.Venue) error {
-	// Используем мапу для явного указания полей, которые нужно обновить
-	// Это позволяет обновлять поля в 0 или пустую строку
-	updateData := map[string]interface{}{
+	columns := venueColumns(venue)
+	columns["version"] = venue.Version + 1
+	result := r.db.Model(venue).Where("version = ?", venue.Version).Updates(columns)
+	if result.Error != nil {
+		r.logger.Error("Ошибка обновления площадки", "id", venue.ID, "error", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionChanged
+	}
+	venue.Version++
+	return nil
+}
+
+// UpdateWithRevision сохраняет площадку вместе с новой версией цены и расписания.
+// previous - состояние до изменения, записывается, если у площадки ещё нет истории
+// (площадки, созданные до её появления). Если версию площадки уже изменил
+// параллельный запрос, возвращает ErrRevisionChanged
+func (r *venueRepository) UpdateWithRevision(venue *models.Venue, previous, revision *models.VenueRevision) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.VenueRevision{}).Where("venue_id = ?", venue.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			if err := tx.Create(previous).Error; err != nil {
+				return err
+			}
+		}
+
+		columns := venueColumns(venue)
+		columns["revision"] = revision.Revision
+		columns["version"] = venue.Version + 1
+		result := tx.Model(venue).Where("revision = ? AND version = ?", previous.Revision, venue.Version).Updates(columns)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRevisionChanged
+		}
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		if !errors.Is(err, ErrRevisionChanged) {
+			r.logger.Error("Ошибка обновления площадки с новой версией", "id", venue.ID, "revision", revision.Revision, "error", err)
+		}
+		return err
+	}
+	venue.Version++
+	return nil
+}
+
+// touchVenue увеличивает версию площадки при изменении связанных записей (удобств, фотографий, единиц).
+// В транзакции заодно блокирует строку площадки. Хуки модели не вызываются
+func touchVenue(tx *gorm.DB, venueID uint) error {
+	result := tx.Model(&models.Venue{}).Where("id = ?", venueID).UpdateColumn("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetRevisions возвращает версии цены и расписания площадки, новые сначала
+func (r *venueRepository) GetRevisions(venueID uint, page, limit int) ([]models.VenueRevision, int64, error) {
+	query := r.db.Model(&models.VenueRevision{}).Where("venue_id = ?", venueID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.logger.Error("Ошибка подсчета версий площадки", "venue_id", venueID, "error", err)
+		return nil, 0, err
+	}
+
+	var revisions []models.VenueRevision
+	if err := query.Order("revision DESC").Offset((page - 1) * limit).Limit(limit).Find(&revisions).Error; err != nil {
+		r.logger.Error("Ошибка получения версий площадки", "venue_id", venueID, "error", err)
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+func (r *venueRepository) GetRevision(venueID uint, revision int) (*models.VenueRevision, error) {
+	var result models.VenueRevision
+	if err := r.db.Where("venue_id = ? AND revision = ?", venueID, revision).First(&result).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Import сохраняет новые и изменённые площадки: либо все, либо ни одной
+func (r *venueRepository) Import(batch *VenueImport) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		repo := &venueRepository{db: tx, logger: r.logger}
+		for _, venue := range batch.Create {
+			if err := tx.Create(venue).Error; err != nil {
+				return err
+			}
+		}
+		for _, update := range batch.Update {
+			if update.Revision != nil {
+				if err := repo.UpdateWithRevision(update.Venue, update.Previous, update.Revision); err != nil {
+					return err
+				}
+			} else if err := repo.Update(update.Venue); err != nil {
+				return err
+			}
+			if err := repo.SetAmenities(update.Venue.ID, update.Amenities); err != nil {
+				return err
+			}
+			if update.ResubmitFrom != "" {
+				// Статус успел изменить администратор - его решение важнее
+				if err := repo.UpdateStatus(update.Venue, update.ResubmitFrom); err != nil && !errors.Is(err, ErrStatusChanged) {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrRevisionChanged) && !errors.Is(err, ErrVersionChanged) {
+		r.logger.Error("Ошибка импорта площадок", "create", len(batch.Create), "update", len(batch.Update), "error", err)
+	}
+	return err
+}
+
+// venueColumns - изменяемые поля площадки. Мапа позволяет обновлять поля в 0 или пустую строку
+func venueColumns(venue *models.Venue) map[string]interface{} {
+	return map[string]interface{}{
 		"venue_type":           venue.VenueType,
 		"owner_id":             venue.OwnerID,
 		"is_active":            venue.IsActive,
 		"hour_price":           venue.HourPrice,
 		"district":             venue.District,
+		"capacity":             venue.Capacity,
 		"monday_enabled":       venue.Weekdays.Monday.Enabled,
 		"monday_start_time":    venue.Weekdays.Monday.StartTime,
 		"monday_end_time":      venue.Weekdays.Monday.EndTime,
+		"monday_intervals":     venue.Weekdays.Monday.Intervals,
 		"tuesday_enabled":      venue.Weekdays.Tuesday.Enabled,
 		"tuesday_start_time":   venue.Weekdays.Tuesday.StartTime,
 		"tuesday_end_time":     venue.Weekdays.Tuesday.EndTime,
+		"tuesday_intervals":    venue.Weekdays.Tuesday.Intervals,
 		"wednesday_enabled":    venue.Weekdays.Wednesday.Enabled,
 		"wednesday_start_time": venue.Weekdays.Wednesday.StartTime,
 		"wednesday_end_time":   venue.Weekdays.Wednesday.EndTime,
+		"wednesday_intervals":  venue.Weekdays.Wednesday.Intervals,
 		"thursday_enabled":     venue.Weekdays.Thursday.Enabled,
 		"thursday_start_time":  venue.Weekdays.Thursday.StartTime,
 		"thursday_end_time":    venue.Weekdays.Thursday.EndTime,
+		"thursday_intervals":   venue.Weekdays.Thursday.Intervals,
 		"friday_enabled":       venue.Weekdays.Friday.Enabled,
 		"friday_start_time":    venue.Weekdays.Friday.StartTime,
 		"friday_end_time":      venue.Weekdays.Friday.EndTime,
+		"friday_intervals":     venue.Weekdays.Friday.Intervals,
 		"saturday_enabled":     venue.Weekdays.Saturday.Enabled,
 		"saturday_start_time":  venue.Weekdays.Saturday.StartTime,
 		"saturday_end_time":    venue.Weekdays.Saturday.EndTime,
+		"saturday_intervals":   venue.Weekdays.Saturday.Intervals,
 		"sunday_enabled":       venue.Weekdays.Sunday.Enabled,
 		"sunday_start_time":    venue.Weekdays.Sunday.StartTime,
 		"sunday_end_time":      venue.Weekdays.Sunday.EndTime,
+		"sunday_intervals":     venue.Weekdays.Sunday.Intervals,
+
+		"booking_min_lead_minutes":     venue.BookingRules.MinLeadMinutes,
+		"booking_max_advance_days":     venue.BookingRules.MaxAdvanceDays,
+		"booking_min_duration_minutes": venue.BookingRules.MinDurationMinutes,
+		"booking_max_duration_minutes": venue.BookingRules.MaxDurationMinutes,
+		"booking_start_step_minutes":   venue.BookingRules.StartStepMinutes,
+		"booking_check_in_required":    venue.BookingRules.CheckInRequired,
+
+		"booking_late_cancel_hours":       venue.BookingRules.LateCancelHours,
+		"booking_reliability_limit":       venue.BookingRules.ReliabilityLimit,
+		"booking_reliability_period_days": venue.BookingRules.ReliabilityPeriodDays,
+		"booking_reliability_action":      venue.BookingRules.ReliabilityAction,
+
+		"address":   venue.Address,
+		"latitude":  venue.Latitude,
+		"longitude": venue.Longitude,
 	}
+}
 
-	if err := r.db.Model(venue).Updates(updateData).Error; err != nil {
-		r.logger.Error("Ошибка обновления площадки", "id", venue.ID, "error", err)
-		return err
+// GetModerationQueue возвращает площадки в статусе status, дольше всех ожидающие решения - первыми
+func (r *venueRepository) GetModerationQueue(status models.VenueStatus, page, limit int) ([]models.Venue, int64, error) {
+	query := r.db.Model(&models.Venue{}).Where("status = ?", status)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.logger.Error("Ошибка подсчета площадок на модерации", "status", status, "error", err)
+		return nil, 0, err
+	}
+
+	query = query.Order("submitted_at ASC NULLS LAST").Order("id ASC").Offset((page - 1) * limit).Limit(limit)
+	query = query.Preload("Amenities", func(db *gorm.DB) *gorm.DB {
+		return db.Order("code ASC")
+	}).Preload("Photos", "is_cover = ?", true)
+
+	var venues []models.Venue
+	if err := query.Find(&venues).Error; err != nil {
+		r.logger.Error("Ошибка получения очереди модерации", "status", status, "error", err)
+		return nil, 0, err
+	}
+	return venues, total, nil
+}
+
+// UpdateStatus сохраняет статус модерации и связанные поля, только если площадка всё ещё в статусе from.
+// Хуки модели не вызываются: остальные поля площадки не меняются
+func (r *venueRepository) UpdateStatus(venue *models.Venue, from models.VenueStatus) error {
+	result := r.db.Model(&models.Venue{}).Where("id = ? AND status = ?", venue.ID, from).UpdateColumns(map[string]interface{}{
+		"status":             venue.Status,
+		"moderation_comment": venue.ModerationComment,
+		"submitted_at":       venue.SubmittedAt,
+		"reviewed_at":        venue.ReviewedAt,
+		"reviewed_by":        venue.ReviewedBy,
+		"updated_at":         time.Now(),
+		"version":            gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		r.logger.Error("Ошибка изменения статуса площадки", "id", venue.ID, "status", venue.Status, "error", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStatusChanged
 	}
 	return nil
 }
@@ -154,3 +506,111 @@ func (r *venueRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+// GetDeleted возвращает удалённые площадки, недавно удалённые - первыми
+func (r *venueRepository) GetDeleted(page, limit int) ([]models.Venue, int64, error) {
+	query := r.db.Unscoped().Model(&models.Venue{}).Where("deleted_at IS NOT NULL")
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.logger.Error("Ошибка подсчета удалённых площадок", "error", err)
+		return nil, 0, err
+	}
+
+	query = query.Order("deleted_at DESC").Order("id DESC").Offset((page - 1) * limit).Limit(limit)
+	query = query.Preload("Photos", "is_cover = ?", true)
+
+	var venues []models.Venue
+	if err := query.Find(&venues).Error; err != nil {
+		r.logger.Error("Ошибка получения удалённых площадок", "error", err)
+		return nil, 0, err
+	}
+	return venues, total, nil
+}
+
+// GetDeletedByID возвращает удалённую площадку, у живой площадки - gorm.ErrRecordNotFound
+func (r *venueRepository) GetDeletedByID(id uint) (*models.Venue, error) {
+	var venue models.Venue
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&venue, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			r.logger.Error("Ошибка получения удалённой площадки", "id", id, "error", err)
+		}
+		return nil, err
+	}
+	return &venue, nil
+}
+
+// Restore снимает пометку об удалении
+func (r *venueRepository) Restore(id uint) error {
+	result := r.db.Unscoped().Model(&models.Venue{}).Where("id = ? AND deleted_at IS NOT NULL", id).UpdateColumn("deleted_at", nil)
+	if result.Error != nil {
+		r.logger.Error("Ошибка восстановления площадки", "id", id, "error", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge окончательно удаляет площадку, удалённую раньше deletedBefore, вместе с единицами, отзывами,
+// удобствами, фотографиями и историей. Возвращает удалённые фотографии, чтобы вызывающий удалил их файлы.
+// Площадку, которую успели восстановить или удалить заново, не трогает - gorm.ErrRecordNotFound
+func (r *venueRepository) Purge(id uint, deletedBefore time.Time) ([]models.VenuePhoto, error) {
+	var photos []models.VenuePhoto
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var venue models.Venue
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+			First(&venue, id).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("venue_id = ?", id).Find(&photos).Error; err != nil {
+			return err
+		}
+		dependents := []interface{}{
+			&models.VenuePhoto{}, &models.VenueAmenity{}, &models.VenueRevision{}, &models.Review{}, &models.VenueUnit{},
+			&models.FavoriteVenue{},
+		}
+		for _, model := range dependents {
+			if err := tx.Unscoped().Where("venue_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&venue).Error
+	})
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			r.logger.Error("Ошибка окончательного удаления площадки", "id", id, "error", err)
+		}
+		return nil, err
+	}
+	return photos, nil
+}
+
+// SetAmenities заменяет набор удобств площадки
+func (r *venueRepository) SetAmenities(venueID uint, codes []string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchVenue(tx, venueID); err != nil {
+			return err
+		}
+		if err := tx.Where("venue_id = ?", venueID).Delete(&models.VenueAmenity{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		amenities := make([]models.VenueAmenity, 0, len(codes))
+		for _, code := range codes {
+			amenities = append(amenities, models.VenueAmenity{VenueID: venueID, Code: code})
+		}
+		return tx.Create(&amenities).Error
+	})
+	if err != nil {
+		r.logger.Error("Ошибка обновления удобств площадки", "id", venueID, "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"log/slog"
+	"venue-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VenueTypeRepository interface {
+	GetList(includeInactive bool) ([]models.VenueTypeDefinition, error)
+	GetByCode(code models.VenueType) (*models.VenueTypeDefinition, error)
+	Create(venueType *models.VenueTypeDefinition) error
+	Update(venueType *models.VenueTypeDefinition, removedAmenities []string) error
+	Delete(code models.VenueType) error
+	CountVenues(code models.VenueType) (int64, error)
+	CountSharedVenues(code models.VenueType) (int64, error)
+}
+
+type venueTypeRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewVenueTypeRepository(db *gorm.DB, logger *slog.Logger) VenueTypeRepository {
+	return &venueTypeRepository{
+		db:     db,
+		logger: logger.With("layer", "repository"),
+	}
+}
+
+func (r *venueTypeRepository) GetList(includeInactive bool) ([]models.VenueTypeDefinition, error) {
+	var types []models.VenueTypeDefinition
+	query := r.db.Order("sort_order ASC, code ASC")
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+	if err := query.Find(&types).Error; err != nil {
+		r.logger.Error("Ошибка получения типов площадок", "error", err)
+		return nil, err
+	}
+	return types, nil
+}
+
+func (r *venueTypeRepository) GetByCode(code models.VenueType) (*models.VenueTypeDefinition, error) {
+	var venueType models.VenueTypeDefinition
+	if err := r.db.Where("code = ?", code).First(&venueType).Error; err != nil {
+		return nil, err
+	}
+	return &venueType, nil
+}
+
+func (r *venueTypeRepository) Create(venueType *models.VenueTypeDefinition) error {
+	// Повторный код не перезаписывает существующий тип, а приводит к ошибке
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(venueType)
+	if result.Error != nil {
+		r.logger.Error("Ошибка создания типа площадки", "code", venueType.Code, "error", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	return nil
+}
+
+// Update сохраняет тип и в той же транзакции снимает у его площадок удобства removedAmenities,
+// которые к типу больше неприменимы
+func (r *venueTypeRepository) Update(venueType *models.VenueTypeDefinition, removedAmenities []string) error {
+	// Мапа нужна, чтобы сохранять нулевые значения (shared = false, sort_order = 0)
+	updateData := map[string]interface{}{
+		"names":                        venueType.Names,
+		"icon":                         venueType.Icon,
+		"sort_order":                   venueType.SortOrder,
+		"is_active":                    venueType.IsActive,
+		"shared":                       venueType.Shared,
+		"default_min_duration_minutes": venueType.DefaultMinDurationMinutes,
+		"amenities":                    venueType.Amenities,
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(venueType).Updates(updateData).Error; err != nil {
+			return err
+		}
+		if len(removedAmenities) == 0 {
+			return nil
+		}
+		// Удалённые площадки тоже: после восстановления у них не должно остаться неприменимых удобств
+		venueIDs := tx.Unscoped().Model(&models.Venue{}).Select("id").Where("venue_type = ?", venueType.Code)
+		affected := tx.Model(&models.VenueAmenity{}).Select("venue_id").Where("code IN ? AND venue_id IN (?)", removedAmenities, venueIDs)
+		// Версия меняется, чтобы ETag площадки учитывал изменённые удобства
+		if err := tx.Unscoped().Model(&models.Venue{}).Where("id IN (?)", affected).UpdateColumn("version", gorm.Expr("version + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Where("code IN ? AND venue_id IN (?)", removedAmenities, venueIDs).Delete(&models.VenueAmenity{}).Error
+	})
+	if err != nil {
+		r.logger.Error("Ошибка обновления типа площадки", "code", venueType.Code, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *venueTypeRepository) Delete(code models.VenueType) error {
+	if err := r.db.Where("code = ?", code).Delete(&models.VenueTypeDefinition{}).Error; err != nil {
+		r.logger.Error("Ошибка удаления типа площадки", "code", code, "error", err)
+		return err
+	}
+	return nil
+}
+
+// CountVenues считает площадки типа, включая удалённые: их можно восстановить
+func (r *venueTypeRepository) CountVenues(code models.VenueType) (int64, error) {
+	var count int64
+	if err := r.db.Unscoped().Model(&models.Venue{}).Where("venue_type = ?", code).Count(&count).Error; err != nil {
+		r.logger.Error("Ошибка подсчета площадок типа", "code", code, "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountSharedVenues считает площадки типа с вместимостью больше 1
+func (r *venueTypeRepository) CountSharedVenues(code models.VenueType) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Venue{}).Where("venue_type = ? AND capacity > 1", code).Count(&count).Error; err != nil {
+		r.logger.Error("Ошибка подсчета площадок типа с местами", "code", code, "error", err)
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,169 @@
+package seed
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gorm.io/gorm"
+)
+
+// Databases - базы сервисов, в которые записываются сгенерированные данные
+type Databases struct {
+	Users        *gorm.DB
+	Venues       *gorm.DB
+	Reservations *gorm.DB
+	Payments     *gorm.DB
+}
+
+type seedDatabase struct {
+	name     string
+	db       *gorm.DB
+	required []string // Таблицы, которые заполняет генератор, должны быть созданы сервисом
+	reset    []string // Таблицы, которые очищает Reset
+}
+
+func (d Databases) list() []seedDatabase {
+	return []seedDatabase{
+		{name: "user_db", db: d.Users, required: []string{"users"}, reset: []string{"users"}},
+		// Справочник venue_types не очищается: его ведёт администратор
+		{name: "venue_db", db: d.Venues, required: []string{"venues"}, reset: []string{"favorite_venues", "saved_searches", "venue_units", "venue_amenities", "venue_photos", "reviews", "venue_revisions", "venues"}},
+		{name: "reservation_db", db: d.Reservations, required: []string{"reservation_details", "client_incidents"}, reset: []string{"booking_reminders", "client_incidents", "reservation_details"}},
+		{name: "payment_db", db: d.Payments, required: []string{"payments", "refunds"}, reset: []string{"refunds", "payments"}},
+	}
+}
+
+// batchSize - размер пакета вставки
+const batchSize = 500
+
+// Reset удаляет данные всех сервисов и сбрасывает счётчики идентификаторов
+func Reset(dbs Databases, logger *slog.Logger) error {
+	logger = logger.With("layer", "seed")
+
+	for _, d := range dbs.list() {
+		var tables []string
+		for _, table := range d.reset {
+			// Таблицы сервиса, который ещё ни разу не запускался, пропускаются
+			if d.db.Migrator().HasTable(table) {
+				tables = append(tables, table)
+			}
+		}
+		if len(tables) == 0 {
+			continue
+		}
+
+		sql := "TRUNCATE TABLE "
+		for i, table := range tables {
+			if i > 0 {
+				sql += ", "
+			}
+			sql += table
+		}
+		if err := d.db.Exec(sql + " RESTART IDENTITY CASCADE").Error; err != nil {
+			return fmt.Errorf("ошибка очистки %s: %w", d.name, err)
+		}
+		logger.Info("База очищена", "db", d.name, "tables", tables)
+	}
+	return nil
+}
+
+// Apply записывает данные в базы сервисов. Базы должны быть пустыми: идентификаторы
+// в плане назначены заранее и не должны пересекаться с существующими записями
+func Apply(dbs Databases, plan *Plan, logger *slog.Logger) error {
+	logger = logger.With("layer", "seed")
+
+	for _, d := range dbs.list() {
+		for _, table := range d.required {
+			if !d.db.Migrator().HasTable(table) {
+				return fmt.Errorf("в %s нет таблицы %s: запустите сервис один раз, чтобы создать схему", d.name, table)
+			}
+		}
+		var count int64
+		if err := d.db.Table(d.required[0]).Count(&count).Error; err != nil {
+			return fmt.Errorf("ошибка проверки данных %s: %w", d.name, err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%s уже содержит данные (%s: %d), запустите с -reset", d.name, d.required[0], count)
+		}
+	}
+
+	steps := []struct {
+		name   string
+		db     *gorm.DB
+		insert func(tx *gorm.DB) error
+		tables []string // Таблицы с заданными в плане идентификаторами
+	}{
+		{
+			name:   "user_db",
+			db:     dbs.Users,
+			insert: func(tx *gorm.DB) error { return createInBatches(tx, plan.Users) },
+			tables: []string{"users"},
+		},
+		{
+			name: "venue_db",
+			db:   dbs.Venues,
+			// Удобства и исходные версии площадок создаются вместе с площадками
+			insert: func(tx *gorm.DB) error { return createInBatches(tx, plan.Venues) },
+			tables: []string{"venues"},
+		},
+		{
+			name: "reservation_db",
+			db:   dbs.Reservations,
+			insert: func(tx *gorm.DB) error {
+				if err := createInBatches(tx, plan.Bookings); err != nil {
+					return err
+				}
+				return createInBatches(tx, plan.Incidents)
+			},
+			tables: []string{"reservation_details"},
+		},
+		{
+			name: "payment_db",
+			db:   dbs.Payments,
+			insert: func(tx *gorm.DB) error {
+				if err := createInBatches(tx, plan.Payments); err != nil {
+					return err
+				}
+				return createInBatches(tx, plan.Refunds)
+			},
+			tables: []string{"payments"},
+		},
+	}
+
+	for _, step := range steps {
+		err := step.db.Transaction(func(tx *gorm.DB) error {
+			if err := step.insert(tx); err != nil {
+				return err
+			}
+			// Вставка с явным id не двигает последовательность, новые записи сервисов получили бы занятые id
+			for _, table := range step.tables {
+				sql := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), (SELECT MAX(id) FROM %s))", table, table)
+				if err := tx.Exec(sql).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("ошибка записи в %s: %w", step.name, err)
+		}
+		logger.Info("Данные записаны", "db", step.name)
+	}
+
+	logger.Info("Генерация завершена",
+		"users", len(plan.Users),
+		"venues", len(plan.Venues),
+		"bookings", len(plan.Bookings),
+		"incidents", len(plan.Incidents),
+		"payments", len(plan.Payments),
+		"refunds", len(plan.Refunds),
+	)
+	return nil
+}
+
+// createInBatches пропускает пустые срезы: GORM возвращает на них ошибку
+func createInBatches[T any](tx *gorm.DB, rows []T) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(rows, batchSize).Error
+}
@@ -0,0 +1,605 @@
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+	"venue-service/internal/models"
+)
+
+// GenerateConfig - объём и период генерируемых данных
+type GenerateConfig struct {
+	Seed              uint64   // Одинаковый seed и параметры дают одинаковые данные
+	Owners            int      // Владельцы площадок
+	Clients           int      // Клиенты
+	Districts         []string // Районы, в каждом VenuesPerDistrict площадок
+	VenuesPerDistrict int
+	Bookings          int       // Броней всего, в прошлом и будущем
+	WeeksBack         int       // За сколько недель до Anchor начинаются брони
+	WeeksAhead        int       // На сколько недель после Anchor есть брони
+	Anchor            time.Time // "Сегодня": брони до него завершены, после - предстоят
+}
+
+// DefaultGenerateConfig возвращает параметры для демо-стенда
+func DefaultGenerateConfig() GenerateConfig {
+	return GenerateConfig{
+		Seed:              1,
+		Owners:            20,
+		Clients:           500,
+		Districts:         []string{"Центральный", "Северный", "Южный", "Западный", "Восточный"},
+		VenuesPerDistrict: 10,
+		Bookings:          5000,
+		WeeksBack:         8,
+		WeeksAhead:        2,
+		Anchor:            time.Now().UTC().Truncate(24 * time.Hour),
+	}
+}
+
+func (c GenerateConfig) validate() error {
+	if c.Owners < 1 || c.Clients < 1 || c.VenuesPerDistrict < 1 || len(c.Districts) == 0 {
+		return fmt.Errorf("нужен хотя бы один владелец, клиент, район и площадка в районе")
+	}
+	if c.Bookings < 0 || c.WeeksBack < 0 || c.WeeksAhead < 0 || c.WeeksBack+c.WeeksAhead == 0 && c.Bookings > 0 {
+		return fmt.Errorf("неверный период или количество броней")
+	}
+	return nil
+}
+
+// Plan - согласованные данные всех сервисов. Идентификаторы назначаются заранее,
+// чтобы связи между базами (owner_id, client_id, booking_id) совпадали
+type Plan struct {
+	Users     []userRow
+	Venues    []models.Venue
+	Bookings  []bookingRow
+	Incidents []incidentRow
+	Payments  []paymentRow
+	Refunds   []refundRow
+}
+
+// Пароль всех сгенерированных пользователей - password123 (bcrypt)
+const seedPasswordHash = "$2a$10$rjlpBgkd3tbKRGJv593uEOZV2V3auTBgduwGR1Hk7EBMdJAADrCVC"
+
+// Шаг сетки занятости площадки и начала броней
+const slotMinutes = 30
+
+var (
+	maleNames   = []string{"Александр", "Иван", "Дмитрий", "Сергей", "Андрей", "Михаил", "Алексей", "Ислам"}
+	femaleNames = []string{"Мария", "Анна", "Елена", "Ольга", "Наталья", "Татьяна", "Екатерина", "Амина"}
+	lastNames   = []string{"Иванов", "Смирнов", "Кузнецов", "Попов", "Васильев", "Петров", "Соколов", "Михайлов", "Новиков", "Фёдоров", "Морозов", "Волков", "Алексеев", "Лебедев"} // Женская форма - с окончанием "а"
+	streets     = []string{"ул. Ленина", "ул. Гагарина", "Спортивная ул.", "Садовая ул.", "пр. Мира", "Школьная ул.", "Парковая ул.", "ул. Победы", "Набережная ул.", "Олимпийский пр."}
+
+	// Доля площадок каждого типа и диапазон цены часа
+	venueTypeWeights = []struct {
+		venueType  models.VenueType
+		weight     int
+		minPrice   int
+		maxPrice   int
+		capacities []int
+	}{
+		{models.VenueFootball, 3, 1200, 4000, nil},
+		{models.VenueTennis, 3, 1500, 3500, nil},
+		{models.VenueBasketball, 2, 1000, 2500, nil},
+		{models.VenueGym, 2, 300, 900, []int{15, 25, 40}},
+		{models.VenueSwimming, 1, 500, 1200, []int{10, 20, 30}},
+	}
+
+	// Примерное расположение районов относительно центра (широта, долгота)
+	districtOffsets = map[string][2]float64{
+		"Центральный": {0, 0},
+		"Северный":    {0.09, 0},
+		"Южный":       {-0.09, 0},
+		"Западный":    {0, -0.15},
+		"Восточный":   {0, 0.15},
+	}
+)
+
+const centerLat, centerLon = 55.7558, 37.6173
+
+// Generate строит данные без обращения к базе, результат зависит только от cfg
+func Generate(cfg GenerateConfig) (*Plan, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		cfg:       cfg,
+		rng:       rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x5eed)),
+		plan:      &Plan{},
+		occupancy: make(map[uint]map[int64]int),
+	}
+	g.users()
+	if err := g.venues(); err != nil {
+		return nil, err
+	}
+	g.bookings()
+	g.payments()
+	return g.plan, nil
+}
+
+type generator struct {
+	cfg  GenerateConfig
+	rng  *rand.Rand
+	plan *Plan
+
+	owners, clients []uint
+	occupancy       map[uint]map[int64]int // Занятые места площадки по слотам (номер слота от Unix-эпохи)
+}
+
+func (g *generator) users() {
+	created := g.cfg.Anchor.AddDate(0, 0, -7*g.cfg.WeeksBack-90)
+	add := func(email, role string) uint {
+		id := uint(len(g.plan.Users) + 1)
+		user := userRow{
+			FullName: g.fullName(),
+			Email:    email,
+			Password: seedPasswordHash,
+			Role:     role,
+		}
+		user.ID = id
+		user.CreatedAt = created.Add(time.Duration(id) * time.Minute)
+		g.plan.Users = append(g.plan.Users, user)
+		return id
+	}
+
+	add("admin@seed.local", "Admin")
+	for i := 1; i <= g.cfg.Owners; i++ {
+		g.owners = append(g.owners, add(fmt.Sprintf("owner%03d@seed.local", i), "Owner"))
+	}
+	for i := 1; i <= g.cfg.Clients; i++ {
+		g.clients = append(g.clients, add(fmt.Sprintf("client%05d@seed.local", i), "Client"))
+	}
+}
+
+func (g *generator) fullName() string {
+	if g.rng.IntN(2) == 0 {
+		return pick(g.rng, lastNames) + " " + pick(g.rng, maleNames)
+	}
+	return pick(g.rng, lastNames) + "а " + pick(g.rng, femaleNames)
+}
+
+func (g *generator) venues() error {
+	created := g.cfg.Anchor.AddDate(0, 0, -7*g.cfg.WeeksBack-30)
+	totalWeight := 0
+	for _, t := range venueTypeWeights {
+		totalWeight += t.weight
+	}
+
+	for d, district := range g.cfg.Districts {
+		lat, lon := districtCenter(district, d, len(g.cfg.Districts))
+		for i := 0; i < g.cfg.VenuesPerDistrict; i++ {
+			n := g.rng.IntN(totalWeight)
+			kind := venueTypeWeights[0]
+			for _, t := range venueTypeWeights {
+				if n < t.weight {
+					kind = t
+					break
+				}
+				n -= t.weight
+			}
+
+			capacity := 1
+			if len(kind.capacities) > 0 {
+				capacity = pick(g.rng, kind.capacities)
+			}
+			// Цена кратна 100 рублям
+			price := (kind.minPrice + g.rng.IntN(kind.maxPrice-kind.minPrice+1)) / 100 * 100
+
+			venue := models.Venue{
+				VenueType:    kind.venueType,
+				OwnerID:      pick(g.rng, g.owners),
+				IsActive:     true,
+				HourPrice:    price,
+				District:     district,
+				Capacity:     capacity,
+				Weekdays:     g.schedule(kind.venueType),
+				BookingRules: models.BookingRules{MinDurationMinutes: models.DefaultMinDurationMinutes, StartStepMinutes: slotMinutes},
+				Revision:     1,
+				Version:      1,
+				Address:      fmt.Sprintf("%s, д. %d", pick(g.rng, streets), 1+g.rng.IntN(120)),
+				Latitude:     ptr(round6(lat + (g.rng.Float64()-0.5)*0.04)),
+				Longitude:    ptr(round6(lon + (g.rng.Float64()-0.5)*0.06)),
+				Status:       models.VenuePublished,
+			}
+			venue.ID = uint(len(g.plan.Venues) + 1)
+			venue.CreatedAt = created.Add(time.Duration(venue.ID) * time.Hour)
+			venue.UpdatedAt = venue.CreatedAt
+
+			venueType := defaultVenueType(venue.VenueType)
+			codes, err := models.ValidateAmenities(venueType, g.amenities(venueType))
+			if err != nil {
+				return fmt.Errorf("удобства площадки %d: %w", venue.ID, err)
+			}
+			for _, code := range codes {
+				venue.Amenities = append(venue.Amenities, models.VenueAmenity{VenueID: venue.ID, Code: code})
+			}
+
+			revision := models.NewVenueRevision(&venue, ptr(venue.OwnerID), nil)
+			revision.CreatedAt = venue.CreatedAt
+			venue.Revisions = []models.VenueRevision{revision}
+
+			g.plan.Venues = append(g.plan.Venues, venue)
+		}
+	}
+	return nil
+}
+
+// schedule выбирает один из типовых графиков работы
+func (g *generator) schedule(venueType models.VenueType) models.Weekdays {
+	daily := func(open, close string) []models.TimeInterval {
+		return []models.TimeInterval{{Start: open, End: close}}
+	}
+
+	var weekday, weekend []models.TimeInterval
+	switch venueType {
+	case models.VenueGym:
+		weekday, weekend = daily("06:00", "23:00"), daily("08:00", "22:00")
+	case models.VenueSwimming:
+		weekday, weekend = daily("07:00", "22:00"), daily("08:00", "20:00")
+	default:
+		switch g.rng.IntN(4) {
+		case 0:
+			weekday, weekend = daily("08:00", "22:00"), daily("08:00", "22:00")
+		case 1:
+			weekday, weekend = daily("07:00", "23:00"), daily("09:00", "21:00")
+		case 2:
+			weekday, weekend = daily("10:00", "22:00"), nil // Выходные - нерабочие дни
+		default:
+			// Школьный зал: утром до уроков и вечером после
+			weekday = []models.TimeInterval{{Start: "07:00", End: "08:30"}, {Start: "17:00", End: "22:00"}}
+			weekend = daily("09:00", "21:00")
+		}
+	}
+
+	return models.Weekdays{
+		Monday:    daySchedule(weekday),
+		Tuesday:   daySchedule(weekday),
+		Wednesday: daySchedule(weekday),
+		Thursday:  daySchedule(weekday),
+		Friday:    daySchedule(weekday),
+		Saturday:  daySchedule(weekend),
+		Sunday:    daySchedule(weekend),
+	}
+}
+
+// defaultVenueType возвращает тип из справочника по умолчанию: сид создаёт площадки только этих типов
+func defaultVenueType(code models.VenueType) *models.VenueTypeDefinition {
+	for _, t := range models.DefaultVenueTypes() {
+		if t.Code == code {
+			return &t
+		}
+	}
+	return &models.VenueTypeDefinition{Code: code}
+}
+
+// amenities выбирает расположение, покрытие и часть удобств, применимых к типу
+func (g *generator) amenities(venueType *models.VenueTypeDefinition) []string {
+	var codes []string
+	byGroup := make(map[string][]string)
+	for _, a := range models.AmenitiesFor(venueType) {
+		byGroup[a.Group] = append(byGroup[a.Group], a.Code)
+	}
+
+	if placement := byGroup[models.AmenityGroupPlacement]; len(placement) > 0 {
+		codes = append(codes, pick(g.rng, placement))
+	}
+	if surface := byGroup[models.AmenityGroupSurface]; len(surface) > 0 {
+		codes = append(codes, pick(g.rng, surface))
+	}
+	for _, code := range byGroup[models.AmenityGroupFacilities] {
+		if g.rng.IntN(2) == 0 {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+func (g *generator) bookings() {
+	if g.cfg.Bookings == 0 {
+		return
+	}
+
+	from := g.cfg.Anchor.AddDate(0, 0, -7*g.cfg.WeeksBack)
+	days := 7 * (g.cfg.WeeksBack + g.cfg.WeeksAhead)
+	durations := []int{60, 60, 90, 120}
+
+	// Площадки и дни бывают заняты полностью, поэтому попыток больше, чем броней
+	for attempt := 0; attempt < g.cfg.Bookings*20 && len(g.plan.Bookings) < g.cfg.Bookings; attempt++ {
+		venue := &g.plan.Venues[g.rng.IntN(len(g.plan.Venues))]
+		date := from.AddDate(0, 0, g.rng.IntN(days))
+		// В выходные броней больше
+		if !isWeekend(date) && g.rng.IntN(10) < 3 {
+			continue
+		}
+
+		duration := pick(g.rng, durations)
+		start, ok := g.startTime(venue, date, duration)
+		if !ok {
+			continue
+		}
+		end := start.Add(time.Duration(duration) * time.Minute)
+
+		partySize := 1
+		if venue.Capacity > 1 {
+			partySize = 1 + g.rng.IntN(min(3, venue.Capacity))
+		}
+		if !g.reserve(venue, start, end, partySize) {
+			continue
+		}
+
+		price := float64(venue.HourPrice) * end.Sub(start).Hours()
+		if venue.Capacity > 1 {
+			price *= float64(partySize)
+		}
+		booking := bookingRow{
+			ID:            uint(len(g.plan.Bookings) + 1),
+			VenueID:       venue.ID,
+			ClientID:      pick(g.rng, g.clients),
+			OwnerID:       venue.OwnerID,
+			StartAt:       start,
+			EndAt:         end,
+			Price:         price,
+			PartySize:     partySize,
+			Duration:      end.Sub(start),
+			VenueRevision: venue.Revision,
+		}
+		g.setStatus(&booking)
+		g.plan.Bookings = append(g.plan.Bookings, booking)
+	}
+}
+
+// startTime выбирает начало брони в часы работы площадки, вечера будней и дни выходных популярнее
+func (g *generator) startTime(venue *models.Venue, date time.Time, duration int) (time.Time, bool) {
+	type candidate struct {
+		start  time.Time
+		weight int
+	}
+	var candidates []candidate
+	total := 0
+	for _, window := range venue.Weekdays.Day(date.Weekday()).Windows() {
+		open, close := clock(date, window.Start), clock(date, window.End)
+		for start := open; !start.Add(time.Duration(duration) * time.Minute).After(close); start = start.Add(slotMinutes * time.Minute) {
+			weight := peakWeight(date, start.Hour())
+			candidates = append(candidates, candidate{start: start, weight: weight})
+			total += weight
+		}
+	}
+	if total == 0 {
+		return time.Time{}, false
+	}
+
+	n := g.rng.IntN(total)
+	for _, c := range candidates {
+		if n < c.weight {
+			return c.start, true
+		}
+		n -= c.weight
+	}
+	return time.Time{}, false
+}
+
+// peakWeight - относительная популярность часа начала брони
+func peakWeight(date time.Time, hour int) int {
+	if isWeekend(date) {
+		switch {
+		case hour >= 10 && hour < 18:
+			return 6
+		case hour >= 18 && hour < 21:
+			return 3
+		default:
+			return 1
+		}
+	}
+	switch {
+	case hour >= 17 && hour < 21:
+		return 8
+	case hour >= 7 && hour < 9:
+		return 3
+	case hour >= 21:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// reserve занимает места на площадке, если они свободны на всём интервале
+func (g *generator) reserve(venue *models.Venue, start, end time.Time, partySize int) bool {
+	slots := g.occupancy[venue.ID]
+	if slots == nil {
+		slots = make(map[int64]int)
+		g.occupancy[venue.ID] = slots
+	}
+
+	first, last := slotIndex(start), slotIndex(end)
+	for s := first; s < last; s++ {
+		if slots[s]+partySize > venue.Capacity {
+			return false
+		}
+	}
+	for s := first; s < last; s++ {
+		slots[s] += partySize
+	}
+	return true
+}
+
+// setStatus распределяет статусы: прошедшие брони в основном завершены,
+// будущие подтверждены или ожидают подтверждения
+func (g *generator) setStatus(b *bookingRow) {
+	// Бронь создана за 2 часа - 3 недели до начала, но не позже "сегодня"
+	b.CreatedAt = b.StartAt.Add(-time.Duration(120+g.rng.IntN(21*24*60)) * time.Minute)
+	if b.CreatedAt.After(g.cfg.Anchor) {
+		b.CreatedAt = g.cfg.Anchor.Add(-time.Duration(1+g.rng.IntN(72*60)) * time.Minute)
+	}
+	b.UpdatedAt = b.CreatedAt
+
+	n := g.rng.IntN(100)
+	if !b.EndAt.After(g.cfg.Anchor) {
+		switch {
+		case n < 85:
+			b.Status = "completed"
+			checkedIn := b.StartAt.Add(-time.Duration(g.rng.IntN(15)) * time.Minute)
+			b.CheckedInAt = &checkedIn
+			b.UpdatedAt = b.EndAt
+		case n < 92:
+			b.Status = "no_show"
+			b.UpdatedAt = b.EndAt
+			g.plan.Incidents = append(g.plan.Incidents, incidentRow{
+				CreatedAt:  b.EndAt,
+				UpdatedAt:  b.EndAt,
+				ClientID:   b.ClientID,
+				VenueID:    b.VenueID,
+				OwnerID:    b.OwnerID,
+				BookingID:  b.ID,
+				Kind:       "no_show",
+				OccurredAt: b.EndAt,
+			})
+		default:
+			g.cancel(b)
+		}
+		return
+	}
+
+	switch {
+	case n < 70:
+		b.Status = "confirmed"
+	case n < 90:
+		b.Status = "pending"
+	default:
+		g.cancel(b)
+	}
+}
+
+func (g *generator) cancel(b *bookingRow) {
+	b.Status = "cancelled"
+	b.ReasonForCancel = pick(g.rng, []string{"Изменились планы", "Не собралась команда", "Плохая погода", "Заболел"})
+	// Отмена между созданием и началом брони, но не позже "сегодня"
+	latest := b.StartAt
+	if latest.After(g.cfg.Anchor) {
+		latest = g.cfg.Anchor
+	}
+	b.UpdatedAt = b.CreatedAt
+	if span := latest.Sub(b.CreatedAt); span > time.Minute {
+		b.UpdatedAt = b.CreatedAt.Add(time.Duration(g.rng.Int64N(int64(span))))
+	}
+}
+
+// payments создаёт платежи броней: оплаченные, возвращённые при отмене,
+// неоплаченные ожидающие брони и неудачные попытки оплаты картой
+func (g *generator) payments() {
+	add := func(p paymentRow) *paymentRow {
+		p.ID = uint(len(g.plan.Payments) + 1)
+		p.Currency = "RUB"
+		g.plan.Payments = append(g.plan.Payments, p)
+		return &g.plan.Payments[len(g.plan.Payments)-1]
+	}
+
+	for _, b := range g.plan.Bookings {
+		amount := minorUnits(b.Price)
+		method := "card"
+		if g.rng.IntN(5) == 0 {
+			method = "cash"
+		}
+		paidAt := b.CreatedAt.Add(time.Duration(1+g.rng.IntN(30)) * time.Minute)
+		if paidAt.After(g.cfg.Anchor) {
+			paidAt = b.CreatedAt
+		}
+
+		switch b.Status {
+		case "pending":
+			if g.rng.IntN(2) == 0 {
+				p := paymentRow{BookingID: b.ID, UserID: b.ClientID, VenueID: b.VenueID, OwnerID: b.OwnerID, Amount: amount, Method: method, Status: "pending"}
+				p.CreatedAt = b.CreatedAt
+				add(p)
+			}
+			continue
+		case "cancelled":
+			// Часть отменённых броней не оплачивалась
+			if g.rng.IntN(10) < 4 {
+				continue
+			}
+		}
+
+		if method == "card" && g.rng.IntN(100) < 3 {
+			failed := paymentRow{BookingID: b.ID, UserID: b.ClientID, VenueID: b.VenueID, OwnerID: b.OwnerID, Amount: amount, Method: method, Status: "failed"}
+			failed.CreatedAt = b.CreatedAt
+			add(failed)
+		}
+
+		p := paymentRow{BookingID: b.ID, UserID: b.ClientID, VenueID: b.VenueID, OwnerID: b.OwnerID, Amount: amount, Method: method, Status: "completed", PaidAt: &paidAt}
+		p.CreatedAt = b.CreatedAt
+		p.UpdatedAt = paidAt
+		payment := add(p)
+
+		if b.Status != "cancelled" {
+			continue
+		}
+		refundedAt := b.UpdatedAt
+		if refundedAt.Before(paidAt) {
+			refundedAt = paidAt
+		}
+		payment.Status = "refunded"
+		payment.RefundedAmount = amount
+		payment.RefundedAt = &refundedAt
+		payment.UpdatedAt = refundedAt
+
+		refund := refundRow{PaymentID: payment.ID, Amount: amount, Reason: b.ReasonForCancel, Status: "completed"}
+		refund.CreatedAt = refundedAt
+		g.plan.Refunds = append(g.plan.Refunds, refund)
+	}
+}
+
+// pick возвращает случайный элемент непустого среза
+func pick[T any](rng *rand.Rand, values []T) T {
+	return values[rng.IntN(len(values))]
+}
+
+// districtCenter возвращает центр района: известные районы по сторонам света,
+// остальные по кругу вокруг центра города
+func districtCenter(name string, i, total int) (float64, float64) {
+	if offset, ok := districtOffsets[name]; ok {
+		return centerLat + offset[0], centerLon + offset[1]
+	}
+	angle := 2 * math.Pi * float64(i) / float64(total)
+	return centerLat + 0.12*math.Sin(angle), centerLon + 0.2*math.Cos(angle)
+}
+
+func daySchedule(intervals []models.TimeInterval) models.DaySchedule {
+	if len(intervals) == 0 {
+		return models.DaySchedule{Enabled: false}
+	}
+	start := clock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), intervals[0].Start)
+	end := clock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), intervals[len(intervals)-1].End)
+	day := models.DaySchedule{Enabled: true, StartTime: &start, EndTime: &end}
+	if len(intervals) > 1 {
+		day.Intervals = slices.Clone(intervals)
+	}
+	return day
+}
+
+// clock возвращает время "HH:MM" в день date
+func clock(date time.Time, value string) time.Time {
+	t, _ := time.Parse(models.TimeLayout, value)
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, date.Location())
+}
+
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+func slotIndex(t time.Time) int64 {
+	return t.Unix() / (slotMinutes * 60)
+}
+
+// minorUnits переводит рубли в копейки так же, как contracts/events.MinorUnits
+func minorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func round6(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,140 @@
+package seed
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"venue-service/internal/models"
+)
+
+func testGenerateConfig() GenerateConfig {
+	cfg := DefaultGenerateConfig()
+	cfg.Owners = 5
+	cfg.Clients = 50
+	cfg.VenuesPerDistrict = 4
+	cfg.Bookings = 1500
+	cfg.Anchor = time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	return cfg
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	cfg := testGenerateConfig()
+	first, err := Generate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Generate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("одинаковый seed дал разные данные")
+	}
+
+	cfg.Seed++
+	other, err := Generate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(first.Bookings, other.Bookings) {
+		t.Fatal("разные seed дали одинаковые брони")
+	}
+}
+
+func TestGenerateConsistency(t *testing.T) {
+	cfg := testGenerateConfig()
+	plan, err := Generate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(plan.Venues), cfg.VenuesPerDistrict*len(cfg.Districts); got != want {
+		t.Fatalf("площадок %d, ожидалось %d", got, want)
+	}
+	if len(plan.Bookings) < cfg.Bookings*9/10 {
+		t.Fatalf("создано %d броней из %d", len(plan.Bookings), cfg.Bookings)
+	}
+
+	roles := make(map[uint]string)
+	for _, u := range plan.Users {
+		roles[u.ID] = u.Role
+	}
+	venues := make(map[uint]*models.Venue)
+	for i := range plan.Venues {
+		v := &plan.Venues[i]
+		if roles[v.OwnerID] != "Owner" {
+			t.Fatalf("площадка %d: владелец %d не Owner", v.ID, v.OwnerID)
+		}
+		venues[v.ID] = v
+	}
+
+	occupancy := make(map[uint]map[int64]int)
+	bookings := make(map[uint]bookingRow)
+	for _, b := range plan.Bookings {
+		v := venues[b.VenueID]
+		if v == nil || b.OwnerID != v.OwnerID || roles[b.ClientID] != "Client" {
+			t.Fatalf("бронь %d ссылается на несуществующие площадку или пользователей", b.ID)
+		}
+		if !b.CreatedAt.Before(b.StartAt) || b.CreatedAt.After(cfg.Anchor) {
+			t.Fatalf("бронь %d создана %s, начало %s", b.ID, b.CreatedAt, b.StartAt)
+		}
+
+		// Бронь целиком внутри одного интервала работы
+		inside := false
+		for _, w := range v.Weekdays.Day(b.StartAt.Weekday()).Windows() {
+			if !b.StartAt.Before(clock(b.StartAt, w.Start)) && !b.EndAt.After(clock(b.StartAt, w.End)) {
+				inside = true
+			}
+		}
+		if !inside {
+			t.Fatalf("бронь %d (%s - %s) вне расписания площадки %d", b.ID, b.StartAt, b.EndAt, v.ID)
+		}
+
+		if occupancy[v.ID] == nil {
+			occupancy[v.ID] = make(map[int64]int)
+		}
+		for s := slotIndex(b.StartAt); s < slotIndex(b.EndAt); s++ {
+			occupancy[v.ID][s] += b.PartySize
+			if occupancy[v.ID][s] > v.Capacity {
+				t.Fatalf("площадка %d переполнена бронью %d", v.ID, b.ID)
+			}
+		}
+
+		past := !b.EndAt.After(cfg.Anchor)
+		switch b.Status {
+		case "completed", "no_show":
+			if !past {
+				t.Fatalf("будущая бронь %d в статусе %s", b.ID, b.Status)
+			}
+		case "confirmed", "pending":
+			if past {
+				t.Fatalf("прошедшая бронь %d в статусе %s", b.ID, b.Status)
+			}
+		}
+		bookings[b.ID] = b
+	}
+
+	for _, incident := range plan.Incidents {
+		if bookings[incident.BookingID].Status != "no_show" {
+			t.Fatalf("нарушение по брони %d без неявки", incident.BookingID)
+		}
+	}
+
+	payments := make(map[uint]paymentRow)
+	for _, p := range plan.Payments {
+		b, ok := bookings[p.BookingID]
+		if !ok || p.UserID != b.ClientID || p.Amount != minorUnits(b.Price) || p.VenueID != b.VenueID || p.OwnerID != b.OwnerID {
+			t.Fatalf("платёж %d не соответствует брони %d", p.ID, p.BookingID)
+		}
+		if p.Status == "refunded" && b.Status != "cancelled" {
+			t.Fatalf("возврат по брони %d в статусе %s", b.ID, b.Status)
+		}
+		payments[p.ID] = p
+	}
+	for _, r := range plan.Refunds {
+		p := payments[r.PaymentID]
+		if p.Status != "refunded" || p.RefundedAmount != r.Amount {
+			t.Fatalf("возврат по платежу %d не совпадает с платежом", r.PaymentID)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package seed
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Строки таблиц других сервисов. Сервисы не могут импортировать модели друг друга,
+// поэтому здесь повторены только колонки, которые заполняет генератор.
+// Схему создают сами сервисы при старте (AutoMigrate), генератор её не меняет
+
+// userRow - users в user-service
+type userRow struct {
+	gorm.Model
+	FullName string
+	Email    string
+	Password string
+	Role     string
+}
+
+func (userRow) TableName() string { return "users" }
+
+// bookingRow - reservation_details в reservation-service
+type bookingRow struct {
+	ID                 uint `gorm:"primarykey"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	VenueID            uint
+	ClientID           uint
+	OwnerID            uint
+	StartAt            time.Time
+	EndAt              time.Time
+	Price              float64
+	PartySize          int
+	Duration           time.Duration
+	ReasonForCancel    string
+	Status             string
+	CheckedInAt        *time.Time
+	PrepaymentRequired bool
+	VenueRevision      int
+}
+
+func (bookingRow) TableName() string { return "reservation_details" }
+
+// incidentRow - client_incidents в reservation-service
+type incidentRow struct {
+	ID         uint `gorm:"primarykey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ClientID   uint
+	VenueID    uint
+	OwnerID    uint
+	BookingID  uint
+	Kind       string
+	OccurredAt time.Time
+}
+
+func (incidentRow) TableName() string { return "client_incidents" }
+
+// paymentRow - payments в payment-service, суммы в копейках
+type paymentRow struct {
+	gorm.Model
+	BookingID      uint
+	UserID         uint
+	VenueID        uint // Площадка и владелец брони: по owner_id строится выгрузка платежей владельца
+	OwnerID        uint
+	Amount         int64
+	Currency       string
+	Method         string
+	Status         string
+	RefundedAmount int64
+	PaidAt         *time.Time
+	RefundedAt     *time.Time
+}
+
+func (paymentRow) TableName() string { return "payments" }
+
+// refundRow - refunds в payment-service
+type refundRow struct {
+	gorm.Model
+	PaymentID uint
+	Amount    int64
+	Reason    string
+	Status    string
+}
+
+func (refundRow) TableName() string { return "refunds" }
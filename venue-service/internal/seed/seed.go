@@ -151,6 +151,7 @@ func SeedVenues(db *gorm.DB, logger *slog.Logger) error {
 			IsActive:  true,
 			HourPrice: 500,
 			District:  "Северный",
+			Capacity:  40,
 			Weekdays:  createWeekdays(6, 0, 23, 59),
 		},
 		{
@@ -159,6 +160,7 @@ func SeedVenues(db *gorm.DB, logger *slog.Logger) error {
 			IsActive:  true,
 			HourPrice: 800,
 			District:  "Центральный",
+			Capacity:  25,
 			Weekdays:  createWeekdays(7, 0, 23, 0, true, true, true, true, true, true, false), // Воскресенье выходной
 		},
 		// Бассейны
@@ -168,6 +170,7 @@ func SeedVenues(db *gorm.DB, logger *slog.Logger) error {
 			IsActive:  true,
 			HourPrice: 1000,
 			District:  "Южный",
+			Capacity:  30,
 			Weekdays:  createWeekdays(8, 0, 20, 0),
 		},
 		{
@@ -176,6 +179,7 @@ func SeedVenues(db *gorm.DB, logger *slog.Logger) error {
 			IsActive:  false, // Деактивированная площадка для тестирования
 			HourPrice: 1500,
 			District:  "Восточный",
+			Capacity:  20,
 			Weekdays:  createWeekdays(9, 0, 19, 0, true, true, true, true, true, false, false), // Суббота и воскресенье выходные
 		},
 	}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+	"venue-service/internal/clients"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// fakeDeletedVenueRepository хранит живые и удалённые площадки отдельно
+type fakeDeletedVenueRepository struct {
+	repository.VenueRepository
+	venues  map[uint]models.Venue
+	deleted map[uint]models.Venue
+	photos  map[uint][]models.VenuePhoto
+}
+
+func (r *fakeDeletedVenueRepository) GetByID(id uint) (*models.Venue, error) {
+	v, ok := r.venues[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &v, nil
+}
+
+func (r *fakeDeletedVenueRepository) GetDeletedByID(id uint) (*models.Venue, error) {
+	v, ok := r.deleted[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &v, nil
+}
+
+func (r *fakeDeletedVenueRepository) Restore(id uint) error {
+	v, ok := r.deleted[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	v.DeletedAt = gorm.DeletedAt{}
+	r.venues[id] = v
+	delete(r.deleted, id)
+	return nil
+}
+
+func (r *fakeDeletedVenueRepository) Purge(id uint, deletedBefore time.Time) ([]models.VenuePhoto, error) {
+	v, ok := r.deleted[id]
+	if !ok || !v.DeletedAt.Time.Before(deletedBefore) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	delete(r.deleted, id)
+	photos := r.photos[id]
+	delete(r.photos, id)
+	return photos, nil
+}
+
+type fakeReservationClient struct {
+	clients.ReservationClient
+	upcoming int64
+	err      error
+}
+
+func (c *fakeReservationClient) CountUpcomingBookings(venueID uint) (int64, error) {
+	return c.upcoming, c.err
+}
+
+// fakeStorage запоминает удалённые ключи
+type fakeStorage struct {
+	deleted []string
+}
+
+func (s *fakeStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	return nil
+}
+
+func (s *fakeStorage) Delete(ctx context.Context, key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+func (s *fakeStorage) URL(key string) string {
+	return key
+}
+
+const testRetention = 30 * 24 * time.Hour
+
+func deletedVenue(deletedAgo time.Duration) models.Venue {
+	venue := testVenue()
+	venue.DeletedAt = gorm.DeletedAt{Time: time.Now().Add(-deletedAgo), Valid: true}
+	return venue
+}
+
+func newFakeDeletedVenueRepository(venue models.Venue) *fakeDeletedVenueRepository {
+	return &fakeDeletedVenueRepository{
+		venues:  make(map[uint]models.Venue),
+		deleted: map[uint]models.Venue{venue.ID: venue},
+		photos: map[uint][]models.VenuePhoto{venue.ID: {
+			{VenueID: venue.ID, OriginalKey: "venues/1/a/original.jpg", MediumKey: "venues/1/a/medium.jpg", ThumbKey: "venues/1/a/thumb.jpg"},
+		}},
+	}
+}
+
+func TestDeletedVenuePurge(t *testing.T) {
+	unavailable := errors.New("reservation-service недоступен")
+	tests := []struct {
+		name        string
+		claims      *models.Claims
+		deletedAgo  time.Duration
+		upcoming    int64
+		reservedErr error
+		wantErr     error
+	}{
+		{name: "владелец", claims: ownerClaims, deletedAgo: 40 * 24 * time.Hour, wantErr: ErrForbidden},
+		{name: "срок не истёк", claims: adminClaims, deletedAgo: 10 * 24 * time.Hour, wantErr: ErrPurgeTooEarly},
+		{name: "будущие брони", claims: adminClaims, deletedAgo: 40 * 24 * time.Hour, upcoming: 2, wantErr: ErrVenueHasBookings},
+		{name: "reservation-service недоступен", claims: adminClaims, deletedAgo: 40 * 24 * time.Hour, reservedErr: unavailable, wantErr: unavailable},
+		{name: "удаление", claims: adminClaims, deletedAgo: 40 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeDeletedVenueRepository(deletedVenue(tt.deletedAgo))
+			files := &fakeStorage{}
+			reservations := &fakeReservationClient{upcoming: tt.upcoming, err: tt.reservedErr}
+			service := NewDeletedVenueService(repo, reservations, files, testRetention, testLogger())
+
+			err := service.Purge(context.Background(), 1, tt.claims)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+
+			_, stillDeleted := repo.deleted[1]
+			if err != nil {
+				if !stillDeleted || len(files.deleted) != 0 {
+					t.Fatalf("площадка и файлы не должны удаляться")
+				}
+				return
+			}
+			if stillDeleted || len(files.deleted) != 3 {
+				t.Fatalf("площадка удалена: %v, удалено файлов: %d", !stillDeleted, len(files.deleted))
+			}
+		})
+	}
+}
+
+func TestDeletedVenueRestore(t *testing.T) {
+	repo := newFakeDeletedVenueRepository(deletedVenue(time.Hour))
+	service := NewDeletedVenueService(repo, &fakeReservationClient{}, &fakeStorage{}, testRetention, testLogger())
+
+	if _, err := service.Restore(1, ownerClaims); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("восстановление владельцем: ошибка %v", err)
+	}
+	venue, err := service.Restore(1, adminClaims)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if venue.DeletedAt.Valid || len(repo.deleted) != 0 {
+		t.Fatalf("площадка должна быть восстановлена")
+	}
+	if _, err := service.Restore(1, adminClaims); !errors.Is(err, ErrVenueNotFound) {
+		t.Fatalf("повторное восстановление: ошибка %v", err)
+	}
+}
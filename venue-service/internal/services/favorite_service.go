@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// FavoriteService - избранные площадки пользователя
+type FavoriteService interface {
+	Add(claims *models.Claims, venueID uint) error
+	Remove(claims *models.Claims, venueID uint) error
+	GetList(claims *models.Claims, page, limit int) ([]models.Venue, int64, error)
+	// FavoriteIDs отмечает, какие из площадок в избранном у пользователя. Без пользователя - пустой результат
+	FavoriteIDs(claims *models.Claims, venueIDs []uint) (map[uint]bool, error)
+}
+
+type favoriteService struct {
+	venues    repository.VenueRepository
+	favorites repository.FavoriteRepository
+	logger    *slog.Logger
+}
+
+func NewFavoriteService(venues repository.VenueRepository, favorites repository.FavoriteRepository, logger *slog.Logger) FavoriteService {
+	return &favoriteService{
+		venues:    venues,
+		favorites: favorites,
+		logger:    logger.With("layer", "service"),
+	}
+}
+
+// Add добавляет в избранное опубликованную площадку. Повторное добавление не ошибка
+func (s *favoriteService) Add(claims *models.Claims, venueID uint) error {
+	if claims == nil {
+		return ErrForbidden
+	}
+	venue, err := s.venues.GetByID(venueID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVenueNotFound
+		}
+		return err
+	}
+	if venue.Status != models.VenuePublished {
+		return ErrVenueNotFound
+	}
+
+	if err := s.favorites.Add(claims.UserID, venueID); err != nil {
+		return err
+	}
+	s.logger.Info("Площадка добавлена в избранное", "venue_id", venueID, "user_id", claims.UserID)
+	return nil
+}
+
+// Remove убирает площадку из избранного. Удаление отсутствующей площадки не ошибка
+func (s *favoriteService) Remove(claims *models.Claims, venueID uint) error {
+	if claims == nil {
+		return ErrForbidden
+	}
+	return s.favorites.Remove(claims.UserID, venueID)
+}
+
+func (s *favoriteService) GetList(claims *models.Claims, page, limit int) ([]models.Venue, int64, error) {
+	if claims == nil {
+		return nil, 0, ErrForbidden
+	}
+	return s.favorites.GetVenues(claims.UserID, page, limit)
+}
+
+func (s *favoriteService) FavoriteIDs(claims *models.Claims, venueIDs []uint) (map[uint]bool, error) {
+	result := make(map[uint]bool)
+	if claims == nil || len(venueIDs) == 0 {
+		return result, nil
+	}
+	ids, err := s.favorites.FavoriteVenueIDs(claims.UserID, venueIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
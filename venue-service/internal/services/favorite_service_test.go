@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+)
+
+// fakeFavoriteRepository хранит избранное в памяти
+type fakeFavoriteRepository struct {
+	repository.FavoriteRepository
+	favorites map[uint][]uint // user_id -> venue_id
+}
+
+func newFakeFavoriteRepository() *fakeFavoriteRepository {
+	return &fakeFavoriteRepository{favorites: make(map[uint][]uint)}
+}
+
+func (r *fakeFavoriteRepository) Add(userID, venueID uint) error {
+	if !slices.Contains(r.favorites[userID], venueID) {
+		r.favorites[userID] = append(r.favorites[userID], venueID)
+	}
+	return nil
+}
+
+func (r *fakeFavoriteRepository) Remove(userID, venueID uint) error {
+	r.favorites[userID] = slices.DeleteFunc(r.favorites[userID], func(id uint) bool { return id == venueID })
+	return nil
+}
+
+func (r *fakeFavoriteRepository) FavoriteVenueIDs(userID uint, venueIDs []uint) ([]uint, error) {
+	var result []uint
+	for _, id := range venueIDs {
+		if slices.Contains(r.favorites[userID], id) {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+func TestFavoriteAdd(t *testing.T) {
+	draft := venueWithStatus(models.VenueDraft)
+	draft.ID = 2
+	venues := newFakeVenueRepository(venueWithStatus(models.VenuePublished), draft)
+	favorites := newFakeFavoriteRepository()
+	service := NewFavoriteService(venues, favorites, testLogger())
+
+	tests := []struct {
+		name    string
+		claims  *models.Claims
+		venueID uint
+		wantErr error
+	}{
+		{name: "опубликованная площадка", claims: clientClaims, venueID: 1},
+		{name: "повторное добавление", claims: clientClaims, venueID: 1},
+		{name: "неопубликованная площадка", claims: clientClaims, venueID: 2, wantErr: ErrVenueNotFound},
+		{name: "несуществующая площадка", claims: clientClaims, venueID: 3, wantErr: ErrVenueNotFound},
+		{name: "без авторизации", claims: nil, venueID: 1, wantErr: ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.Add(tt.claims, tt.venueID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+	if got := favorites.favorites[clientID]; !slices.Equal(got, []uint{1}) {
+		t.Fatalf("избранное клиента %v, ожидалось [1]", got)
+	}
+}
+
+func TestFavoriteIDs(t *testing.T) {
+	favorites := newFakeFavoriteRepository()
+	service := NewFavoriteService(newFakeVenueRepository(venueWithStatus(models.VenuePublished)), favorites, testLogger())
+	if err := service.Add(clientClaims, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := service.FavoriteIDs(clientClaims, []uint{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ids[1] || ids[2] {
+		t.Fatalf("избранное %v", ids)
+	}
+
+	// У другого пользователя своё избранное, без пользователя - пустое
+	for _, claims := range []*models.Claims{otherOwnerClaims, nil} {
+		ids, err := service.FavoriteIDs(claims, []uint{1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 0 {
+			t.Fatalf("избранное %v, ожидалось пустое", ids)
+		}
+	}
+
+	if err := service.Remove(clientClaims, 1); err != nil {
+		t.Fatal(err)
+	}
+	if ids, _ := service.FavoriteIDs(clientClaims, []uint{1}); ids[1] {
+		t.Fatal("площадка осталась в избранном после удаления")
+	}
+}
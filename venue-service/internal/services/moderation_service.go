@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidTransition        = errors.New("действие недоступно в текущем статусе площадки")
+	ErrModerationReasonRequired = errors.New("для этого действия нужно указать причину")
+)
+
+// ModerationPolicy настройки модерации площадок
+type ModerationPolicy struct {
+	// Существенные правки (тип, район, адрес, координаты) одобренной или опубликованной площадки
+	// отправляют её на повторную модерацию. Правки администратора модерацию не требуют
+	ReapproveMaterialEdits bool
+}
+
+// VenuePublishedListener узнаёт о публикации площадок
+type VenuePublishedListener interface {
+	VenuePublished(ctx context.Context, venue *models.Venue)
+}
+
+type VenueModerationService interface {
+	Apply(id uint, claims *models.Claims, action models.ModerationAction, reason string) (*models.Venue, error)
+	GetQueue(claims *models.Claims, status models.VenueStatus, page, limit int) ([]models.Venue, int64, error)
+}
+
+type venueModerationService struct {
+	repository repository.VenueRepository
+	published  VenuePublishedListener
+	logger     *slog.Logger
+}
+
+func NewVenueModerationService(repository repository.VenueRepository, published VenuePublishedListener, logger *slog.Logger) VenueModerationService {
+	return &venueModerationService{
+		repository: repository,
+		published:  published,
+		logger:     logger.With("layer", "service"),
+	}
+}
+
+// Apply выполняет действие модерации: проверяет права, текущий статус и сохраняет новый статус
+func (s *venueModerationService) Apply(id uint, claims *models.Claims, action models.ModerationAction, reason string) (*models.Venue, error) {
+	transition, ok := models.FindTransition(action)
+	if !ok {
+		return nil, fmt.Errorf("%w: неизвестное действие %s", ErrInvalidTransition, action)
+	}
+
+	venue, err := s.repository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVenueNotFound
+		}
+		return nil, err
+	}
+
+	isAdmin := claims != nil && claims.Role == models.RoleAdmin
+	if transition.AdminOnly && !isAdmin || !canManageVenue(claims, venue) {
+		return nil, ErrForbidden
+	}
+	if transition.ReasonRequired && reason == "" {
+		return nil, ErrModerationReasonRequired
+	}
+	if !transition.Allows(venue.Status) {
+		return nil, fmt.Errorf("%w: %s из статуса %s", ErrInvalidTransition, action, venue.Status)
+	}
+
+	from := venue.Status
+	now := time.Now()
+	venue.Status = transition.To
+	switch action {
+	case models.ActionSubmit:
+		venue.SubmittedAt = &now
+		venue.ModerationComment = ""
+	case models.ActionPublish:
+		// Публикация одобренной площадки не меняет решение модератора
+	default:
+		venue.ReviewedAt = &now
+		venue.ReviewedBy = &claims.UserID
+		venue.ModerationComment = reason
+	}
+
+	if err := s.repository.UpdateStatus(venue, from); err != nil {
+		if errors.Is(err, repository.ErrStatusChanged) {
+			return nil, fmt.Errorf("%w: статус площадки изменился, обновите данные", ErrInvalidTransition)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Статус площадки изменён", "id", id, "action", action, "from", from, "to", venue.Status, "user_id", claims.UserID)
+	if action == models.ActionPublish {
+		// Сохранённые поиски проверяются в фоне, чтобы не задерживать ответ
+		published := *venue
+		go s.published.VenuePublished(context.Background(), &published)
+	}
+	return venue, nil
+}
+
+// GetQueue возвращает очередь модерации администратора: площадки в статусе status, старые заявки первыми
+func (s *venueModerationService) GetQueue(claims *models.Claims, status models.VenueStatus, page, limit int) ([]models.Venue, int64, error) {
+	if claims == nil || claims.Role != models.RoleAdmin {
+		return nil, 0, ErrForbidden
+	}
+
+	venues, total, err := s.repository.GetModerationQueue(status, page, limit)
+	if err != nil {
+		s.logger.Error("Ошибка получения очереди модерации", "status", status, "error", err)
+		return nil, 0, err
+	}
+	return venues, total, nil
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"venue-service/internal/models"
+)
+
+// fakePublishedListener передаёт опубликованные площадки в канал
+type fakePublishedListener struct {
+	published chan models.Venue
+}
+
+func newFakePublishedListener() *fakePublishedListener {
+	return &fakePublishedListener{published: make(chan models.Venue, 1)}
+}
+
+func (l *fakePublishedListener) VenuePublished(ctx context.Context, venue *models.Venue) {
+	l.published <- *venue
+}
+
+func venueWithStatus(status models.VenueStatus) models.Venue {
+	v := testVenue()
+	v.Status = status
+	return v
+}
+
+func TestVenueCreateStartsAsDraft(t *testing.T) {
+	repo := newFakeVenueRepository()
+	service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	venue := testVenue()
+	venue.ID = 0
+	venue.Status = models.VenuePublished
+	if err := service.Create(ownerClaims, &venue); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.venues[venue.ID].Status; got != models.VenueDraft {
+		t.Fatalf("статус новой площадки %s, ожидался draft", got)
+	}
+}
+
+func TestModerationTransitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       models.VenueStatus
+		action     models.ModerationAction
+		claims     *models.Claims
+		reason     string
+		wantErr    error
+		wantStatus models.VenueStatus
+	}{
+		{name: "владелец отправляет черновик", from: models.VenueDraft, action: models.ActionSubmit, claims: ownerClaims, wantStatus: models.VenueSubmitted},
+		{name: "владелец отправляет отклонённую", from: models.VenueRejected, action: models.ActionSubmit, claims: ownerClaims, wantStatus: models.VenueSubmitted},
+		{name: "чужой владелец не отправляет", from: models.VenueDraft, action: models.ActionSubmit, claims: otherOwnerClaims, wantErr: ErrForbidden},
+		{name: "клиент не отправляет", from: models.VenueDraft, action: models.ActionSubmit, claims: clientClaims, wantErr: ErrForbidden},
+		{name: "повторная отправка", from: models.VenueSubmitted, action: models.ActionSubmit, claims: ownerClaims, wantErr: ErrInvalidTransition},
+
+		{name: "администратор одобряет", from: models.VenueSubmitted, action: models.ActionApprove, claims: adminClaims, wantStatus: models.VenueApproved},
+		{name: "владелец не одобряет", from: models.VenueSubmitted, action: models.ActionApprove, claims: ownerClaims, wantErr: ErrForbidden},
+		{name: "одобрение черновика", from: models.VenueDraft, action: models.ActionApprove, claims: adminClaims, wantErr: ErrInvalidTransition},
+		{name: "отклонение с причиной", from: models.VenueSubmitted, action: models.ActionReject, claims: adminClaims, reason: "нет фото", wantStatus: models.VenueRejected},
+		{name: "отклонение без причины", from: models.VenueSubmitted, action: models.ActionReject, claims: adminClaims, wantErr: ErrModerationReasonRequired},
+
+		{name: "владелец публикует одобренную", from: models.VenueApproved, action: models.ActionPublish, claims: ownerClaims, wantStatus: models.VenuePublished},
+		{name: "публикация без одобрения", from: models.VenueSubmitted, action: models.ActionPublish, claims: ownerClaims, wantErr: ErrInvalidTransition},
+
+		{name: "администратор приостанавливает", from: models.VenuePublished, action: models.ActionSuspend, claims: adminClaims, reason: "жалобы", wantStatus: models.VenueSuspended},
+		{name: "владелец не приостанавливает", from: models.VenuePublished, action: models.ActionSuspend, claims: ownerClaims, reason: "ремонт", wantErr: ErrForbidden},
+		{name: "владелец не возвращает приостановленную", from: models.VenueSuspended, action: models.ActionReinstate, claims: ownerClaims, wantErr: ErrForbidden},
+		{name: "администратор возвращает", from: models.VenueSuspended, action: models.ActionReinstate, claims: adminClaims, wantStatus: models.VenuePublished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeVenueRepository(venueWithStatus(tt.from))
+			service := NewVenueModerationService(repo, newFakePublishedListener(), testLogger())
+
+			_, err := service.Apply(1, tt.claims, tt.action, tt.reason)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+
+			saved := repo.venues[1]
+			if tt.wantErr != nil {
+				if saved.Status != tt.from {
+					t.Fatalf("статус изменился на %s", saved.Status)
+				}
+				return
+			}
+			if saved.Status != tt.wantStatus {
+				t.Fatalf("статус %s, ожидался %s", saved.Status, tt.wantStatus)
+			}
+			if saved.ModerationComment != tt.reason {
+				t.Fatalf("moderation_comment = %q, ожидалось %q", saved.ModerationComment, tt.reason)
+			}
+		})
+	}
+}
+
+func TestMaterialEditRequiresReapproval(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     ModerationPolicy
+		claims     *models.Claims
+		district   string
+		hourPrice  int
+		wantStatus models.VenueStatus
+	}{
+		{name: "смена района", policy: ModerationPolicy{ReapproveMaterialEdits: true}, claims: ownerClaims, district: "Северный", hourPrice: 3000, wantStatus: models.VenueSubmitted},
+		{name: "смена цены", policy: ModerationPolicy{ReapproveMaterialEdits: true}, claims: ownerClaims, district: "Центральный", hourPrice: 4000, wantStatus: models.VenuePublished},
+		{name: "правка администратора", policy: ModerationPolicy{ReapproveMaterialEdits: true}, claims: adminClaims, district: "Северный", hourPrice: 3000, wantStatus: models.VenuePublished},
+		{name: "политика выключена", policy: ModerationPolicy{}, claims: ownerClaims, district: "Северный", hourPrice: 3000, wantStatus: models.VenuePublished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeVenueRepository(venueWithStatus(models.VenuePublished))
+			service := NewVenueService(repo, newFakeVenueTypeRepository(), tt.policy, testLogger())
+
+			update := testVenue()
+			update.District = tt.district
+			update.HourPrice = tt.hourPrice
+			if err := service.Update(1, tt.claims, &update, 0); err != nil {
+				t.Fatal(err)
+			}
+			if got := repo.venues[1].Status; got != tt.wantStatus {
+				t.Fatalf("статус %s, ожидался %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestModerationQueueAdminOnly(t *testing.T) {
+	service := NewVenueModerationService(newFakeVenueRepository(), newFakePublishedListener(), testLogger())
+	for _, claims := range []*models.Claims{nil, clientClaims, ownerClaims} {
+		if _, _, err := service.GetQueue(claims, models.VenueSubmitted, 1, 20); !errors.Is(err, ErrForbidden) {
+			t.Fatalf("ошибка %v, ожидалась %v", err, ErrForbidden)
+		}
+	}
+}
+
+func TestModerationPublishNotifiesListener(t *testing.T) {
+	repo := newFakeVenueRepository(venueWithStatus(models.VenueApproved))
+	listener := newFakePublishedListener()
+	service := NewVenueModerationService(repo, listener, testLogger())
+
+	if _, err := service.Apply(1, ownerClaims, models.ActionPublish, ""); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case venue := <-listener.published:
+		if venue.ID != 1 || venue.Status != models.VenuePublished {
+			t.Fatalf("опубликована площадка %d со статусом %s", venue.ID, venue.Status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("слушатель не узнал о публикации")
+	}
+}
+
+func TestVenueGetVisibleByStatus(t *testing.T) {
+	for _, status := range []models.VenueStatus{models.VenueDraft, models.VenueSubmitted, models.VenueRejected, models.VenuePublished} {
+		service := NewVenueService(newFakeVenueRepository(venueWithStatus(status)), newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+		for _, claims := range []*models.Claims{nil, clientClaims, otherOwnerClaims, ownerClaims, adminClaims} {
+			_, err := service.GetVisible(1, claims)
+			visible := status == models.VenuePublished || claims == ownerClaims || claims == adminClaims
+			if visible && err != nil {
+				t.Fatalf("%s, claims %+v: %v", status, claims, err)
+			}
+			if !visible && !errors.Is(err, ErrVenueNotFound) {
+				t.Fatalf("%s, claims %+v: ошибка %v, ожидалась %v", status, claims, err, ErrVenueNotFound)
+			}
+		}
+	}
+}
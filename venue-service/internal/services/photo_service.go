@@ -0,0 +1,229 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"venue-service/internal/imaging"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+	"venue-service/internal/storage"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPhotoNotFound     = errors.New("photo not found")
+	ErrInvalidImage      = errors.New("invalid image")
+	ErrPhotoLimit        = fmt.Errorf("у площадки может быть не больше %d фотографий", models.MaxVenuePhotos)
+	ErrInvalidPhotoOrder = errors.New("порядок должен содержать все фотографии площадки ровно по одному разу")
+)
+
+type VenuePhotoService interface {
+	GetByVenueID(venueID uint) ([]models.VenuePhoto, error)
+	Upload(ctx context.Context, venueID uint, claims *models.Claims, data []byte) (*models.VenuePhoto, error)
+	Reorder(venueID uint, claims *models.Claims, ids []uint) ([]models.VenuePhoto, error)
+	SetCover(venueID, id uint, claims *models.Claims) ([]models.VenuePhoto, error)
+	Delete(ctx context.Context, venueID, id uint, claims *models.Claims) error
+}
+
+type venuePhotoService struct {
+	venueRepository repository.VenueRepository
+	photoRepository repository.VenuePhotoRepository
+	storage         storage.Storage
+	logger          *slog.Logger
+}
+
+func NewVenuePhotoService(venueRepository repository.VenueRepository, photoRepository repository.VenuePhotoRepository, storage storage.Storage, logger *slog.Logger) VenuePhotoService {
+	return &venuePhotoService{
+		venueRepository: venueRepository,
+		photoRepository: photoRepository,
+		storage:         storage,
+		logger:          logger.With("layer", "service"),
+	}
+}
+
+func (s *venuePhotoService) GetByVenueID(venueID uint) ([]models.VenuePhoto, error) {
+	if _, err := s.getVenue(venueID); err != nil {
+		return nil, err
+	}
+	return s.photoRepository.GetByVenueID(venueID)
+}
+
+// Upload проверяет изображение, сохраняет оригинал и уменьшенные копии в хранилище и добавляет фото в конец галереи.
+// Если запись в БД не удалась, загруженные файлы удаляются
+func (s *venuePhotoService) Upload(ctx context.Context, venueID uint, claims *models.Claims, data []byte) (*models.VenuePhoto, error) {
+	venue, err := s.getVenue(venueID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageVenue(claims, venue) {
+		return nil, ErrForbidden
+	}
+	if len(venue.Photos) >= models.MaxVenuePhotos {
+		return nil, ErrPhotoLimit
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	thumbnails, err := img.Thumbnails()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	prefix, err := photoKeyPrefix(venueID)
+	if err != nil {
+		return nil, err
+	}
+	photo := &models.VenuePhoto{
+		VenueID:     venueID,
+		ContentType: img.ContentType,
+		Width:       img.Width,
+		Height:      img.Height,
+		SizeBytes:   int64(len(data)),
+		OriginalKey: prefix + "/original." + img.Ext,
+		MediumKey:   prefix + "/medium.jpg",
+		ThumbKey:    prefix + "/thumb.jpg",
+	}
+
+	files := []struct {
+		key         string
+		data        []byte
+		contentType string
+	}{
+		{photo.OriginalKey, data, img.ContentType},
+		{photo.MediumKey, thumbnails["medium"], "image/jpeg"},
+		{photo.ThumbKey, thumbnails["thumb"], "image/jpeg"},
+	}
+	for _, f := range files {
+		if err := s.storage.Put(ctx, f.key, bytes.NewReader(f.data), f.contentType); err != nil {
+			s.logger.Error("Ошибка сохранения файла фотографии", "venue_id", venueID, "key", f.key, "error", err)
+			s.deleteFiles(ctx, photo)
+			return nil, err
+		}
+	}
+
+	if err := s.photoRepository.Create(photo, models.MaxVenuePhotos); err != nil {
+		s.deleteFiles(ctx, photo)
+		if errors.Is(err, repository.ErrPhotoLimit) {
+			return nil, ErrPhotoLimit
+		}
+		return nil, err
+	}
+	return photo, nil
+}
+
+// Reorder задает порядок фотографий: ids должны перечислять все фотографии площадки
+func (s *venuePhotoService) Reorder(venueID uint, claims *models.Claims, ids []uint) ([]models.VenuePhoto, error) {
+	venue, err := s.getVenue(venueID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageVenue(claims, venue) {
+		return nil, ErrForbidden
+	}
+
+	if len(ids) != len(venue.Photos) {
+		return nil, ErrInvalidPhotoOrder
+	}
+	existing := make(map[uint]bool, len(venue.Photos))
+	for _, p := range venue.Photos {
+		existing[p.ID] = true
+	}
+	for _, id := range ids {
+		if !existing[id] {
+			return nil, ErrInvalidPhotoOrder
+		}
+		delete(existing, id)
+	}
+
+	if err := s.photoRepository.Reorder(venueID, ids); err != nil {
+		return nil, err
+	}
+	return s.photoRepository.GetByVenueID(venueID)
+}
+
+func (s *venuePhotoService) SetCover(venueID, id uint, claims *models.Claims) ([]models.VenuePhoto, error) {
+	venue, err := s.getVenue(venueID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageVenue(claims, venue) {
+		return nil, ErrForbidden
+	}
+
+	if err := s.photoRepository.SetCover(venueID, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	return s.photoRepository.GetByVenueID(venueID)
+}
+
+// Delete удаляет фотографию из галереи, затем её файлы.
+// Ошибка удаления файлов только логируется: запись уже удалена и файлы никому не видны
+func (s *venuePhotoService) Delete(ctx context.Context, venueID, id uint, claims *models.Claims) error {
+	venue, err := s.getVenue(venueID)
+	if err != nil {
+		return err
+	}
+	if !canManageVenue(claims, venue) {
+		return ErrForbidden
+	}
+
+	photo, err := s.photoRepository.GetByID(venueID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPhotoNotFound
+		}
+		return err
+	}
+	if err := s.photoRepository.Delete(photo); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPhotoNotFound
+		}
+		return err
+	}
+	s.deleteFiles(ctx, photo)
+	return nil
+}
+
+func (s *venuePhotoService) deleteFiles(ctx context.Context, photo *models.VenuePhoto) {
+	for _, key := range photo.Keys() {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Error("Ошибка удаления файла фотографии", "venue_id", photo.VenueID, "key", key, "error", err)
+		}
+	}
+}
+
+func (s *venuePhotoService) getVenue(venueID uint) (*models.Venue, error) {
+	venue, err := s.venueRepository.GetByID(venueID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVenueNotFound
+		}
+		return nil, err
+	}
+	return venue, nil
+}
+
+// canManageVenue - изменять площадку может её владелец или администратор
+func canManageVenue(claims *models.Claims, venue *models.Venue) bool {
+	return claims != nil && (claims.Role == models.RoleAdmin || venue.OwnerID == claims.UserID)
+}
+
+// photoKeyPrefix возвращает случайный каталог фотографии: URL нельзя подобрать, а повторная загрузка не перезаписывает старые файлы
+func photoKeyPrefix(venueID uint) (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("venues/%d/%s", venueID, hex.EncodeToString(token)), nil
+}
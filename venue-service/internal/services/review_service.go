@@ -0,0 +1,186 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+	"venue-service/internal/clients"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// Статус завершённой брони в reservation-service
+const bookingStatusCompleted = "completed"
+
+var (
+	ErrForbidden         = errors.New("недостаточно прав")
+	ErrReviewNotFound    = errors.New("review not found")
+	ErrReviewNotAllowed  = errors.New("отзыв можно оставить только по своей завершённой брони на этой площадке")
+	ErrReviewExists      = errors.New("по этой брони уже оставлен отзыв")
+	ErrReviewReplyExists = errors.New("владелец уже ответил на этот отзыв")
+)
+
+type VenueReviewService interface {
+	GetByVenueID(venueID uint, claims *models.Claims, page, limit int) ([]models.Review, int64, error)
+	Create(venueID uint, claims *models.Claims, review *models.Review) error
+	Reply(venueID, id uint, claims *models.Claims, text string) (*models.Review, error)
+	SetHidden(venueID, id uint, claims *models.Claims, hidden bool, reason string) (*models.Review, error)
+}
+
+type venueReviewService struct {
+	venueRepository  repository.VenueRepository
+	reviewRepository repository.ReviewRepository
+	reservations     clients.ReservationClient
+	logger           *slog.Logger
+}
+
+func NewVenueReviewService(venueRepository repository.VenueRepository, reviewRepository repository.ReviewRepository, reservations clients.ReservationClient, logger *slog.Logger) VenueReviewService {
+	return &venueReviewService{
+		venueRepository:  venueRepository,
+		reviewRepository: reviewRepository,
+		reservations:     reservations,
+		logger:           logger.With("layer", "service"),
+	}
+}
+
+// GetByVenueID возвращает отзывы площадки, новые сначала. Скрытые отзывы видит только администратор
+func (s *venueReviewService) GetByVenueID(venueID uint, claims *models.Claims, page, limit int) ([]models.Review, int64, error) {
+	if _, err := s.getVenue(venueID); err != nil {
+		return nil, 0, err
+	}
+
+	includeHidden := claims != nil && claims.Role == models.RoleAdmin
+	reviews, total, err := s.reviewRepository.GetByVenueID(venueID, includeHidden, page, limit)
+	if err != nil {
+		s.logger.Error("Ошибка получения отзывов площадки", "venue_id", venueID, "error", err)
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// Create добавляет отзыв клиента. Бронь проверяется в reservation-service:
+// она должна принадлежать клиенту, относиться к площадке и быть завершённой
+func (s *venueReviewService) Create(venueID uint, claims *models.Claims, review *models.Review) error {
+	if claims == nil {
+		return ErrForbidden
+	}
+	if _, err := s.getVenue(venueID); err != nil {
+		return err
+	}
+
+	booking, err := s.reservations.GetBooking(review.BookingID)
+	if err != nil {
+		if errors.Is(err, clients.ErrBookingNotFound) {
+			return ErrReviewNotAllowed
+		}
+		s.logger.Error("Ошибка проверки брони для отзыва", "booking_id", review.BookingID, "error", err)
+		return err
+	}
+	if booking.VenueID != venueID || booking.ClientID != claims.UserID || booking.Status != bookingStatusCompleted {
+		return ErrReviewNotAllowed
+	}
+
+	exists, err := s.reviewRepository.ExistsForBooking(review.BookingID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrReviewExists
+	}
+
+	review.VenueID = venueID
+	review.ClientID = claims.UserID
+	review.Reply = nil
+	review.RepliedAt = nil
+	review.IsHidden = false
+	review.HiddenReason = ""
+	if err := s.reviewRepository.Create(review); err != nil {
+		// Уникальный индекс по booking_id защищает от параллельного повторного отзыва
+		if repository.IsUniqueViolation(err) {
+			return ErrReviewExists
+		}
+		return err
+	}
+	return nil
+}
+
+// Reply сохраняет единственный ответ владельца площадки на отзыв
+func (s *venueReviewService) Reply(venueID, id uint, claims *models.Claims, text string) (*models.Review, error) {
+	venue, err := s.getVenue(venueID)
+	if err != nil {
+		return nil, err
+	}
+	if claims == nil || (claims.Role != models.RoleAdmin && venue.OwnerID != claims.UserID) {
+		return nil, ErrForbidden
+	}
+
+	review, err := s.getReview(venueID, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.Reply != nil {
+		return nil, ErrReviewReplyExists
+	}
+
+	now := time.Now()
+	replied, err := s.reviewRepository.SetReply(review.ID, text, now)
+	if err != nil {
+		return nil, err
+	}
+	if !replied {
+		// Ответ успел сохранить параллельный запрос
+		return nil, ErrReviewReplyExists
+	}
+	review.Reply = &text
+	review.RepliedAt = &now
+	return review, nil
+}
+
+// SetHidden скрывает или возвращает отзыв (только администратор). Рейтинг площадки пересчитывается
+func (s *venueReviewService) SetHidden(venueID, id uint, claims *models.Claims, hidden bool, reason string) (*models.Review, error) {
+	if claims == nil || claims.Role != models.RoleAdmin {
+		return nil, ErrForbidden
+	}
+
+	review, err := s.getReview(venueID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hidden {
+		reason = ""
+	}
+	review.IsHidden = hidden
+	review.HiddenReason = reason
+	if err := s.reviewRepository.Update(review, map[string]interface{}{
+		"is_hidden":     hidden,
+		"hidden_reason": reason,
+	}); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *venueReviewService) getVenue(venueID uint) (*models.Venue, error) {
+	venue, err := s.venueRepository.GetByID(venueID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVenueNotFound
+		}
+		return nil, err
+	}
+	return venue, nil
+}
+
+func (s *venueReviewService) getReview(venueID, id uint) (*models.Review, error) {
+	review, err := s.reviewRepository.GetByID(venueID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, err
+	}
+	return review, nil
+}
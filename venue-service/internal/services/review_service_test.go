@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// fakeReviewRepository хранит отзывы в памяти. SetReply повторяет условие reply IS NULL из репозитория
+type fakeReviewRepository struct {
+	repository.ReviewRepository
+	reviews map[uint]models.Review
+}
+
+func newFakeReviewRepository(reviews ...models.Review) *fakeReviewRepository {
+	r := &fakeReviewRepository{reviews: make(map[uint]models.Review)}
+	for _, review := range reviews {
+		r.reviews[review.ID] = review
+	}
+	return r
+}
+
+func (r *fakeReviewRepository) GetByID(venueID, id uint) (*models.Review, error) {
+	review, ok := r.reviews[id]
+	if !ok || review.VenueID != venueID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &review, nil
+}
+
+func (r *fakeReviewRepository) SetReply(id uint, text string, at time.Time) (bool, error) {
+	review, ok := r.reviews[id]
+	if !ok || review.Reply != nil {
+		return false, nil
+	}
+	review.Reply = &text
+	review.RepliedAt = &at
+	r.reviews[id] = review
+	return true, nil
+}
+
+func testReview() models.Review {
+	review := models.Review{VenueID: 1, BookingID: 40, ClientID: clientID, Rating: 5}
+	review.ID = 3
+	return review
+}
+
+func TestReviewReply(t *testing.T) {
+	reviews := newFakeReviewRepository(testReview())
+	service := NewVenueReviewService(newFakeVenueRepository(testVenue()), reviews, nil, testLogger())
+
+	review, err := service.Reply(1, 3, ownerClaims, "Спасибо!")
+	if err != nil {
+		t.Fatalf("Reply: %v", err)
+	}
+	if review.Reply == nil || *review.Reply != "Спасибо!" || review.RepliedAt == nil {
+		t.Fatalf("unexpected review: %+v", review)
+	}
+
+	if _, err := service.Reply(1, 3, ownerClaims, "Ещё раз спасибо"); !errors.Is(err, ErrReviewReplyExists) {
+		t.Fatalf("повторный ответ: err = %v, want ErrReviewReplyExists", err)
+	}
+	if got := *reviews.reviews[3].Reply; got != "Спасибо!" {
+		t.Fatalf("ответ перезаписан: %q", got)
+	}
+}
+
+// racingReviewRepository отдаёт отзыв без ответа, хотя его уже сохранил параллельный запрос
+type racingReviewRepository struct {
+	*fakeReviewRepository
+}
+
+func (r racingReviewRepository) GetByID(venueID, id uint) (*models.Review, error) {
+	review, err := r.fakeReviewRepository.GetByID(venueID, id)
+	if err != nil {
+		return nil, err
+	}
+	review.Reply = nil
+	return review, nil
+}
+
+func TestReviewReplyConcurrentConflict(t *testing.T) {
+	review := testReview()
+	first := "Первый ответ"
+	review.Reply = &first
+	reviews := racingReviewRepository{newFakeReviewRepository(review)}
+	service := NewVenueReviewService(newFakeVenueRepository(testVenue()), reviews, nil, testLogger())
+
+	if _, err := service.Reply(1, 3, ownerClaims, "Второй ответ"); !errors.Is(err, ErrReviewReplyExists) {
+		t.Fatalf("err = %v, want ErrReviewReplyExists", err)
+	}
+	if got := *reviews.reviews[3].Reply; got != first {
+		t.Fatalf("ответ перезаписан: %q", got)
+	}
+}
+
+func TestReviewReplyForbidden(t *testing.T) {
+	service := NewVenueReviewService(newFakeVenueRepository(testVenue()), newFakeReviewRepository(testReview()), nil, testLogger())
+
+	for _, claims := range []*models.Claims{nil, otherOwnerClaims, clientClaims} {
+		if _, err := service.Reply(1, 3, claims, "Ответ"); !errors.Is(err, ErrForbidden) {
+			t.Fatalf("claims %+v: err = %v, want ErrForbidden", claims, err)
+		}
+	}
+}
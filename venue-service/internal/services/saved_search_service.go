@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"venue-service/internal/kafka"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"contracts/events"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSavedSearchNotFound  = errors.New("сохранённый поиск не найден")
+	ErrInvalidSavedSearch   = errors.New("неверные параметры сохранённого поиска")
+	ErrTooManySavedSearches = fmt.Errorf("можно сохранить не больше %d поисков", models.MaxSavedSearches)
+)
+
+// Сохранённые поиски проверяются пачками такого размера
+const savedSearchBatchSize = 200
+
+// FreedSlot - время площадки, освободившееся после отмены брони
+type FreedSlot struct {
+	BookingID uint
+	VenueID   uint
+	UnitID    *uint
+	ClientID  uint // Отменивший бронь клиент, ему о своей отмене не сообщаем
+	StartAt   time.Time
+	EndAt     time.Time
+}
+
+// SavedSearchService - сохранённые поиски пользователя и события о подходящих под них площадках
+type SavedSearchService interface {
+	Create(claims *models.Claims, search *models.SavedSearch) error
+	GetList(claims *models.Claims) ([]models.SavedSearch, error)
+	GetByID(id uint, claims *models.Claims) (*models.SavedSearch, error)
+	Update(id uint, claims *models.Claims, search *models.SavedSearch) error
+	Delete(id uint, claims *models.Claims) error
+
+	VenuePublishedListener
+	// SlotFreed отправляет saved_search.matched по поискам, под которые подходит освободившееся время
+	SlotFreed(ctx context.Context, slot FreedSlot) error
+}
+
+type savedSearchService struct {
+	venues   repository.VenueRepository
+	searches repository.SavedSearchRepository
+	producer kafka.Producer // nil - Kafka не настроена, события не отправляются
+	logger   *slog.Logger
+}
+
+func NewSavedSearchService(venues repository.VenueRepository, searches repository.SavedSearchRepository, producer kafka.Producer, logger *slog.Logger) SavedSearchService {
+	return &savedSearchService{
+		venues:   venues,
+		searches: searches,
+		producer: producer,
+		logger:   logger.With("layer", "service"),
+	}
+}
+
+func (s *savedSearchService) Create(claims *models.Claims, search *models.SavedSearch) error {
+	if claims == nil {
+		return ErrForbidden
+	}
+	if err := search.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSavedSearch, err)
+	}
+	count, err := s.searches.CountByUserID(claims.UserID)
+	if err != nil {
+		return err
+	}
+	if count >= models.MaxSavedSearches {
+		return ErrTooManySavedSearches
+	}
+
+	search.ID = 0
+	search.UserID = claims.UserID
+	if err := s.searches.Create(search); err != nil {
+		return err
+	}
+	s.logger.Info("Поиск сохранён", "id", search.ID, "user_id", claims.UserID)
+	return nil
+}
+
+func (s *savedSearchService) GetList(claims *models.Claims) ([]models.SavedSearch, error) {
+	if claims == nil {
+		return nil, ErrForbidden
+	}
+	return s.searches.GetByUserID(claims.UserID)
+}
+
+// GetByID возвращает поиск пользователя. Чужой поиск не отличается от несуществующего
+func (s *savedSearchService) GetByID(id uint, claims *models.Claims) (*models.SavedSearch, error) {
+	if claims == nil {
+		return nil, ErrForbidden
+	}
+	search, err := s.searches.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSavedSearchNotFound
+		}
+		return nil, err
+	}
+	if search.UserID != claims.UserID {
+		return nil, ErrSavedSearchNotFound
+	}
+	return search, nil
+}
+
+// Update заменяет название, фильтр и удобное время поиска
+func (s *savedSearchService) Update(id uint, claims *models.Claims, search *models.SavedSearch) error {
+	existing, err := s.GetByID(id, claims)
+	if err != nil {
+		return err
+	}
+	if err := search.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSavedSearch, err)
+	}
+
+	existing.Name = search.Name
+	existing.Filter = search.Filter
+	existing.Time = search.Time
+	if err := s.searches.Update(existing); err != nil {
+		return err
+	}
+	*search = *existing
+	return nil
+}
+
+func (s *savedSearchService) Delete(id uint, claims *models.Claims) error {
+	if _, err := s.GetByID(id, claims); err != nil {
+		return err
+	}
+	return s.searches.Delete(id)
+}
+
+// VenuePublished сообщает о новой площадке тем, у кого она подходит под фильтр
+// и работает в удобное время. Ошибки только логируются: публикация уже состоялась.
+// venue загружена через GetByID вместе с удобствами, фильтры проверяются по ней без запросов к базе
+func (s *savedSearchService) VenuePublished(ctx context.Context, venue *models.Venue) {
+	if s.producer == nil {
+		return
+	}
+	err := s.searches.ForEach(savedSearchBatchSize, func(batch []models.SavedSearch) error {
+		for i := range batch {
+			search := &batch[i]
+			if !search.Time.MatchesSchedule(venue.Weekdays) {
+				continue
+			}
+			s.notify(ctx, search, venue, fmt.Sprintf("saved-search-%d-venue-%d-published", search.ID, venue.ID), events.SavedSearchMatchedV1{
+				Reason: events.MatchReasonVenuePublished,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Ошибка проверки сохранённых поисков для новой площадки", "venue_id", venue.ID, "error", err)
+	}
+}
+
+func (s *savedSearchService) SlotFreed(ctx context.Context, slot FreedSlot) error {
+	if s.producer == nil || !slot.StartAt.After(time.Now()) {
+		return nil
+	}
+	venue, err := s.venues.GetByID(slot.VenueID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if venue.Status != models.VenuePublished {
+		return nil
+	}
+
+	return s.searches.ForEach(savedSearchBatchSize, func(batch []models.SavedSearch) error {
+		for i := range batch {
+			search := &batch[i]
+			if search.UserID == slot.ClientID || !search.Time.MatchesSlot(slot.StartAt, slot.EndAt) {
+				continue
+			}
+			s.notify(ctx, search, venue, fmt.Sprintf("saved-search-%d-booking-%d", search.ID, slot.BookingID), events.SavedSearchMatchedV1{
+				Reason:  events.MatchReasonSlotFreed,
+				UnitID:  slot.UnitID,
+				StartAt: slot.StartAt,
+				EndAt:   slot.EndAt,
+			})
+		}
+		return nil
+	})
+}
+
+// notify проверяет площадку по фильтру поиска и отправляет событие.
+// event_id постоянный для пары поиск-причина: повторная обработка не приводит к повторному уведомлению
+func (s *savedSearchService) notify(ctx context.Context, search *models.SavedSearch, venue *models.Venue, eventID string, evt events.SavedSearchMatchedV1) {
+	if !search.Filter.Matches(venue) {
+		return
+	}
+
+	evt.SearchID = search.ID
+	evt.ClientID = search.UserID
+	evt.VenueID = venue.ID
+	meta := events.Meta{EventID: eventID, OccurredAt: time.Now()}
+	if err := s.producer.PublishSavedSearchMatched(ctx, meta, evt); err != nil {
+		s.logger.Error("Ошибка отправки saved_search.matched", "search_id", search.ID, "venue_id", venue.ID, "error", err)
+		return
+	}
+	s.logger.Info("Площадка подошла под сохранённый поиск", "search_id", search.ID, "venue_id", venue.ID, "reason", evt.Reason)
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"venue-service/internal/kafka"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"contracts/events"
+
+	"gorm.io/gorm"
+)
+
+// fakeSavedSearchRepository хранит сохранённые поиски в памяти
+type fakeSavedSearchRepository struct {
+	repository.SavedSearchRepository
+	searches map[uint]models.SavedSearch
+	nextID   uint
+}
+
+func newFakeSavedSearchRepository(searches ...models.SavedSearch) *fakeSavedSearchRepository {
+	r := &fakeSavedSearchRepository{searches: make(map[uint]models.SavedSearch)}
+	for _, s := range searches {
+		r.searches[s.ID] = s
+		r.nextID = max(r.nextID, s.ID)
+	}
+	return r
+}
+
+func (r *fakeSavedSearchRepository) Create(search *models.SavedSearch) error {
+	r.nextID++
+	search.ID = r.nextID
+	r.searches[search.ID] = *search
+	return nil
+}
+
+func (r *fakeSavedSearchRepository) GetByID(id uint) (*models.SavedSearch, error) {
+	s, ok := r.searches[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &s, nil
+}
+
+func (r *fakeSavedSearchRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	for _, s := range r.searches {
+		if s.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeSavedSearchRepository) Update(search *models.SavedSearch) error {
+	r.searches[search.ID] = *search
+	return nil
+}
+
+func (r *fakeSavedSearchRepository) Delete(id uint) error {
+	delete(r.searches, id)
+	return nil
+}
+
+func (r *fakeSavedSearchRepository) ForEach(batchSize int, fn func([]models.SavedSearch) error) error {
+	var batch []models.SavedSearch
+	for id := uint(1); id <= r.nextID; id++ {
+		if s, ok := r.searches[id]; ok {
+			batch = append(batch, s)
+		}
+	}
+	return fn(batch)
+}
+
+// fakeProducer запоминает отправленные события
+type fakeProducer struct {
+	kafka.Producer
+	metas   []events.Meta
+	matched []events.SavedSearchMatchedV1
+}
+
+func (p *fakeProducer) PublishSavedSearchMatched(ctx context.Context, meta events.Meta, evt events.SavedSearchMatchedV1) error {
+	p.metas = append(p.metas, meta)
+	p.matched = append(p.matched, evt)
+	return nil
+}
+
+func testSavedSearch(id, userID uint, district string, preference models.TimePreference) models.SavedSearch {
+	search := models.SavedSearch{
+		UserID: userID,
+		Name:   "Футбол вечером",
+		Filter: models.SavedSearchFilter{Districts: []string{district}},
+		Time:   preference,
+	}
+	search.ID = id
+	return search
+}
+
+func TestSavedSearchLimit(t *testing.T) {
+	searches := newFakeSavedSearchRepository()
+	service := NewSavedSearchService(newFakeVenueRepository(), searches, nil, testLogger())
+
+	for i := 0; i < models.MaxSavedSearches; i++ {
+		search := testSavedSearch(0, 0, "Центральный", models.TimePreference{})
+		if err := service.Create(clientClaims, &search); err != nil {
+			t.Fatal(err)
+		}
+		if search.UserID != clientID {
+			t.Fatalf("поиск сохранён для пользователя %d", search.UserID)
+		}
+	}
+
+	search := testSavedSearch(0, 0, "Центральный", models.TimePreference{})
+	if err := service.Create(clientClaims, &search); !errors.Is(err, ErrTooManySavedSearches) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrTooManySavedSearches)
+	}
+}
+
+func TestSavedSearchValidation(t *testing.T) {
+	service := NewSavedSearchService(newFakeVenueRepository(), newFakeSavedSearchRepository(), nil, testLogger())
+
+	tests := []struct {
+		name       string
+		preference models.TimePreference
+	}{
+		{name: "from позже to", preference: models.TimePreference{From: "20:00", To: "18:00"}},
+		{name: "неверное время", preference: models.TimePreference{From: "25:00"}},
+		{name: "неверный день", preference: models.TimePreference{Days: []time.Weekday{7}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search := testSavedSearch(0, 0, "Центральный", tt.preference)
+			if err := service.Create(clientClaims, &search); !errors.Is(err, ErrInvalidSavedSearch) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, ErrInvalidSavedSearch)
+			}
+		})
+	}
+}
+
+func TestSavedSearchOwnership(t *testing.T) {
+	searches := newFakeSavedSearchRepository(testSavedSearch(1, clientID, "Центральный", models.TimePreference{}))
+	service := NewSavedSearchService(newFakeVenueRepository(), searches, nil, testLogger())
+
+	if _, err := service.GetByID(1, otherOwnerClaims); !errors.Is(err, ErrSavedSearchNotFound) {
+		t.Fatalf("чужой поиск: ошибка %v, ожидалась %v", err, ErrSavedSearchNotFound)
+	}
+	if err := service.Delete(1, otherOwnerClaims); !errors.Is(err, ErrSavedSearchNotFound) {
+		t.Fatalf("удаление чужого поиска: ошибка %v, ожидалась %v", err, ErrSavedSearchNotFound)
+	}
+	if _, err := service.GetByID(1, nil); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("без авторизации: ошибка %v, ожидалась %v", err, ErrForbidden)
+	}
+
+	update := testSavedSearch(0, otherOwnerID, "Северный", models.TimePreference{})
+	if err := service.Update(1, clientClaims, &update); err != nil {
+		t.Fatal(err)
+	}
+	saved := searches.searches[1]
+	if saved.UserID != clientID || saved.Filter.Districts[0] != "Северный" {
+		t.Fatalf("после обновления user_id=%d, районы %v", saved.UserID, saved.Filter.Districts)
+	}
+}
+
+// listCountingVenueRepository считает запросы списка площадок
+type listCountingVenueRepository struct {
+	*fakeVenueRepository
+	lists int
+}
+
+func (r *listCountingVenueRepository) GetList(filter repository.VenueFilter) ([]models.Venue, int64, error) {
+	r.lists++
+	return r.fakeVenueRepository.GetList(filter)
+}
+
+func TestSavedSearchSlotFreed(t *testing.T) {
+	venue := venueWithStatus(models.VenuePublished)
+	evening := models.TimePreference{Days: []time.Weekday{time.Saturday}, From: "18:00", To: "22:00"}
+	searches := newFakeSavedSearchRepository(
+		testSavedSearch(1, clientID, "Центральный", evening),
+		testSavedSearch(2, clientID, "Северный", evening),
+		testSavedSearch(3, clientID, "Центральный", models.TimePreference{From: "08:00", To: "12:00"}),
+		testSavedSearch(4, otherOwnerID, "Центральный", models.TimePreference{}),
+	)
+	producer := &fakeProducer{}
+	venues := &listCountingVenueRepository{fakeVenueRepository: newFakeVenueRepository(venue)}
+	service := NewSavedSearchService(venues, searches, producer, testLogger())
+
+	// Суббота в ближайшие семь дней, 19:00-21:00
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 7).Add(19 * time.Hour)
+	for start.Weekday() != time.Saturday {
+		start = start.AddDate(0, 0, -1)
+	}
+	unitID := uint(3)
+	slot := FreedSlot{BookingID: 42, VenueID: 1, UnitID: &unitID, ClientID: otherOwnerID, StartAt: start, EndAt: start.Add(2 * time.Hour)}
+	if err := service.SlotFreed(context.Background(), slot); err != nil {
+		t.Fatal(err)
+	}
+
+	// Поиск 2 - другой район, 3 - другое время, 4 - поиск отменившего бронь
+	if len(producer.matched) != 1 {
+		t.Fatalf("отправлено %d событий, ожидалось 1: %+v", len(producer.matched), producer.matched)
+	}
+	evt := producer.matched[0]
+	if evt.SearchID != 1 || evt.ClientID != clientID || evt.VenueID != 1 || evt.Reason != events.MatchReasonSlotFreed {
+		t.Fatalf("событие %+v", evt)
+	}
+	if evt.UnitID == nil || *evt.UnitID != unitID || !evt.StartAt.Equal(slot.StartAt) || !evt.EndAt.Equal(slot.EndAt) {
+		t.Fatalf("слот в событии %+v", evt)
+	}
+	if got := producer.metas[0].EventID; got != "saved-search-1-booking-42" {
+		t.Fatalf("event_id = %s", got)
+	}
+	// Площадка проверяется по фильтрам в памяти, без запроса на каждый поиск
+	if venues.lists != 0 {
+		t.Fatalf("выполнено %d запросов списка площадок", venues.lists)
+	}
+}
+
+func TestSavedSearchSlotFreedSkips(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour)
+	tests := []struct {
+		name   string
+		status models.VenueStatus
+		start  time.Time
+	}{
+		{name: "прошедшее время", status: models.VenuePublished, start: time.Now().Add(-3 * time.Hour)},
+		{name: "площадка не опубликована", status: models.VenueSuspended, start: start},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searches := newFakeSavedSearchRepository(testSavedSearch(1, clientID, "Центральный", models.TimePreference{}))
+			producer := &fakeProducer{}
+			service := NewSavedSearchService(newFakeVenueRepository(venueWithStatus(tt.status)), searches, producer, testLogger())
+
+			slot := FreedSlot{BookingID: 42, VenueID: 1, ClientID: otherOwnerID, StartAt: tt.start, EndAt: tt.start.Add(time.Hour)}
+			if err := service.SlotFreed(context.Background(), slot); err != nil {
+				t.Fatal(err)
+			}
+			if len(producer.matched) != 0 {
+				t.Fatalf("отправлено %d событий", len(producer.matched))
+			}
+		})
+	}
+}
+
+func TestSavedSearchVenuePublished(t *testing.T) {
+	venue := venueWithStatus(models.VenuePublished)
+	searches := newFakeSavedSearchRepository(
+		testSavedSearch(1, clientID, "Центральный", models.TimePreference{From: "18:00", To: "22:00"}),
+		testSavedSearch(2, clientID, "Центральный", models.TimePreference{From: "22:00"}), // Площадка работает до 21:00
+		testSavedSearch(3, clientID, "Северный", models.TimePreference{}),
+	)
+	producer := &fakeProducer{}
+	service := NewSavedSearchService(newFakeVenueRepository(venue), searches, producer, testLogger())
+
+	service.VenuePublished(context.Background(), &venue)
+
+	if len(producer.matched) != 1 {
+		t.Fatalf("отправлено %d событий, ожидалось 1: %+v", len(producer.matched), producer.matched)
+	}
+	if evt := producer.matched[0]; evt.SearchID != 1 || evt.Reason != events.MatchReasonVenuePublished {
+		t.Fatalf("событие %+v", evt)
+	}
+	if got := producer.metas[0].EventID; got != "saved-search-1-venue-1-published" {
+		t.Fatalf("event_id = %s", got)
+	}
+}
+
+func TestSavedSearchFilterMatches(t *testing.T) {
+	venue := testVenue()
+	lat, lng := 55.75, 37.62
+	venue.Latitude, venue.Longitude = &lat, &lng
+	venue.HourPrice = 2000
+	venue.Rating = 4.5
+	venue.Amenities = []models.VenueAmenity{{Code: "parking"}, {Code: "shower"}}
+
+	tests := []struct {
+		name   string
+		filter models.SavedSearchFilter
+		want   bool
+	}{
+		{name: "пустой фильтр", filter: models.SavedSearchFilter{}, want: true},
+		{name: "район и тип", filter: models.SavedSearchFilter{Districts: []string{"Северный", "Центральный"}, VenueTypes: []models.VenueType{venue.VenueType}}, want: true},
+		{name: "другой тип", filter: models.SavedSearchFilter{VenueTypes: []models.VenueType{models.VenueSwimming}}, want: false},
+		{name: "цена в диапазоне", filter: models.SavedSearchFilter{MinPrice: 2000, MaxPrice: 2000}, want: true},
+		{name: "дороже максимума", filter: models.SavedSearchFilter{MaxPrice: 1500}, want: false},
+		{name: "рейтинг ниже", filter: models.SavedSearchFilter{MinRating: 4.8}, want: false},
+		{name: "все удобства", filter: models.SavedSearchFilter{Amenities: []string{"parking", "shower"}}, want: true},
+		{name: "не все удобства", filter: models.SavedSearchFilter{Amenities: []string{"parking", "sauna"}}, want: false},
+		{name: "любое из удобств", filter: models.SavedSearchFilter{Amenities: []string{"parking", "sauna"}, AmenitiesMatch: "any"}, want: true},
+		{name: "в радиусе", filter: models.SavedSearchFilter{Geo: &models.GeoPoint{Lat: 55.76, Lng: 37.62, RadiusKm: 2}}, want: true},
+		{name: "вне радиуса", filter: models.SavedSearchFilter{Geo: &models.GeoPoint{Lat: 59.93, Lng: 30.31, RadiusKm: 50}}, want: false},
+		{name: "в области", filter: models.SavedSearchFilter{BBox: &models.BoundingBox{MinLat: 55, MinLng: 37, MaxLat: 56, MaxLng: 38}}, want: true},
+		{name: "область через 180-й меридиан", filter: models.SavedSearchFilter{BBox: &models.BoundingBox{MinLat: 50, MinLng: 170, MaxLat: 60, MaxLng: -170}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(&venue); got != tt.want {
+				t.Fatalf("Matches = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+
+	// Площадка без координат не подходит под гео-фильтр
+	venue.Latitude, venue.Longitude = nil, nil
+	if (models.SavedSearchFilter{Geo: &models.GeoPoint{Lat: 55.75, Lng: 37.62}}).Matches(&venue) {
+		t.Fatal("площадка без координат подошла под гео-фильтр")
+	}
+}
+
+func TestTimePreferenceMatchesSlot(t *testing.T) {
+	// 2026-01-24 - суббота
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name       string
+		preference models.TimePreference
+		start, end time.Time
+		want       bool
+	}{
+		{name: "без ограничений", preference: models.TimePreference{}, start: at(24, 10, 0), end: at(24, 11, 0), want: true},
+		{name: "внутри окна", preference: models.TimePreference{From: "18:00", To: "22:00"}, start: at(24, 19, 0), end: at(24, 20, 0), want: true},
+		{name: "частичное пересечение", preference: models.TimePreference{From: "18:00", To: "22:00"}, start: at(24, 17, 0), end: at(24, 19, 0), want: true},
+		{name: "заканчивается к началу окна", preference: models.TimePreference{From: "18:00", To: "22:00"}, start: at(24, 16, 0), end: at(24, 18, 0), want: false},
+		{name: "начинается с концом окна", preference: models.TimePreference{From: "18:00", To: "22:00"}, start: at(24, 22, 0), end: at(24, 23, 0), want: false},
+		{name: "до полуночи", preference: models.TimePreference{From: "23:00"}, start: at(24, 22, 0), end: at(25, 0, 0), want: true},
+		{name: "подходящий день", preference: models.TimePreference{Days: []time.Weekday{time.Saturday, time.Sunday}}, start: at(24, 10, 0), end: at(24, 11, 0), want: true},
+		{name: "другой день", preference: models.TimePreference{Days: []time.Weekday{time.Monday}}, start: at(24, 10, 0), end: at(24, 11, 0), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.preference.MatchesSlot(tt.start, tt.end); got != tt.want {
+				t.Fatalf("MatchesSlot = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnitNotFound      = errors.New("venue unit not found")
+	ErrUnitParentInvalid = errors.New("родительская единица должна принадлежать той же площадке и не может сама быть частью другой единицы")
+	ErrUnitHasChildren   = errors.New("единица площадки состоит из частей: сначала удалите или перенесите их")
+)
+
+type VenueUnitService interface {
+	GetByVenueID(venueID uint) ([]models.VenueUnit, error)
+	Create(venueID uint, claims *models.Claims, unit *models.VenueUnit) error
+	Update(venueID, id uint, claims *models.Claims, unit *models.VenueUnit) error
+	Delete(venueID, id uint, claims *models.Claims) error
+}
+
+type venueUnitService struct {
+	venueRepository repository.VenueRepository
+	unitRepository  repository.VenueUnitRepository
+	logger          *slog.Logger
+}
+
+func NewVenueUnitService(venueRepository repository.VenueRepository, unitRepository repository.VenueUnitRepository, logger *slog.Logger) VenueUnitService {
+	return &venueUnitService{
+		venueRepository: venueRepository,
+		unitRepository:  unitRepository,
+		logger:          logger.With("layer", "service"),
+	}
+}
+
+func (s *venueUnitService) GetByVenueID(venueID uint) ([]models.VenueUnit, error) {
+	if err := s.ensureVenue(venueID); err != nil {
+		return nil, err
+	}
+
+	units, err := s.unitRepository.GetByVenueID(venueID)
+	if err != nil {
+		s.logger.Error("Ошибка получения единиц площадки", "venue_id", venueID, "error", err)
+		return nil, err
+	}
+	return units, nil
+}
+
+func (s *venueUnitService) Create(venueID uint, claims *models.Claims, unit *models.VenueUnit) error {
+	if err := s.ensureManagedVenue(venueID, claims); err != nil {
+		return err
+	}
+	if err := s.checkParent(venueID, 0, unit.ParentID); err != nil {
+		return err
+	}
+
+	unit.VenueID = venueID
+	if err := s.unitRepository.Create(unit); err != nil {
+		s.logger.Error("Ошибка создания единицы площадки", "venue_id", venueID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *venueUnitService) Update(venueID, id uint, claims *models.Claims, unit *models.VenueUnit) error {
+	if err := s.ensureManagedVenue(venueID, claims); err != nil {
+		return err
+	}
+	existingUnit, err := s.unitRepository.GetByID(venueID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUnitNotFound
+		}
+		return err
+	}
+
+	if unit.ParentID != nil {
+		// Единица, у которой есть части, сама не может стать частью другой
+		children, err := s.unitRepository.CountChildren(id)
+		if err != nil {
+			return err
+		}
+		if children > 0 {
+			return ErrUnitParentInvalid
+		}
+	}
+	if err := s.checkParent(venueID, id, unit.ParentID); err != nil {
+		return err
+	}
+
+	existingUnit.Name = unit.Name
+	existingUnit.ParentID = unit.ParentID
+	existingUnit.HourPrice = unit.HourPrice
+	existingUnit.IsActive = unit.IsActive
+
+	if err := s.unitRepository.Update(existingUnit); err != nil {
+		s.logger.Error("Ошибка обновления единицы площадки", "venue_id", venueID, "id", id, "error", err)
+		return err
+	}
+	*unit = *existingUnit
+	return nil
+}
+
+func (s *venueUnitService) Delete(venueID, id uint, claims *models.Claims) error {
+	if err := s.ensureManagedVenue(venueID, claims); err != nil {
+		return err
+	}
+	if _, err := s.unitRepository.GetByID(venueID, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUnitNotFound
+		}
+		return err
+	}
+
+	children, err := s.unitRepository.CountChildren(id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrUnitHasChildren
+	}
+
+	if err := s.unitRepository.Delete(venueID, id); err != nil {
+		s.logger.Error("Ошибка удаления единицы площадки", "venue_id", venueID, "id", id, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ensureVenue проверяет существование площадки
+func (s *venueUnitService) ensureVenue(venueID uint) error {
+	if _, err := s.venueRepository.GetByID(venueID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVenueNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ensureManagedVenue проверяет существование площадки и права пользователя на неё
+func (s *venueUnitService) ensureManagedVenue(venueID uint, claims *models.Claims) error {
+	venue, err := s.venueRepository.GetByID(venueID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVenueNotFound
+		}
+		return err
+	}
+	if !canManageVenue(claims, venue) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// checkParent проверяет, что родительская единица существует в той же площадке
+// и сама не является частью другой единицы (поддерживается один уровень вложенности)
+func (s *venueUnitService) checkParent(venueID, unitID uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == unitID {
+		return ErrUnitParentInvalid
+	}
+
+	parent, err := s.unitRepository.GetByID(venueID, *parentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUnitParentInvalid
+		}
+		return err
+	}
+	if parent.ParentID != nil {
+		return ErrUnitParentInvalid
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"venue-service/internal/models"
+)
+
+func TestVenueHistoryRecordsPriceAndScheduleChanges(t *testing.T) {
+	repo := newFakeVenueRepository()
+	service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	venue := testVenue()
+	venue.ID = 0
+	if err := service.Create(ownerClaims, &venue); err != nil {
+		t.Fatal(err)
+	}
+	id := venue.ID
+
+	// Изменение без цены и расписания новую версию не создаёт
+	update := testVenue()
+	update.District = "Северный"
+	if err := service.Update(id, ownerClaims, &update, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	update = testVenue()
+	update.HourPrice = 3500
+	if err := service.Update(id, adminClaims, &update, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	weekdays := testVenue().Weekdays
+	weekdays.Sunday = models.DaySchedule{Enabled: false}
+	if err := service.UpdateSchedule(id, ownerClaims, weekdays, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Тот же интервал в другой записи расписанием не считается изменением
+	same := weekdays
+	same.Monday.Intervals = models.TimeIntervals{{Start: "09:00", End: "21:00"}}
+	if err := service.UpdateSchedule(id, ownerClaims, same, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := repo.venues[id].Revision; got != 3 {
+		t.Fatalf("revision = %d, ожидалась 3", got)
+	}
+
+	revisions, total, err := service.GetHistory(id, ownerClaims, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		revision  int
+		hourPrice int
+		changes   []string
+		changedBy uint
+	}{
+		{3, 3500, []string{models.RevisionChangeSchedule}, venueOwnerID},
+		{2, 3500, []string{models.RevisionChangeHourPrice}, adminID},
+		{1, 3000, nil, venueOwnerID},
+	}
+	if total != int64(len(want)) {
+		t.Fatalf("версий %d, ожидалось %d", total, len(want))
+	}
+	for i, w := range want {
+		got := revisions[i]
+		if got.Revision != w.revision || got.HourPrice != w.hourPrice || !slices.Equal(got.Changes, w.changes) {
+			t.Fatalf("версия %d: %+v", w.revision, got)
+		}
+		if got.ChangedBy == nil || *got.ChangedBy != w.changedBy {
+			t.Fatalf("версия %d: changed_by = %v, ожидался %d", w.revision, got.ChangedBy, w.changedBy)
+		}
+	}
+
+	// Клиент видит историю, но не авторов изменений
+	revisions, _, err = service.GetHistory(id, clientClaims, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range revisions {
+		if r.ChangedBy != nil {
+			t.Fatalf("клиенту возвращён автор версии %d", r.Revision)
+		}
+	}
+}
+
+func TestVenueHistoryBackfillsInitialState(t *testing.T) {
+	// Площадка создана до появления истории
+	existing := testVenue()
+	existing.UpdatedAt = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo := newFakeVenueRepository(existing)
+	service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	update := testVenue()
+	update.HourPrice = 5000
+	if err := service.Update(1, ownerClaims, &update, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, _, err := service.GetHistory(1, ownerClaims, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("версий %d, ожидалось 2", len(revisions))
+	}
+	initial := revisions[1]
+	if initial.Revision != 1 || initial.HourPrice != 3000 || initial.ChangedBy != nil || !initial.CreatedAt.Equal(existing.UpdatedAt) {
+		t.Fatalf("исходная версия: %+v", initial)
+	}
+}
+
+func TestVenueHistoryConflict(t *testing.T) {
+	repo := newFakeVenueRepository(testVenue())
+	service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	// Пока запрос читал площадку, цену успел изменить другой запрос
+	stale := repo.venues[1]
+	stale.Revision = 2
+	repo.venues[1] = stale
+
+	venue := testVenue()
+	before := venue
+	venue.HourPrice = 4000
+	if err := service.(*venueService).save(&before, &venue, ownerClaims); !errors.Is(err, ErrRevisionConflict) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrRevisionConflict)
+	}
+	if venue.Revision != 1 {
+		t.Fatalf("revision = %d, ожидалась 1", venue.Revision)
+	}
+}
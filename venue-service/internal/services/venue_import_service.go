@@ -0,0 +1,282 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// MaxImportRows - сколько площадок можно импортировать за один запрос
+const MaxImportRows = 1000
+
+var (
+	ErrImportEmpty    = errors.New("файл импорта не содержит площадок")
+	ErrImportTooLarge = fmt.Errorf("в файле импорта больше %d площадок", MaxImportRows)
+)
+
+// Что импорт делает со строкой
+const (
+	ImportCreate = "create"
+	ImportUpdate = "update"
+)
+
+// Необязательные поля файла импорта. Если поля нет в файле (колонки в заголовке CSV
+// или ключа в объекте JSON), при изменении площадки сохраняется его текущее значение
+const (
+	ImportFieldCapacity  = "capacity"
+	ImportFieldIsActive  = "is_active"
+	ImportFieldAddress   = "address"
+	ImportFieldLatitude  = "latitude"
+	ImportFieldLongitude = "longitude"
+	ImportFieldAmenities = "amenities"
+)
+
+// ImportRow - площадка из строки файла импорта. Venue.ID != 0 - изменение существующей площадки
+type ImportRow struct {
+	Line    int             // Номер строки в файле
+	Venue   *models.Venue   // nil, если строку не удалось разобрать
+	Err     error           // Ошибка разбора строки
+	Missing map[string]bool // Необязательные поля (ImportField*), которых нет в файле
+}
+
+// ImportRowResult - результат проверки строки
+type ImportRowResult struct {
+	Line   int
+	ID     uint // Изменяемая площадка; у новых заполняется после сохранения
+	Action string
+	Error  string // Пусто, если строка корректна
+}
+
+// ImportResult - результат импорта. Изменения сохраняются, только если ни в одной строке нет ошибок
+type ImportResult struct {
+	DryRun  bool
+	Applied bool
+	Created int
+	Updated int
+	Errors  int
+	Rows    []ImportRowResult
+}
+
+type VenueImportService interface {
+	Import(claims *models.Claims, rows []ImportRow, dryRun bool) (*ImportResult, error)
+	Export(claims *models.Claims, ownerID uint) ([]models.Venue, error)
+}
+
+type venueImportService struct {
+	repository repository.VenueRepository
+	types      repository.VenueTypeRepository
+	moderation ModerationPolicy
+	logger     *slog.Logger
+}
+
+func NewVenueImportService(repository repository.VenueRepository, types repository.VenueTypeRepository, moderation ModerationPolicy, logger *slog.Logger) VenueImportService {
+	return &venueImportService{
+		repository: repository,
+		types:      types,
+		moderation: moderation,
+		logger:     logger.With("layer", "service"),
+	}
+}
+
+// Import проверяет все строки по тем же правилам, что создание и изменение площадки.
+// Новые площадки создаются черновиками, строки с id изменяют площадки целиком, кроме правил бронирования.
+// Без dryRun и без ошибок изменения сохраняются одной транзакцией
+func (s *venueImportService) Import(claims *models.Claims, rows []ImportRow, dryRun bool) (*ImportResult, error) {
+	if claims == nil || (claims.Role != models.RoleOwner && claims.Role != models.RoleAdmin) {
+		return nil, ErrForbidden
+	}
+	if len(rows) == 0 {
+		return nil, ErrImportEmpty
+	}
+	if len(rows) > MaxImportRows {
+		return nil, ErrImportTooLarge
+	}
+
+	result := &ImportResult{DryRun: dryRun, Rows: make([]ImportRowResult, 0, len(rows))}
+	batch := &repository.VenueImport{}
+	var createdRows []int      // Строки результата новых площадок, по порядку batch.Create
+	seen := make(map[uint]int) // Площадка - строка, которая её уже изменяет
+
+	for _, row := range rows {
+		rowResult := ImportRowResult{Line: row.Line, Action: ImportCreate}
+		err := row.Err
+		switch {
+		case err != nil:
+			err = fmt.Errorf("%w: %v", ErrInvalidVenue, err)
+		case row.Venue.ID != 0:
+			rowResult.Action, rowResult.ID = ImportUpdate, row.Venue.ID
+			if line, ok := seen[row.Venue.ID]; ok {
+				err = fmt.Errorf("%w: площадка %d уже изменяется в строке %d", ErrInvalidVenue, row.Venue.ID, line)
+				break
+			}
+			seen[row.Venue.ID] = row.Line
+
+			var update *repository.VenueImportUpdate
+			if update, err = s.prepareUpdate(claims, row.Venue, row.Missing); err == nil {
+				batch.Update = append(batch.Update, *update)
+			}
+		default:
+			// Как в BeforeCreate: без вместимости площадка бронируется целиком
+			if row.Venue.Capacity == 0 {
+				row.Venue.Capacity = 1
+			}
+			if err = prepareNewVenue(s.types, claims, row.Venue); err == nil {
+				err = validateVenue(row.Venue)
+			}
+			if err == nil {
+				createdRows = append(createdRows, len(result.Rows))
+				batch.Create = append(batch.Create, row.Venue)
+			}
+		}
+
+		if err != nil {
+			if !isImportRowError(err) {
+				s.logger.Error("Ошибка проверки строки импорта", "line", row.Line, "error", err)
+				return nil, err
+			}
+			rowResult.Error = err.Error()
+			result.Errors++
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	result.Created, result.Updated = len(batch.Create), len(batch.Update)
+	if result.Errors > 0 || dryRun {
+		return result, nil
+	}
+
+	if err := s.repository.Import(batch); err != nil {
+		if errors.Is(err, repository.ErrRevisionChanged) {
+			return nil, ErrRevisionConflict
+		}
+		if errors.Is(err, repository.ErrVersionChanged) {
+			return nil, ErrVenueConflict
+		}
+		return nil, err
+	}
+	for i, rowIndex := range createdRows {
+		result.Rows[rowIndex].ID = batch.Create[i].ID
+	}
+	result.Applied = true
+
+	s.logger.Info("Площадки импортированы", "user_id", claims.UserID, "created", result.Created, "updated", result.Updated)
+	return result, nil
+}
+
+// prepareUpdate применяет строку к существующей площадке так же, как PUT /venues/:id.
+// Правил бронирования в файле нет, они остаются прежними, как и поля из missing
+func (s *venueImportService) prepareUpdate(claims *models.Claims, venue *models.Venue, missing map[string]bool) (*repository.VenueImportUpdate, error) {
+	existing, err := s.repository.GetByID(venue.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrVenueNotFound, venue.ID)
+		}
+		return nil, err
+	}
+	if !canManageVenue(claims, existing) {
+		return nil, ErrForbidden
+	}
+	keepMissingFields(existing, venue, missing)
+	ownerID, err := resolveOwner(claims, venue.OwnerID, existing.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	venueType, err := resolveVenueType(s.types, venue, existing.VenueType)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(venue.Amenities))
+	for _, a := range venue.Amenities {
+		codes = append(codes, a.Code)
+	}
+	codes, err = models.ValidateAmenities(venueType, codes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAmenities, err)
+	}
+
+	reapprove := s.moderation.needsReapproval(claims, existing, venue)
+	before := *existing
+	venue.BookingRules = existing.BookingRules
+	mergeVenue(existing, venue, ownerID, venueType)
+	if err := validateVenue(existing); err != nil {
+		return nil, err
+	}
+
+	update := &repository.VenueImportUpdate{Venue: existing, Amenities: codes}
+	update.Previous, update.Revision = nextRevision(&before, existing, claims)
+	if reapprove {
+		now := time.Now()
+		existing.Status = models.VenueSubmitted
+		existing.SubmittedAt = &now
+		existing.ModerationComment = ""
+		update.ResubmitFrom = before.Status
+	}
+	return update, nil
+}
+
+// keepMissingFields переносит в строку импорта текущие значения полей, которых нет в файле,
+// чтобы файл с частью колонок не стирал координаты, удобства и остальные необязательные поля
+func keepMissingFields(existing, venue *models.Venue, missing map[string]bool) {
+	if missing[ImportFieldCapacity] {
+		venue.Capacity = existing.Capacity
+	}
+	if missing[ImportFieldIsActive] {
+		venue.IsActive = existing.IsActive
+	}
+	if missing[ImportFieldAddress] {
+		venue.Address = existing.Address
+	}
+	if missing[ImportFieldLatitude] {
+		venue.Latitude = existing.Latitude
+	}
+	if missing[ImportFieldLongitude] {
+		venue.Longitude = existing.Longitude
+	}
+	if missing[ImportFieldAmenities] {
+		venue.Amenities = existing.Amenities
+	}
+}
+
+// Export возвращает площадки для выгрузки: владелец выгружает свои,
+// администратор - площадки указанного владельца или все (ownerID = 0)
+func (s *venueImportService) Export(claims *models.Claims, ownerID uint) ([]models.Venue, error) {
+	if claims == nil || (claims.Role != models.RoleOwner && claims.Role != models.RoleAdmin) {
+		return nil, ErrForbidden
+	}
+	if claims.Role != models.RoleAdmin {
+		if ownerID != 0 && ownerID != claims.UserID {
+			return nil, ErrForbidden
+		}
+		ownerID = claims.UserID
+	}
+
+	venues, _, err := s.repository.GetList(repository.VenueFilter{OwnerID: ownerID, Sort: repository.SortNewest})
+	if err != nil {
+		s.logger.Error("Ошибка выгрузки площадок", "owner_id", ownerID, "error", err)
+		return nil, err
+	}
+	return venues, nil
+}
+
+// validateVenue проверяет площадку по правилам модели, не дожидаясь сохранения
+func validateVenue(venue *models.Venue) error {
+	if err := venue.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidVenue, err)
+	}
+	return nil
+}
+
+// isImportRowError отличает ошибки данных строки от ошибок базы, которые прерывают импорт
+func isImportRowError(err error) bool {
+	return errors.Is(err, ErrInvalidVenue) ||
+		errors.Is(err, ErrForbidden) ||
+		errors.Is(err, ErrVenueNotFound) ||
+		errors.Is(err, ErrInvalidVenueType) ||
+		errors.Is(err, ErrInvalidAmenities)
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"venue-service/internal/models"
+)
+
+// importRows собирает строки импорта с номерами строк файла начиная со 2 (после заголовка)
+func importRows(venues ...models.Venue) []ImportRow {
+	rows := make([]ImportRow, 0, len(venues))
+	for i := range venues {
+		rows = append(rows, ImportRow{Line: i + 2, Venue: &venues[i]})
+	}
+	return rows
+}
+
+func newVenueForImport() models.Venue {
+	venue := testVenue()
+	venue.ID = 0
+	venue.OwnerID = 0
+	return venue
+}
+
+func TestVenueImportDryRun(t *testing.T) {
+	repo := newFakeVenueRepository(testVenue())
+	service := NewVenueImportService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	update := testVenue()
+	update.HourPrice = 4500
+	result, err := service.Import(ownerClaims, importRows(newVenueForImport(), update), true)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Applied || result.Created != 1 || result.Updated != 1 || result.Errors != 0 {
+		t.Fatalf("неверный результат проверки: %+v", result)
+	}
+	if len(repo.venues) != 1 || repo.venues[1].HourPrice != 3000 {
+		t.Fatalf("проверка не должна ничего сохранять")
+	}
+}
+
+func TestVenueImportApply(t *testing.T) {
+	repo := newFakeVenueRepository(testVenue())
+	service := NewVenueImportService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	update := testVenue()
+	update.HourPrice = 4500
+	update.Amenities = []models.VenueAmenity{{Code: "lighting"}}
+	result, err := service.Import(ownerClaims, importRows(newVenueForImport(), update), false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !result.Applied || result.Created != 1 || result.Updated != 1 {
+		t.Fatalf("неверный результат импорта: %+v", result)
+	}
+
+	created := repo.venues[result.Rows[0].ID]
+	if created.OwnerID != venueOwnerID || created.Status != models.VenueDraft || created.Revision != 1 {
+		t.Fatalf("новая площадка: owner_id = %d, статус %s, ревизия %d", created.OwnerID, created.Status, created.Revision)
+	}
+	updated := repo.venues[1]
+	if updated.HourPrice != 4500 || updated.Revision != 2 || len(updated.Amenities) != 1 {
+		t.Fatalf("изменённая площадка: %+v", updated)
+	}
+	if revisions, _, _ := repo.GetRevisions(1, 1, 10); len(revisions) != 2 {
+		t.Fatalf("ожидалось 2 ревизии, получено %d", len(revisions))
+	}
+}
+
+func TestVenueImportKeepsMissingFields(t *testing.T) {
+	existing := testVenue()
+	latitude, longitude := 55.75, 37.61
+	existing.Latitude, existing.Longitude = &latitude, &longitude
+	existing.Address = "ул. Ленина, 1"
+	existing.IsActive = false
+	existing.Amenities = []models.VenueAmenity{{VenueID: 1, Code: "lighting"}}
+	repo := newFakeVenueRepository(existing)
+	service := NewVenueImportService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	// Файл только с обязательными колонками: остальные поля в строке пустые
+	update := testVenue()
+	update.HourPrice = 4500
+	update.IsActive = true
+	rows := importRows(update)
+	rows[0].Missing = map[string]bool{
+		ImportFieldCapacity: true, ImportFieldIsActive: true, ImportFieldAddress: true,
+		ImportFieldLatitude: true, ImportFieldLongitude: true, ImportFieldAmenities: true,
+	}
+	result, err := service.Import(ownerClaims, rows, false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !result.Applied || result.Errors != 0 {
+		t.Fatalf("неверный результат импорта: %+v", result)
+	}
+
+	updated := repo.venues[1]
+	if updated.HourPrice != 4500 {
+		t.Fatalf("hour_price = %d, ожидалось 4500", updated.HourPrice)
+	}
+	if updated.Latitude == nil || *updated.Latitude != latitude || updated.Longitude == nil || *updated.Longitude != longitude {
+		t.Fatalf("координаты стёрты: %v, %v", updated.Latitude, updated.Longitude)
+	}
+	if updated.Address != existing.Address || updated.IsActive {
+		t.Fatalf("address/is_active: %q/%v", updated.Address, updated.IsActive)
+	}
+	if len(updated.Amenities) != 1 || updated.Amenities[0].Code != "lighting" {
+		t.Fatalf("удобства стёрты: %+v", updated.Amenities)
+	}
+}
+
+func TestVenueImportRowErrors(t *testing.T) {
+	invalid := newVenueForImport()
+	latitude := 55.75
+	invalid.Latitude = &latitude
+	missing := testVenue()
+	missing.ID = 42
+
+	tests := []struct {
+		name    string
+		claims  *models.Claims
+		rows    []ImportRow
+		wantErr error
+	}{
+		{name: "ошибка разбора", claims: ownerClaims, rows: []ImportRow{{Line: 2, Err: errors.New("hour_price: ожидается целое число")}}, wantErr: ErrInvalidVenue},
+		{name: "неверные данные", claims: ownerClaims, rows: importRows(invalid), wantErr: ErrInvalidVenue},
+		{name: "нет площадки", claims: ownerClaims, rows: importRows(missing), wantErr: ErrVenueNotFound},
+		{name: "чужая площадка", claims: otherOwnerClaims, rows: importRows(testVenue()), wantErr: ErrForbidden},
+		{name: "площадка дважды", claims: ownerClaims, rows: importRows(testVenue(), testVenue()), wantErr: ErrInvalidVenue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeVenueRepository(testVenue())
+			service := NewVenueImportService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+			rows := append([]ImportRow{{Line: 1, Venue: ptrVenue(newVenueForImport())}}, tt.rows...)
+			result, err := service.Import(tt.claims, rows, false)
+			if err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			if result.Applied || result.Errors != 1 {
+				t.Fatalf("ожидалась одна ошибка без сохранения: %+v", result)
+			}
+			if len(repo.venues) != 1 {
+				t.Fatalf("при ошибках ни одна площадка не должна сохраниться")
+			}
+			last := result.Rows[len(result.Rows)-1]
+			if !strings.Contains(last.Error, tt.wantErr.Error()) || result.Rows[0].Error != "" {
+				t.Fatalf("ошибка приписана не той строке: %+v", result.Rows)
+			}
+		})
+	}
+}
+
+func TestVenueImportAccess(t *testing.T) {
+	service := NewVenueImportService(newFakeVenueRepository(testVenue()), newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	if _, err := service.Import(clientClaims, importRows(newVenueForImport()), true); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("импорт клиентом: ошибка %v", err)
+	}
+	if _, err := service.Import(ownerClaims, nil, true); !errors.Is(err, ErrImportEmpty) {
+		t.Fatalf("пустой импорт: ошибка %v", err)
+	}
+	if _, err := service.Export(ownerClaims, otherOwnerID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("выгрузка чужих площадок: ошибка %v", err)
+	}
+
+	venues, err := service.Export(otherOwnerClaims, 0)
+	if err != nil || len(venues) != 0 {
+		t.Fatalf("владелец должен выгружать только свои площадки: %d, %v", len(venues), err)
+	}
+	venues, err = service.Export(adminClaims, venueOwnerID)
+	if err != nil || len(venues) != 1 {
+		t.Fatalf("администратор выгружает площадки владельца: %d, %v", len(venues), err)
+	}
+}
+
+func ptrVenue(v models.Venue) *models.Venue {
+	return &v
+}
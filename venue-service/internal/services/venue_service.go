@@ -2,7 +2,9 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 	"venue-service/internal/models"
 	"venue-service/internal/repository"
 
@@ -10,40 +12,83 @@ import (
 )
 
 var (
-	ErrVenueNotFound = errors.New("venue not found")
+	ErrVenueNotFound    = errors.New("venue not found")
+	ErrInvalidAmenities = errors.New("invalid amenities")
+	ErrInvalidCursor    = repository.ErrInvalidCursor
+	ErrRevisionNotFound = errors.New("venue revision not found")
+	ErrRevisionConflict = errors.New("цену или расписание площадки одновременно изменил другой запрос, повторите изменение")
+	ErrVenueConflict    = errors.New("площадку одновременно изменил другой запрос, повторите изменение")
+	// ErrVersionMismatch - площадка изменилась после того, как клиент получил версию из If-Match
+	ErrVersionMismatch = errors.New("площадка изменилась, получите актуальную версию и повторите изменение")
+	// ErrInvalidVenue - данные площадки не прошли проверку
+	ErrInvalidVenue = errors.New("неверные данные площадки")
 )
 
+// VenuePatch строит изменённую площадку из текущей. Текущую площадку изменять нельзя
+type VenuePatch func(current *models.Venue) (*models.Venue, error)
+
 type VenueFilter struct {
-	District  string
-	VenueType models.VenueType
-	HourPrice int
-	IsActive  *bool
-	OwnerID   uint
-	Page      int
-	Limit     int
+	Districts  []string
+	VenueTypes []models.VenueType
+	HourPrice  int
+	IsActive   *bool
+	OwnerID    uint
+	Page       int
+	Limit      int
+
+	MinHourPrice int
+	MaxHourPrice int
+	MinRating    float64
+	Sort         string // newest, price, rating или distance
+
+	Geo  *models.GeoPoint    // Поиск ближайших площадок вокруг точки
+	BBox *models.BoundingBox // Площадки в области карты
+
+	Amenities      []string
+	AmenitiesMatch string // all (по умолчанию) или any
+
+	Cursor string // next_cursor предыдущей страницы, выдан для той же сортировки
+}
+
+// VenueList страница списка площадок
+type VenueList struct {
+	Venues     []models.Venue
+	Total      int64
+	NextCursor string // Пусто, если страница последняя
 }
 
 // ScheduleUpdate удален - теперь используется models.Weekdays напрямую
 
 type VenueService interface {
 	GetByID(id uint) (*models.Venue, error)
-	GetList(filter VenueFilter) ([]models.Venue, error)
-	GetByOwnerID(ownerID uint) ([]models.Venue, error)
-	Create(venue *models.Venue) error
-	Update(id uint, venue *models.Venue) error
-	Delete(id uint) error
+	GetVisible(id uint, claims *models.Claims) (*models.Venue, error)
+	GetList(filter VenueFilter) (*VenueList, error)
+	GetByOwnerID(ownerID uint, claims *models.Claims) ([]models.Venue, error)
+	Create(claims *models.Claims, venue *models.Venue) error
+	// version - ожидаемая версия площадки из If-Match, 0 - без проверки
+	Update(id uint, claims *models.Claims, venue *models.Venue, version int) error
+	Patch(id uint, claims *models.Claims, patch VenuePatch, version int) error
+	Delete(id uint, claims *models.Claims) error
 	GetSchedule(id uint) (*models.Venue, error)
-	UpdateSchedule(id uint, weekdays models.Weekdays) error
+	UpdateSchedule(id uint, claims *models.Claims, weekdays models.Weekdays, version int) error
+	UpdateBookingRules(id uint, claims *models.Claims, rules models.BookingRules) error
+	UpdateAmenities(id uint, claims *models.Claims, codes []string) ([]string, error)
+	GetHistory(id uint, claims *models.Claims, page, limit int) ([]models.VenueRevision, int64, error)
+	GetRevision(id uint, revision int) (*models.VenueRevision, error)
 }
 
 type venueService struct {
 	repository repository.VenueRepository
+	types      repository.VenueTypeRepository
+	moderation ModerationPolicy
 	logger     *slog.Logger
 }
 
-func NewVenueService(repository repository.VenueRepository, logger *slog.Logger) VenueService {
+func NewVenueService(repository repository.VenueRepository, types repository.VenueTypeRepository, moderation ModerationPolicy, logger *slog.Logger) VenueService {
 	return &venueService{
 		repository: repository,
+		types:      types,
+		moderation: moderation,
 		logger:     logger.With("layer", "service"),
 	}
 }
@@ -61,7 +106,26 @@ func (s *venueService) GetByID(id uint) (*models.Venue, error) {
 	return venue, nil
 }
 
-func (s *venueService) Create(v *models.Venue) error {
+// GetVisible возвращает площадку для публичного просмотра. Неопубликованную площадку
+// (черновик, на модерации, отклонённую) видят только её владелец и администратор, остальным - ErrVenueNotFound
+func (s *venueService) GetVisible(id uint, claims *models.Claims) (*models.Venue, error) {
+	venue, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if venue.Status != models.VenuePublished && !canManageVenue(claims, venue) {
+		return nil, ErrVenueNotFound
+	}
+	return venue, nil
+}
+
+// Create создаёт площадку. Создавать площадки могут владельцы и администраторы,
+// владельцем становится автор запроса, если администратор не указал другого
+func (s *venueService) Create(claims *models.Claims, v *models.Venue) error {
+	if err := prepareNewVenue(s.types, claims, v); err != nil {
+		return err
+	}
+
 	if err := s.repository.Create(v); err != nil {
 		s.logger.Error("Ошибка создания площадки", "venue_type", v.VenueType, "owner_id", v.OwnerID, "error", err)
 		return err
@@ -69,26 +133,104 @@ func (s *venueService) Create(v *models.Venue) error {
 	return nil
 }
 
-func (s *venueService) GetList(filter VenueFilter) ([]models.Venue, error) {
+// prepareNewVenue проверяет права и данные новой площадки и заполняет то, что задаёт сервис:
+// владельца, правила по умолчанию, первую версию и статус черновика
+func prepareNewVenue(types repository.VenueTypeRepository, claims *models.Claims, v *models.Venue) error {
+	if claims == nil || (claims.Role != models.RoleOwner && claims.Role != models.RoleAdmin) {
+		return ErrForbidden
+	}
+	ownerID, err := resolveOwner(claims, v.OwnerID, claims.UserID)
+	if err != nil {
+		return err
+	}
+	v.OwnerID = ownerID
+
+	venueType, err := resolveVenueType(types, v, "")
+	if err != nil {
+		return err
+	}
+	// Минимальная длительность брони по умолчанию зависит от типа площадки
+	v.BookingRules = v.BookingRules.WithDefaults(venueType.DefaultMinDurationMinutes)
+
+	// Исходные цена и расписание - первая версия площадки
+	v.Revision = 1
+	v.Revisions = []models.VenueRevision{models.NewVenueRevision(v, &claims.UserID, nil)}
+
+	// Новая площадка - черновик, в выдачу она попадёт после модерации и публикации
+	v.Status = models.VenueDraft
+	v.ModerationComment = ""
+	v.SubmittedAt, v.ReviewedAt, v.ReviewedBy = nil, nil, nil
+
+	codes := make([]string, 0, len(v.Amenities))
+	for _, a := range v.Amenities {
+		codes = append(codes, a.Code)
+	}
+	codes, err = models.ValidateAmenities(venueType, codes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAmenities, err)
+	}
+	v.Amenities = make([]models.VenueAmenity, 0, len(codes))
+	for _, code := range codes {
+		v.Amenities = append(v.Amenities, models.VenueAmenity{Code: code})
+	}
+	return nil
+}
+
+func (s *venueService) GetList(filter VenueFilter) (*VenueList, error) {
 	repoFilter := repository.VenueFilter{
-		District:  filter.District,
-		VenueType: filter.VenueType,
-		HourPrice: filter.HourPrice,
-		IsActive:  filter.IsActive,
-		OwnerID:   filter.OwnerID,
-		Page:      filter.Page,
-		Limit:     filter.Limit,
+		Districts:  filter.Districts,
+		VenueTypes: filter.VenueTypes,
+		HourPrice:  filter.HourPrice,
+		IsActive:   filter.IsActive,
+		OwnerID:    filter.OwnerID,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+
+		MinHourPrice: filter.MinHourPrice,
+		MaxHourPrice: filter.MaxHourPrice,
+		MinRating:    filter.MinRating,
+		Sort:         filter.Sort,
+
+		Geo:  filter.Geo,
+		BBox: filter.BBox,
+
+		Amenities:      filter.Amenities,
+		AmenitiesMatch: filter.AmenitiesMatch,
+
+		// Публичная выдача - только опубликованные площадки
+		Statuses: []models.VenueStatus{models.VenuePublished},
+	}
+	if filter.Cursor != "" {
+		cursor, err := repository.DecodeVenueCursor(filter.Cursor, filter.Sort)
+		if err != nil {
+			return nil, err
+		}
+		repoFilter.After = cursor
 	}
-	venues, err := s.repository.GetList(repoFilter)
+
+	venues, total, err := s.repository.GetList(repoFilter)
 	if err != nil {
 		s.logger.Error("Ошибка получения списка площадок", "error", err)
 		return nil, err
 	}
-	return venues, nil
+
+	list := &VenueList{Venues: venues, Total: total}
+	if filter.Limit > 0 && len(venues) > filter.Limit {
+		list.Venues = venues[:filter.Limit]
+		list.NextCursor = repository.CursorAfter(filter.Sort, list.Venues[filter.Limit-1]).Encode()
+	}
+	return list, nil
 }
 
-func (s *venueService) GetByOwnerID(ownerID uint) ([]models.Venue, error) {
-	venues, err := s.repository.GetByOwnerID(ownerID)
+// GetByOwnerID возвращает площадки владельца. Сам владелец и администратор видят площадки в любом статусе,
+// остальные - только опубликованные
+func (s *venueService) GetByOwnerID(ownerID uint, claims *models.Claims) ([]models.Venue, error) {
+	var statuses []models.VenueStatus
+	if claims == nil || (claims.Role != models.RoleAdmin && claims.UserID != ownerID) {
+		statuses = []models.VenueStatus{models.VenuePublished}
+	}
+
+	venues, err := s.repository.GetByOwnerID(ownerID, statuses)
 	if err != nil {
 		s.logger.Error("Ошибка получения площадок владельца", "owner_id", ownerID, "error", err)
 		return nil, err
@@ -96,40 +238,79 @@ func (s *venueService) GetByOwnerID(ownerID uint) ([]models.Venue, error) {
 	return venues, nil
 }
 
-func (s *venueService) Update(id uint, venue *models.Venue) error {
-	// Проверяем существование площадки
-	existingVenue, err := s.repository.GetByID(id)
+func (s *venueService) Update(id uint, claims *models.Claims, venue *models.Venue, version int) error {
+	// Проверяем существование площадки и права на неё
+	existingVenue, err := s.getVersionedVenue(id, claims, version)
+	if err != nil {
+		return err
+	}
+	return versionError(s.update(existingVenue, claims, venue), version)
+}
+
+// Patch применяет частичное изменение к текущей площадке и сохраняет результат по правилам Update.
+// Параллельное изменение между чтением и записью не затирается, а возвращает ошибку
+func (s *venueService) Patch(id uint, claims *models.Claims, patch VenuePatch, version int) error {
+	existingVenue, err := s.getVersionedVenue(id, claims, version)
+	if err != nil {
+		return err
+	}
+	current := *existingVenue
+	venue, err := patch(&current)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrVenueNotFound
-		}
-		s.logger.Error("Ошибка получения площадки для обновления", "id", id, "error", err)
 		return err
 	}
+	return versionError(s.update(existingVenue, claims, venue), version)
+}
+
+// update переносит в existingVenue все поля venue (PUT-семантика) и сохраняет площадку
+func (s *venueService) update(existingVenue *models.Venue, claims *models.Claims, venue *models.Venue) error {
+	id := existingVenue.ID
+	ownerID, err := resolveOwner(claims, venue.OwnerID, existingVenue.OwnerID)
+	if err != nil {
+		return err
+	}
+	venueType, err := resolveVenueType(s.types, venue, existingVenue.VenueType)
+	if err != nil {
+		return err
+	}
+	reapprove := s.moderation.needsReapproval(claims, existingVenue, venue)
+
+	before := *existingVenue
 
 	// PUT-семантика: обновляем все поля целиком
 	// Все обязательные поля уже валидированы на уровне транспорта
-	existingVenue.VenueType = venue.VenueType
-	existingVenue.OwnerID = venue.OwnerID
-	existingVenue.IsActive = venue.IsActive
-	existingVenue.HourPrice = venue.HourPrice
-	existingVenue.District = venue.District
-	existingVenue.Weekdays = venue.Weekdays
-
-	if err := s.repository.Update(existingVenue); err != nil {
+	mergeVenue(existingVenue, venue, ownerID, venueType)
+
+	if err := s.save(&before, existingVenue, claims); err != nil {
 		s.logger.Error("Ошибка обновления площадки", "id", id, "error", err)
 		return err
 	}
+
+	if reapprove {
+		if err := s.resubmit(existingVenue); err != nil {
+			return err
+		}
+	}
+
+	// При смене типа площадки убираем удобства, которые к новому типу неприменимы
+	kept := make([]string, 0, len(existingVenue.Amenities))
+	for _, a := range existingVenue.Amenities {
+		if amenity, ok := models.FindAmenity(a.Code); ok && amenity.AppliesTo(venueType) {
+			kept = append(kept, a.Code)
+		}
+	}
+	if len(kept) != len(existingVenue.Amenities) {
+		if err := s.repository.SetAmenities(id, kept); err != nil {
+			s.logger.Error("Ошибка обновления удобств площадки", "id", id, "error", err)
+			return err
+		}
+	}
 	return nil
 }
 
-func (s *venueService) Delete(id uint) error {
-	// Проверяем существование площадки
-	_, err := s.repository.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrVenueNotFound
-		}
+func (s *venueService) Delete(id uint, claims *models.Claims) error {
+	// Проверяем существование площадки и права на неё
+	if _, err := s.getManagedVenue(id, claims); err != nil {
 		return err
 	}
 
@@ -152,21 +333,257 @@ func (s *venueService) GetSchedule(id uint) (*models.Venue, error) {
 	return venue, nil
 }
 
-func (s *venueService) UpdateSchedule(id uint, weekdays models.Weekdays) error {
-	venue, err := s.repository.GetByID(id)
+func (s *venueService) UpdateSchedule(id uint, claims *models.Claims, weekdays models.Weekdays, version int) error {
+	venue, err := s.getVersionedVenue(id, claims, version)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrVenueNotFound
-		}
 		return err
 	}
 
 	// Обновляем только расписание дней недели
+	before := *venue
 	venue.Weekdays = weekdays
 
-	if err := s.repository.Update(venue); err != nil {
+	if err := s.save(&before, venue, claims); err != nil {
 		s.logger.Error("Ошибка обновления расписания", "id", id, "error", err)
+		return versionError(err, version)
+	}
+	return nil
+}
+
+func (s *venueService) UpdateBookingRules(id uint, claims *models.Claims, rules models.BookingRules) error {
+	venue, err := s.getManagedVenue(id, claims)
+	if err != nil {
+		return err
+	}
+
+	// Обновляем только правила бронирования
+	venue.BookingRules = rules.WithDefaults(s.defaultMinDuration(venue.VenueType))
+
+	if err := s.repository.Update(venue); err != nil {
+		if errors.Is(err, repository.ErrVersionChanged) {
+			return ErrVenueConflict
+		}
+		s.logger.Error("Ошибка обновления правил бронирования", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateAmenities заменяет набор удобств площадки и возвращает сохранённые коды
+func (s *venueService) UpdateAmenities(id uint, claims *models.Claims, codes []string) ([]string, error) {
+	venue, err := s.getManagedVenue(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	venueType, err := s.types.GetByCode(venue.VenueType)
+	if err != nil {
+		s.logger.Error("Ошибка получения типа площадки", "code", venue.VenueType, "error", err)
+		return nil, err
+	}
+	codes, err = models.ValidateAmenities(venueType, codes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAmenities, err)
+	}
+
+	if err := s.repository.SetAmenities(id, codes); err != nil {
+		s.logger.Error("Ошибка обновления удобств площадки", "id", id, "error", err)
+		return nil, err
+	}
+	return codes, nil
+}
+
+// GetHistory возвращает версии цены и расписания площадки, новые сначала.
+// Автора изменений видят только владелец площадки и администратор
+func (s *venueService) GetHistory(id uint, claims *models.Claims, page, limit int) ([]models.VenueRevision, int64, error) {
+	venue, err := s.GetByID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revisions, total, err := s.repository.GetRevisions(id, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !canManageVenue(claims, venue) {
+		for i := range revisions {
+			revisions[i].ChangedBy = nil
+		}
+	}
+	return revisions, total, nil
+}
+
+// GetRevision возвращает версию цены и расписания, например ту, по которой рассчитана бронь
+func (s *venueService) GetRevision(id uint, revision int) (*models.VenueRevision, error) {
+	result, err := s.repository.GetRevision(id, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		s.logger.Error("Ошибка получения версии площадки", "id", id, "revision", revision, "error", err)
+		return nil, err
+	}
+	result.ChangedBy = nil
+	return result, nil
+}
+
+// save сохраняет площадку. Если изменились цена или расписание, записывается новая версия.
+// before - состояние площадки до изменения
+func (s *venueService) save(before, venue *models.Venue, claims *models.Claims) error {
+	previous, revision := nextRevision(before, venue, claims)
+	if revision == nil {
+		if err := s.repository.Update(venue); err != nil {
+			if errors.Is(err, repository.ErrVersionChanged) {
+				return ErrVenueConflict
+			}
+			return err
+		}
+		return nil
+	}
+
+	if err := s.repository.UpdateWithRevision(venue, previous, revision); err != nil {
+		venue.Revision = before.Revision
+		if errors.Is(err, repository.ErrRevisionChanged) {
+			return ErrRevisionConflict
+		}
 		return err
 	}
+	s.logger.Info("Новая версия цены и расписания площадки", "id", venue.ID, "revision", venue.Revision, "changes", revision.Changes)
 	return nil
 }
+
+// nextRevision готовит новую версию, если у площадки изменились цена или расписание, и увеличивает
+// venue.Revision. previous - состояние до изменения для площадок без истории. Без изменений возвращает nil
+func nextRevision(before, venue *models.Venue, claims *models.Claims) (previous, revision *models.VenueRevision) {
+	changes := models.RevisionChanges(before, venue)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	prev := models.NewVenueRevision(before, nil, nil)
+	prev.CreatedAt = before.UpdatedAt
+	venue.Revision = before.Revision + 1
+	next := models.NewVenueRevision(venue, &claims.UserID, changes)
+	return &prev, &next
+}
+
+// mergeVenue переносит в площадку все изменяемые владельцем поля (PUT-семантика)
+func mergeVenue(existing, venue *models.Venue, ownerID uint, venueType *models.VenueTypeDefinition) {
+	existing.VenueType = venue.VenueType
+	existing.OwnerID = ownerID
+	existing.IsActive = venue.IsActive
+	existing.HourPrice = venue.HourPrice
+	existing.District = venue.District
+	existing.Capacity = venue.Capacity
+	// Без booking_rules в запросе правила не меняются: их задают через PUT /venues/:id/booking-rules
+	if venue.BookingRules != (models.BookingRules{}) {
+		existing.BookingRules = venue.BookingRules
+	}
+	existing.BookingRules = existing.BookingRules.WithDefaults(venueType.DefaultMinDurationMinutes)
+	existing.Weekdays = venue.Weekdays
+	existing.Address = venue.Address
+	existing.Latitude = venue.Latitude
+	existing.Longitude = venue.Longitude
+}
+
+// defaultMinDuration возвращает минимальную длительность брони по умолчанию для типа площадки
+func (s *venueService) defaultMinDuration(code models.VenueType) int {
+	venueType, err := s.types.GetByCode(code)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Error("Ошибка получения типа площадки", "code", code, "error", err)
+		}
+		return models.DefaultMinDurationMinutes
+	}
+	return venueType.DefaultMinDurationMinutes
+}
+
+// needsReapproval сообщает, нужно ли после правок отправить площадку на повторную модерацию
+func (p ModerationPolicy) needsReapproval(claims *models.Claims, existing, updated *models.Venue) bool {
+	return p.ReapproveMaterialEdits && claims.Role != models.RoleAdmin &&
+		(existing.Status == models.VenueApproved || existing.Status == models.VenuePublished) &&
+		materialChanged(existing, updated)
+}
+
+// materialChanged сообщает, изменились ли существенные для модерации данные площадки
+func materialChanged(existing, updated *models.Venue) bool {
+	return existing.VenueType != updated.VenueType ||
+		existing.District != updated.District ||
+		existing.Address != updated.Address ||
+		!equalCoordinate(existing.Latitude, updated.Latitude) ||
+		!equalCoordinate(existing.Longitude, updated.Longitude)
+}
+
+func equalCoordinate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// resubmit отправляет площадку на повторную модерацию после существенных правок
+func (s *venueService) resubmit(venue *models.Venue) error {
+	from := venue.Status
+	now := time.Now()
+	venue.Status = models.VenueSubmitted
+	venue.SubmittedAt = &now
+	venue.ModerationComment = ""
+	if err := s.repository.UpdateStatus(venue, from); err != nil {
+		// Статус успел изменить администратор - его решение важнее
+		if errors.Is(err, repository.ErrStatusChanged) {
+			return nil
+		}
+		s.logger.Error("Ошибка отправки площадки на повторную модерацию", "id", venue.ID, "error", err)
+		return err
+	}
+	s.logger.Info("Площадка отправлена на повторную модерацию после правок", "id", venue.ID, "from", from)
+	return nil
+}
+
+// getManagedVenue загружает площадку и проверяет, что пользователь может её изменять
+func (s *venueService) getManagedVenue(id uint, claims *models.Claims) (*models.Venue, error) {
+	venue, err := s.repository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVenueNotFound
+		}
+		s.logger.Error("Ошибка получения площадки", "id", id, "error", err)
+		return nil, err
+	}
+	if !canManageVenue(claims, venue) {
+		return nil, ErrForbidden
+	}
+	return venue, nil
+}
+
+// getVersionedVenue - getManagedVenue с проверкой версии из If-Match (0 - без проверки)
+func (s *venueService) getVersionedVenue(id uint, claims *models.Claims, version int) (*models.Venue, error) {
+	venue, err := s.getManagedVenue(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && venue.Version != version {
+		return nil, ErrVersionMismatch
+	}
+	return venue, nil
+}
+
+// versionError: если клиент передал версию, параллельное изменение означает, что его версия устарела
+func versionError(err error, version int) error {
+	if version != 0 && (errors.Is(err, ErrVenueConflict) || errors.Is(err, ErrRevisionConflict)) {
+		return ErrVersionMismatch
+	}
+	return err
+}
+
+// resolveOwner определяет владельца площадки: владелец не может передать площадку другому пользователю,
+// администратор может назначить любого. requested == 0 оставляет current
+func resolveOwner(claims *models.Claims, requested, current uint) (uint, error) {
+	if requested == 0 {
+		return current, nil
+	}
+	if claims.Role != models.RoleAdmin && requested != claims.UserID {
+		return 0, ErrForbidden
+	}
+	return requested, nil
+}
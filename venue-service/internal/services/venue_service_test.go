@@ -0,0 +1,520 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// fakeVenueRepository хранит площадки в памяти. Не нужные тестам методы не реализованы
+type fakeVenueRepository struct {
+	repository.VenueRepository
+	venues    map[uint]models.Venue
+	revisions []models.VenueRevision
+	nextID    uint
+}
+
+func newFakeVenueRepository(venues ...models.Venue) *fakeVenueRepository {
+	r := &fakeVenueRepository{venues: make(map[uint]models.Venue), nextID: 100}
+	for _, v := range venues {
+		r.venues[v.ID] = v
+	}
+	return r
+}
+
+func (r *fakeVenueRepository) GetByID(id uint) (*models.Venue, error) {
+	v, ok := r.venues[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &v, nil
+}
+
+func (r *fakeVenueRepository) Create(venue *models.Venue) error {
+	r.nextID++
+	venue.ID = r.nextID
+	for _, rev := range venue.Revisions {
+		rev.VenueID = venue.ID
+		r.revisions = append(r.revisions, rev)
+	}
+	r.venues[venue.ID] = *venue
+	return nil
+}
+
+func (r *fakeVenueRepository) UpdateWithRevision(venue *models.Venue, previous, revision *models.VenueRevision) error {
+	if r.venues[venue.ID].Revision != previous.Revision || r.venues[venue.ID].Version != venue.Version {
+		return repository.ErrRevisionChanged
+	}
+	venue.Version++
+	if _, total, _ := r.GetRevisions(venue.ID, 1, 1); total == 0 {
+		r.revisions = append(r.revisions, *previous)
+	}
+	r.venues[venue.ID] = *venue
+	r.revisions = append(r.revisions, *revision)
+	return nil
+}
+
+func (r *fakeVenueRepository) GetRevisions(venueID uint, page, limit int) ([]models.VenueRevision, int64, error) {
+	var result []models.VenueRevision
+	for i := len(r.revisions) - 1; i >= 0; i-- {
+		if r.revisions[i].VenueID == venueID {
+			result = append(result, r.revisions[i])
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+func (r *fakeVenueRepository) Update(venue *models.Venue) error {
+	if r.venues[venue.ID].Version != venue.Version {
+		return repository.ErrVersionChanged
+	}
+	venue.Version++
+	r.venues[venue.ID] = *venue
+	return nil
+}
+
+func (r *fakeVenueRepository) Delete(id uint) error {
+	v := r.venues[id]
+	v.IsActive = false
+	r.venues[id] = v
+	return nil
+}
+
+func (r *fakeVenueRepository) SetAmenities(venueID uint, codes []string) error {
+	v := r.venues[venueID]
+	v.Amenities = nil
+	for _, code := range codes {
+		v.Amenities = append(v.Amenities, models.VenueAmenity{VenueID: venueID, Code: code})
+	}
+	r.venues[venueID] = v
+	return nil
+}
+
+func (r *fakeVenueRepository) UpdateStatus(venue *models.Venue, from models.VenueStatus) error {
+	v, ok := r.venues[venue.ID]
+	if !ok || v.Status != from {
+		return repository.ErrStatusChanged
+	}
+	v.Status = venue.Status
+	v.ModerationComment = venue.ModerationComment
+	v.SubmittedAt, v.ReviewedAt, v.ReviewedBy = venue.SubmittedAt, venue.ReviewedAt, venue.ReviewedBy
+	r.venues[venue.ID] = v
+	return nil
+}
+
+func (r *fakeVenueRepository) GetList(filter repository.VenueFilter) ([]models.Venue, int64, error) {
+	var result []models.Venue
+	for _, v := range r.venues {
+		if filter.OwnerID != 0 && v.OwnerID != filter.OwnerID {
+			continue
+		}
+		if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, v.ID) {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, v.Status) {
+			continue
+		}
+		if len(filter.Districts) > 0 && !slices.Contains(filter.Districts, v.District) {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result, int64(len(result)), nil
+}
+
+func (r *fakeVenueRepository) Import(batch *repository.VenueImport) error {
+	for _, venue := range batch.Create {
+		if err := r.Create(venue); err != nil {
+			return err
+		}
+	}
+	for _, update := range batch.Update {
+		if update.Revision == nil {
+			r.venues[update.Venue.ID] = *update.Venue
+		} else if err := r.UpdateWithRevision(update.Venue, update.Previous, update.Revision); err != nil {
+			return err
+		}
+		if err := r.SetAmenities(update.Venue.ID, update.Amenities); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeVenueTypeRepository - справочник типов площадок в памяти
+type fakeVenueTypeRepository struct {
+	repository.VenueTypeRepository
+	types  map[models.VenueType]models.VenueTypeDefinition
+	venues map[models.VenueType]int64 // Число площадок по типам
+
+	removedAmenities []string // Удобства, снятые с площадок последним Update
+}
+
+func newFakeVenueTypeRepository() *fakeVenueTypeRepository {
+	r := &fakeVenueTypeRepository{
+		types:  make(map[models.VenueType]models.VenueTypeDefinition),
+		venues: make(map[models.VenueType]int64),
+	}
+	for _, t := range models.DefaultVenueTypes() {
+		r.types[t.Code] = t
+	}
+	return r
+}
+
+func (r *fakeVenueTypeRepository) GetByCode(code models.VenueType) (*models.VenueTypeDefinition, error) {
+	t, ok := r.types[code]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &t, nil
+}
+
+func (r *fakeVenueTypeRepository) Create(venueType *models.VenueTypeDefinition) error {
+	if _, ok := r.types[venueType.Code]; ok {
+		return gorm.ErrDuplicatedKey
+	}
+	r.types[venueType.Code] = *venueType
+	return nil
+}
+
+func (r *fakeVenueTypeRepository) Update(venueType *models.VenueTypeDefinition, removedAmenities []string) error {
+	r.types[venueType.Code] = *venueType
+	r.removedAmenities = removedAmenities
+	return nil
+}
+
+func (r *fakeVenueTypeRepository) Delete(code models.VenueType) error {
+	delete(r.types, code)
+	return nil
+}
+
+func (r *fakeVenueTypeRepository) CountVenues(code models.VenueType) (int64, error) {
+	return r.venues[code], nil
+}
+
+type fakeUnitRepository struct {
+	repository.VenueUnitRepository
+	created []models.VenueUnit
+}
+
+func (r *fakeUnitRepository) Create(unit *models.VenueUnit) error {
+	r.created = append(r.created, *unit)
+	return nil
+}
+
+const (
+	venueOwnerID = 7
+	otherOwnerID = 8
+	clientID     = 9
+	adminID      = 1
+)
+
+var (
+	ownerClaims      = &models.Claims{UserID: venueOwnerID, Role: models.RoleOwner}
+	otherOwnerClaims = &models.Claims{UserID: otherOwnerID, Role: models.RoleOwner}
+	clientClaims     = &models.Claims{UserID: clientID, Role: models.RoleClient}
+	adminClaims      = &models.Claims{UserID: adminID, Role: models.RoleAdmin}
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func testVenue() models.Venue {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 1, 21, 0, 0, 0, time.UTC)
+	day := models.DaySchedule{Enabled: true, StartTime: &start, EndTime: &end}
+	venue := models.Venue{
+		VenueType: models.VenueFootball,
+		OwnerID:   venueOwnerID,
+		IsActive:  true,
+		HourPrice: 3000,
+		District:  "Центральный",
+		Capacity:  1,
+		Weekdays: models.Weekdays{
+			Monday: day, Tuesday: day, Wednesday: day, Thursday: day,
+			Friday: day, Saturday: day, Sunday: day,
+		},
+	}
+	venue.ID = 1
+	venue.Revision = 1
+	venue.Version = 1
+	return venue
+}
+
+func TestVenueCreateByRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    *models.Claims
+		ownerID   uint // owner_id из запроса
+		wantErr   error
+		wantOwner uint
+	}{
+		{name: "без токена", claims: nil, wantErr: ErrForbidden},
+		{name: "клиент", claims: clientClaims, wantErr: ErrForbidden},
+		{name: "владелец", claims: ownerClaims, wantOwner: venueOwnerID},
+		{name: "владелец указывает себя", claims: ownerClaims, ownerID: venueOwnerID, wantOwner: venueOwnerID},
+		{name: "владелец указывает другого", claims: ownerClaims, ownerID: otherOwnerID, wantErr: ErrForbidden},
+		{name: "администратор для себя", claims: adminClaims, wantOwner: adminID},
+		{name: "администратор для владельца", claims: adminClaims, ownerID: otherOwnerID, wantOwner: otherOwnerID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeVenueRepository()
+			service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+			venue := testVenue()
+			venue.ID = 0
+			venue.OwnerID = tt.ownerID
+			err := service.Create(tt.claims, &venue)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create: ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.venues) != 0 {
+					t.Fatalf("площадка не должна быть создана")
+				}
+				return
+			}
+			if saved := repo.venues[venue.ID]; saved.OwnerID != tt.wantOwner {
+				t.Fatalf("owner_id = %d, ожидался %d", saved.OwnerID, tt.wantOwner)
+			}
+		})
+	}
+}
+
+func TestVenueUpdateByRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    *models.Claims
+		ownerID   uint
+		wantErr   error
+		wantOwner uint
+	}{
+		{name: "без токена", claims: nil, wantErr: ErrForbidden},
+		{name: "клиент", claims: clientClaims, wantErr: ErrForbidden},
+		{name: "чужой владелец", claims: otherOwnerClaims, wantErr: ErrForbidden},
+		{name: "владелец", claims: ownerClaims, wantOwner: venueOwnerID},
+		{name: "владелец передаёт площадку", claims: ownerClaims, ownerID: otherOwnerID, wantErr: ErrForbidden},
+		{name: "администратор", claims: adminClaims, wantOwner: venueOwnerID},
+		{name: "администратор меняет владельца", claims: adminClaims, ownerID: otherOwnerID, wantOwner: otherOwnerID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeVenueRepository(testVenue())
+			service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+			update := testVenue()
+			update.OwnerID = tt.ownerID
+			update.HourPrice = 4500
+			err := service.Update(1, tt.claims, &update, 0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update: ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+
+			saved := repo.venues[1]
+			if tt.wantErr != nil {
+				if saved.HourPrice != 3000 || saved.OwnerID != venueOwnerID {
+					t.Fatalf("площадка не должна измениться: %+v", saved)
+				}
+				return
+			}
+			if saved.HourPrice != 4500 || saved.OwnerID != tt.wantOwner {
+				t.Fatalf("hour_price = %d, owner_id = %d", saved.HourPrice, saved.OwnerID)
+			}
+		})
+	}
+}
+
+func TestVenueUpdateKeepsBookingRules(t *testing.T) {
+	venue := testVenue()
+	venue.BookingRules = models.BookingRules{MinLeadMinutes: 120, MinDurationMinutes: 90, ReliabilityLimit: 3, ReliabilityAction: models.ReliabilityBlock}
+	repo := newFakeVenueRepository(venue)
+	service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	// PUT без booking_rules
+	update := testVenue()
+	update.HourPrice = 4500
+	if err := service.Update(1, ownerClaims, &update, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.venues[1].BookingRules; got != venue.BookingRules {
+		t.Fatalf("правила бронирования %+v, ожидались %+v", got, venue.BookingRules)
+	}
+
+	// Переданные правила заменяют прежние
+	update = testVenue()
+	update.BookingRules = models.BookingRules{MaxAdvanceDays: 14}
+	if err := service.Update(1, ownerClaims, &update, 0); err != nil {
+		t.Fatal(err)
+	}
+	got := repo.venues[1].BookingRules
+	if got.MaxAdvanceDays != 14 || got.MinLeadMinutes != 0 || got.ReliabilityLimit != 0 || got.MinDurationMinutes == 0 {
+		t.Fatalf("правила бронирования %+v", got)
+	}
+}
+
+func TestVenueManageByRole(t *testing.T) {
+	roles := []struct {
+		name    string
+		claims  *models.Claims
+		wantErr error
+	}{
+		{name: "без токена", claims: nil, wantErr: ErrForbidden},
+		{name: "клиент", claims: clientClaims, wantErr: ErrForbidden},
+		{name: "чужой владелец", claims: otherOwnerClaims, wantErr: ErrForbidden},
+		{name: "владелец", claims: ownerClaims},
+		{name: "администратор", claims: adminClaims},
+	}
+	actions := []struct {
+		name string
+		do   func(s VenueService, claims *models.Claims) error
+	}{
+		{"удаление", func(s VenueService, claims *models.Claims) error {
+			return s.Delete(1, claims)
+		}},
+		{"расписание", func(s VenueService, claims *models.Claims) error {
+			return s.UpdateSchedule(1, claims, testVenue().Weekdays, 0)
+		}},
+		{"правила бронирования", func(s VenueService, claims *models.Claims) error {
+			return s.UpdateBookingRules(1, claims, models.DefaultBookingRules())
+		}},
+		{"удобства", func(s VenueService, claims *models.Claims) error {
+			_, err := s.UpdateAmenities(1, claims, []string{"lighting"})
+			return err
+		}},
+	}
+
+	for _, action := range actions {
+		for _, role := range roles {
+			t.Run(action.name+"/"+role.name, func(t *testing.T) {
+				service := NewVenueService(newFakeVenueRepository(testVenue()), newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+				if err := action.do(service, role.claims); !errors.Is(err, role.wantErr) {
+					t.Fatalf("ошибка %v, ожидалась %v", err, role.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestVenueManageNotFound(t *testing.T) {
+	service := NewVenueService(newFakeVenueRepository(), newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+	if err := service.Delete(1, adminClaims); !errors.Is(err, ErrVenueNotFound) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrVenueNotFound)
+	}
+}
+
+func TestVenueUpdateVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     int
+		wantErr     error
+		wantVersion int
+	}{
+		{name: "без If-Match", version: 0, wantVersion: 2},
+		{name: "актуальная версия", version: 1, wantVersion: 2},
+		{name: "устаревшая версия", version: 2, wantErr: ErrVersionMismatch, wantVersion: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeVenueRepository(testVenue())
+			service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+			update := testVenue()
+			update.District = "Северный"
+			if err := service.Update(1, ownerClaims, &update, tt.version); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update: ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+			if saved := repo.venues[1]; saved.Version != tt.wantVersion {
+				t.Fatalf("version = %d, ожидалась %d", saved.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestVenueConcurrentUpdate(t *testing.T) {
+	repo := newFakeVenueRepository(testVenue())
+	service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	// Между чтением и записью площадку изменил другой запрос
+	patch := func(current *models.Venue) (*models.Venue, error) {
+		concurrent := repo.venues[1]
+		concurrent.Version++
+		repo.venues[1] = concurrent
+
+		updated := *current
+		updated.District = "Северный"
+		return &updated, nil
+	}
+	if err := service.Patch(1, ownerClaims, patch, 0); !errors.Is(err, ErrVenueConflict) {
+		t.Fatalf("без If-Match: ошибка %v, ожидалась %v", err, ErrVenueConflict)
+	}
+	if err := service.Patch(1, ownerClaims, patch, 2); !errors.Is(err, ErrVersionMismatch) {
+		t.Fatalf("с If-Match: ошибка %v, ожидалась %v", err, ErrVersionMismatch)
+	}
+	if saved := repo.venues[1]; saved.District != "Центральный" {
+		t.Fatalf("изменение не должно затереть параллельное: district = %s", saved.District)
+	}
+}
+
+func TestVenuePatch(t *testing.T) {
+	repo := newFakeVenueRepository(testVenue())
+	service := NewVenueService(repo, newFakeVenueTypeRepository(), ModerationPolicy{}, testLogger())
+
+	err := service.Patch(1, ownerClaims, func(current *models.Venue) (*models.Venue, error) {
+		current.HourPrice = 4500
+		return current, nil
+	}, 1)
+	if err != nil {
+		t.Fatalf("Patch: %v", err)
+	}
+	saved := repo.venues[1]
+	if saved.HourPrice != 4500 || saved.District != "Центральный" || saved.Version != 2 {
+		t.Fatalf("hour_price = %d, district = %s, version = %d", saved.HourPrice, saved.District, saved.Version)
+	}
+
+	if err := service.Patch(1, otherOwnerClaims, func(current *models.Venue) (*models.Venue, error) {
+		return current, nil
+	}, 0); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("чужой владелец: ошибка %v", err)
+	}
+}
+
+func TestVenueUnitCreateByRole(t *testing.T) {
+	roles := []struct {
+		name    string
+		claims  *models.Claims
+		wantErr error
+	}{
+		{name: "без токена", claims: nil, wantErr: ErrForbidden},
+		{name: "клиент", claims: clientClaims, wantErr: ErrForbidden},
+		{name: "чужой владелец", claims: otherOwnerClaims, wantErr: ErrForbidden},
+		{name: "владелец", claims: ownerClaims},
+		{name: "администратор", claims: adminClaims},
+	}
+
+	for _, role := range roles {
+		t.Run(role.name, func(t *testing.T) {
+			units := &fakeUnitRepository{}
+			service := NewVenueUnitService(newFakeVenueRepository(testVenue()), units, testLogger())
+
+			err := service.Create(1, role.claims, &models.VenueUnit{Name: "Корт 1"})
+			if !errors.Is(err, role.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, role.wantErr)
+			}
+			if created := len(units.created) == 1; created != (role.wantErr == nil) {
+				t.Fatalf("создано единиц: %d", len(units.created))
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"venue-service/internal/models"
+	"venue-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVenueTypeNotFound = errors.New("venue type not found")
+	ErrVenueTypeExists   = errors.New("тип площадки с таким кодом уже существует")
+	ErrVenueTypeInUse    = errors.New("тип площадки используется площадками")
+	ErrInvalidVenueType  = errors.New("неверный тип площадки")
+)
+
+type VenueTypeService interface {
+	GetList(claims *models.Claims, includeInactive bool) ([]models.VenueTypeDefinition, error)
+	Create(claims *models.Claims, venueType *models.VenueTypeDefinition) error
+	Update(claims *models.Claims, code models.VenueType, venueType *models.VenueTypeDefinition) error
+	Delete(claims *models.Claims, code models.VenueType) error
+}
+
+type venueTypeService struct {
+	repository repository.VenueTypeRepository
+	logger     *slog.Logger
+}
+
+func NewVenueTypeService(repository repository.VenueTypeRepository, logger *slog.Logger) VenueTypeService {
+	return &venueTypeService{
+		repository: repository,
+		logger:     logger.With("layer", "service"),
+	}
+}
+
+// GetList возвращает справочник типов. Отключённые типы видит только администратор
+func (s *venueTypeService) GetList(claims *models.Claims, includeInactive bool) ([]models.VenueTypeDefinition, error) {
+	if includeInactive && !isAdmin(claims) {
+		return nil, ErrForbidden
+	}
+	types, err := s.repository.GetList(includeInactive)
+	if err != nil {
+		s.logger.Error("Ошибка получения типов площадок", "error", err)
+		return nil, err
+	}
+	return types, nil
+}
+
+func (s *venueTypeService) Create(claims *models.Claims, venueType *models.VenueTypeDefinition) error {
+	if !isAdmin(claims) {
+		return ErrForbidden
+	}
+	if err := venueType.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidVenueType, err)
+	}
+
+	if err := s.repository.Create(venueType); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrVenueTypeExists
+		}
+		return err
+	}
+	s.logger.Info("Добавлен тип площадки", "code", venueType.Code, "admin_id", claims.UserID)
+	return nil
+}
+
+// Update меняет всё, кроме кода: он записан в площадках
+func (s *venueTypeService) Update(claims *models.Claims, code models.VenueType, venueType *models.VenueTypeDefinition) error {
+	if !isAdmin(claims) {
+		return ErrForbidden
+	}
+	existing, err := s.getVenueType(code)
+	if err != nil {
+		return err
+	}
+
+	venueType.Code = existing.Code
+	venueType.CreatedAt = existing.CreatedAt
+	if err := venueType.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidVenueType, err)
+	}
+
+	// Нельзя сделать тип бронируемым целиком, пока у его площадок продаются отдельные места
+	if existing.Shared && !venueType.Shared {
+		count, err := s.repository.CountSharedVenues(code)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: у %d площадок вместимость больше 1", ErrVenueTypeInUse, count)
+		}
+	}
+
+	// Удобства, которые стали неприменимы к типу, снимаются с его площадок
+	var removed []string
+	for _, code := range existing.Amenities {
+		if !slices.Contains(venueType.Amenities, code) {
+			removed = append(removed, code)
+		}
+	}
+	if err := s.repository.Update(venueType, removed); err != nil {
+		return err
+	}
+	s.logger.Info("Изменён тип площадки", "code", code, "admin_id", claims.UserID)
+	return nil
+}
+
+// Delete удаляет тип, которым не помечена ни одна площадка. Используемый тип можно только отключить
+func (s *venueTypeService) Delete(claims *models.Claims, code models.VenueType) error {
+	if !isAdmin(claims) {
+		return ErrForbidden
+	}
+	if _, err := s.getVenueType(code); err != nil {
+		return err
+	}
+
+	count, err := s.repository.CountVenues(code)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d площадок, отключите тип вместо удаления", ErrVenueTypeInUse, count)
+	}
+
+	if err := s.repository.Delete(code); err != nil {
+		return err
+	}
+	s.logger.Info("Удалён тип площадки", "code", code, "admin_id", claims.UserID)
+	return nil
+}
+
+func (s *venueTypeService) getVenueType(code models.VenueType) (*models.VenueTypeDefinition, error) {
+	venueType, err := s.repository.GetByCode(code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVenueTypeNotFound
+		}
+		s.logger.Error("Ошибка получения типа площадки", "code", code, "error", err)
+		return nil, err
+	}
+	return venueType, nil
+}
+
+// resolveVenueType проверяет тип площадки по справочнику: тип должен существовать,
+// отключённый тип нельзя выбрать заново (current - тип площадки до изменения),
+// вместимость больше 1 допустима только для типов с отдельными местами
+func resolveVenueType(types repository.VenueTypeRepository, venue *models.Venue, current models.VenueType) (*models.VenueTypeDefinition, error) {
+	venueType, err := types.GetByCode(venue.VenueType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidVenueType, venue.VenueType)
+		}
+		return nil, err
+	}
+	if !venueType.IsActive && venue.VenueType != current {
+		return nil, fmt.Errorf("%w: тип %s отключён", ErrInvalidVenueType, venue.VenueType)
+	}
+	if venue.Capacity > 1 && !venueType.Shared {
+		return nil, fmt.Errorf("%w: тип площадки %s бронируется целиком, вместимость должна быть равна 1", ErrInvalidVenueType, venue.VenueType)
+	}
+	return venueType, nil
+}
+
+func isAdmin(claims *models.Claims) bool {
+	return claims != nil && claims.Role == models.RoleAdmin
+}
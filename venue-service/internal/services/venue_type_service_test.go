@@ -0,0 +1,216 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"venue-service/internal/models"
+)
+
+func padelType() *models.VenueTypeDefinition {
+	return &models.VenueTypeDefinition{
+		Code:                      "padel",
+		Names:                     models.LocalizedNames{"ru": "Падел", "en": "Padel"},
+		IsActive:                  true,
+		DefaultMinDurationMinutes: 90,
+	}
+}
+
+func TestVenueTypeManageByRole(t *testing.T) {
+	roles := []struct {
+		name    string
+		claims  *models.Claims
+		wantErr error
+	}{
+		{name: "без токена", claims: nil, wantErr: ErrForbidden},
+		{name: "клиент", claims: clientClaims, wantErr: ErrForbidden},
+		{name: "владелец", claims: ownerClaims, wantErr: ErrForbidden},
+		{name: "администратор", claims: adminClaims},
+	}
+
+	for _, role := range roles {
+		t.Run(role.name, func(t *testing.T) {
+			types := newFakeVenueTypeRepository()
+			service := NewVenueTypeService(types, testLogger())
+
+			if err := service.Create(role.claims, padelType()); !errors.Is(err, role.wantErr) {
+				t.Fatalf("Create: ошибка %v, ожидалась %v", err, role.wantErr)
+			}
+			if _, created := types.types["padel"]; created != (role.wantErr == nil) {
+				t.Fatalf("тип создан: %v", created)
+			}
+			if err := service.Delete(role.claims, models.VenueTennis); !errors.Is(err, role.wantErr) {
+				t.Fatalf("Delete: ошибка %v, ожидалась %v", err, role.wantErr)
+			}
+		})
+	}
+}
+
+func TestVenueTypeCreateValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(vt *models.VenueTypeDefinition)
+		wantErr error
+	}{
+		{name: "существующий код", modify: func(vt *models.VenueTypeDefinition) { vt.Code = models.VenueFootball }, wantErr: ErrVenueTypeExists},
+		{name: "неверный код", modify: func(vt *models.VenueTypeDefinition) { vt.Code = "Падел" }, wantErr: ErrInvalidVenueType},
+		{name: "без русского названия", modify: func(vt *models.VenueTypeDefinition) { vt.Names = models.LocalizedNames{"en": "Padel"} }, wantErr: ErrInvalidVenueType},
+		{name: "корректный тип", modify: func(vt *models.VenueTypeDefinition) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewVenueTypeService(newFakeVenueTypeRepository(), testLogger())
+			venueType := padelType()
+			tt.modify(venueType)
+			if err := service.Create(adminClaims, venueType); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVenueTypeDeleteInUse(t *testing.T) {
+	types := newFakeVenueTypeRepository()
+	types.venues[models.VenueTennis] = 2
+	service := NewVenueTypeService(types, testLogger())
+
+	if err := service.Delete(adminClaims, models.VenueTennis); !errors.Is(err, ErrVenueTypeInUse) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrVenueTypeInUse)
+	}
+	if err := service.Delete(adminClaims, "padel"); !errors.Is(err, ErrVenueTypeNotFound) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrVenueTypeNotFound)
+	}
+}
+
+func TestVenueTypeAmenitiesValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		amenities models.AmenityCodes
+		wantErr   error
+	}{
+		{name: "удобства для типа", amenities: models.AmenityCodes{"outdoor", "surface_hard", "lighting"}},
+		{name: "без удобств", amenities: nil},
+		{name: "неизвестное удобство", amenities: models.AmenityCodes{"sauna"}, wantErr: ErrInvalidVenueType},
+		{name: "удобство для всех типов", amenities: models.AmenityCodes{"parking"}, wantErr: ErrInvalidVenueType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewVenueTypeService(newFakeVenueTypeRepository(), testLogger())
+			venueType := padelType()
+			venueType.Amenities = tt.amenities
+			if err := service.Create(adminClaims, venueType); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Удобства нового типа задаёт администратор, без изменений в коде
+func TestVenueTypeAmenitiesApplyToVenues(t *testing.T) {
+	types := newFakeVenueTypeRepository()
+	types.types["padel"] = *padelType()
+	typeService := NewVenueTypeService(types, testLogger())
+
+	venue := testVenue()
+	venue.VenueType = "padel"
+	repo := newFakeVenueRepository(venue)
+	venueService := NewVenueService(repo, types, ModerationPolicy{}, testLogger())
+
+	if _, err := venueService.UpdateAmenities(1, ownerClaims, []string{"outdoor", "lighting"}); !errors.Is(err, ErrInvalidAmenities) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrInvalidAmenities)
+	}
+
+	update := padelType()
+	update.Amenities = models.AmenityCodes{"outdoor", "surface_artificial", "lighting"}
+	if err := typeService.Update(adminClaims, "padel", update); err != nil {
+		t.Fatal(err)
+	}
+	codes, err := venueService.UpdateAmenities(1, ownerClaims, []string{"outdoor", "lighting", "parking"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 3 {
+		t.Fatalf("удобства %v", codes)
+	}
+
+	// Снятые с типа удобства передаются репозиторию, чтобы убрать их у площадок
+	update = padelType()
+	update.Amenities = models.AmenityCodes{"surface_artificial"}
+	if err := typeService.Update(adminClaims, "padel", update); err != nil {
+		t.Fatal(err)
+	}
+	if got := types.removedAmenities; len(got) != 2 || got[0] != "outdoor" || got[1] != "lighting" {
+		t.Fatalf("снятые удобства %v, ожидались [outdoor lighting]", got)
+	}
+}
+
+func TestVenueCreateValidatesType(t *testing.T) {
+	tests := []struct {
+		name            string
+		venueType       models.VenueType
+		capacity        int
+		wantErr         error
+		wantMinDuration int
+	}{
+		{name: "тип из справочника", venueType: "padel", capacity: 1, wantMinDuration: 90},
+		{name: "неизвестный тип", venueType: "curling", capacity: 1, wantErr: ErrInvalidVenueType},
+		{name: "отключённый тип", venueType: "squash", capacity: 1, wantErr: ErrInvalidVenueType},
+		{name: "места у типа без мест", venueType: "padel", capacity: 4, wantErr: ErrInvalidVenueType},
+		{name: "места у типа с местами", venueType: models.VenueGym, capacity: 30, wantMinDuration: models.DefaultMinDurationMinutes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types := newFakeVenueTypeRepository()
+			types.types["padel"] = *padelType()
+			squash := padelType()
+			squash.Code, squash.IsActive = "squash", false
+			types.types["squash"] = *squash
+
+			repo := newFakeVenueRepository()
+			service := NewVenueService(repo, types, ModerationPolicy{}, testLogger())
+
+			venue := testVenue()
+			venue.ID = 0
+			venue.VenueType = tt.venueType
+			venue.Capacity = tt.capacity
+			err := service.Create(ownerClaims, &venue)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := repo.venues[venue.ID].BookingRules.MinDurationMinutes; got != tt.wantMinDuration {
+				t.Fatalf("min_duration_minutes = %d, ожидалось %d", got, tt.wantMinDuration)
+			}
+		})
+	}
+}
+
+func TestVenueUpdateKeepsDisabledType(t *testing.T) {
+	types := newFakeVenueTypeRepository()
+	football := types.types[models.VenueFootball]
+	football.IsActive = false
+	types.types[models.VenueFootball] = football
+
+	repo := newFakeVenueRepository(testVenue())
+	service := NewVenueService(repo, types, ModerationPolicy{}, testLogger())
+
+	// Площадка отключённого типа остаётся редактируемой
+	update := testVenue()
+	update.HourPrice = 4000
+	if err := service.Update(1, ownerClaims, &update, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Выбрать отключённый тип заново нельзя
+	tennis := testVenue()
+	tennis.VenueType = models.VenueTennis
+	repo.venues[1] = tennis
+	update = testVenue()
+	if err := service.Update(1, ownerClaims, &update, 0); !errors.Is(err, ErrInvalidVenueType) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrInvalidVenueType)
+	}
+}
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("некорректный ключ файла")
+
+// Storage хранилище медиафайлов площадок.
+// Ключ - относительный путь через "/", например venues/1/3f2a.../thumb.jpg
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL возвращает адрес, по которому клиент скачивает файл
+	URL(key string) string
+}
+
+// validateKey запрещает абсолютные пути и выход за пределы хранилища
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return ErrInvalidKey
+	}
+	return nil
+}
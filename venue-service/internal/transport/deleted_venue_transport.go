@@ -0,0 +1,146 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"venue-service/internal/middleware"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeletedVenuesQuery - параметры списка удалённых площадок
+type DeletedVenuesQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type DeletedVenueHandler struct {
+	service   services.DeletedVenueService
+	logger    *slog.Logger
+	jwtSecret string
+	mediaURL  MediaURLFunc
+}
+
+func NewDeletedVenueHandler(service services.DeletedVenueService, logger *slog.Logger, jwtSecret string, mediaURL MediaURLFunc) *DeletedVenueHandler {
+	return &DeletedVenueHandler{
+		service:   service,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+		mediaURL:  mediaURL,
+	}
+}
+
+func (h *DeletedVenueHandler) RegisterRoutes(r *gin.Engine) {
+	venues := r.Group("/venues", middleware.AuthMiddleware(h.jwtSecret))
+	{
+		venues.GET("/deleted", h.GetDeleted)
+		venues.POST("/:id/restore", h.Restore)
+		venues.DELETE("/:id/purge", h.Purge)
+	}
+}
+
+// GetDeleted - удалённые площадки, недавно удалённые первыми (только администратор)
+func (h *DeletedVenueHandler) GetDeleted(c *gin.Context) {
+	var query DeletedVenuesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Ошибка парсинга query параметров", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	venues, total, err := h.service.GetDeleted(claims, query.Page, query.Limit)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения удалённых площадок")
+		return
+	}
+
+	c.JSON(http.StatusOK, DeletedVenueListDTO{
+		Venues: ToDeletedVenueDTOList(venues, h.mediaURL),
+		Total:  total,
+		Page:   query.Page,
+		Limit:  query.Limit,
+	})
+}
+
+func (h *DeletedVenueHandler) Restore(c *gin.Context) {
+	id, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	venue, err := h.service.Restore(id, claims)
+	if err != nil {
+		h.writeError(c, err, "Ошибка восстановления площадки", "id", id)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToVenueDTO(venue, h.mediaURL))
+}
+
+func (h *DeletedVenueHandler) Purge(c *gin.Context) {
+	id, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Purge(c.Request.Context(), id, claims); err != nil {
+		h.writeError(c, err, "Ошибка окончательного удаления площадки", "id", id)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *DeletedVenueHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrVenueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrPurgeTooEarly), errors.Is(err, services.ErrVenueHasBookings):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// parseParam вспомогательная функция для парсинга ID из параметра пути
+func (h *DeletedVenueHandler) parseParam(c *gin.Context, name string) (uint, error) {
+	idStr := c.Param(name)
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		h.logger.Error("Неверный формат ID", name, idStr, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "неверный формат ID",
+		})
+		if err == nil {
+			err = strconv.ErrRange
+		}
+		return 0, err
+	}
+	return uint(id), nil
+}
@@ -2,16 +2,30 @@ package transport
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 	"venue-service/internal/models"
+	"venue-service/internal/services"
 	"venue-service/internal/validation"
 )
 
 // DayScheduleDTO - DTO для расписания одного дня недели
-// Время представлено в формате "HH:MM" как строки
+// Время представлено в формате "HH:MM" как строки.
+// Можно передать один интервал через start_time/end_time или несколько через intervals.
+// В ответе start_time/end_time - начало первого и конец последнего интервала
 type DayScheduleDTO struct {
 	Enabled   bool    `json:"enabled"`              // Включен ли день (false - валидное значение)
 	StartTime *string `json:"start_time,omitempty"` // Формат "HH:MM" (nil если disabled)
 	EndTime   *string `json:"end_time,omitempty"`   // Формат "HH:MM" (nil если disabled)
+
+	Intervals []TimeIntervalDTO `json:"intervals,omitempty"` // Интервалы работы по порядку
+}
+
+// TimeIntervalDTO - интервал работы внутри дня
+type TimeIntervalDTO struct {
+	Start string `json:"start"` // Формат "HH:MM"
+	End   string `json:"end"`   // Формат "HH:MM"
 }
 
 // WeekdaysDTO - DTO для дней недели
@@ -25,16 +39,223 @@ type WeekdaysDTO struct {
 	Sunday    DayScheduleDTO `json:"sunday" binding:"required"`
 }
 
+// days возвращает дни недели по порядку, начиная с понедельника
+func (w *WeekdaysDTO) days() []*DayScheduleDTO {
+	return []*DayScheduleDTO{&w.Monday, &w.Tuesday, &w.Wednesday, &w.Thursday, &w.Friday, &w.Saturday, &w.Sunday}
+}
+
 // VenueDTO - DTO для запросов (Create/Update) и ответов
-// Для PUT (Update) все поля обязательны - это полное обновление записи
+// Для PUT (Update) все поля обязательны - это полное обновление записи.
+// owner_id в запросе необязателен: владельцем становится автор запроса, другого владельца может указать только администратор
 type VenueDTO struct {
 	ID        uint             `json:"id,omitempty"` // Только в ответах
 	VenueType models.VenueType `json:"venue_type" binding:"required"`
-	OwnerID   uint             `json:"owner_id" binding:"required"`
+	OwnerID   uint             `json:"owner_id"`
 	IsActive  bool             `json:"is_active"`
 	HourPrice int              `json:"hour_price" binding:"required"`
 	District  string           `json:"district" binding:"required"`
+	Capacity  int              `json:"capacity" binding:"omitempty,min=1"` // Если не указано - площадка бронируется целиком (1)
 	Weekdays  WeekdaysDTO      `json:"weekdays" binding:"required"`
+	Units     []VenueUnitDTO   `json:"units,omitempty"` // Только в ответах
+
+	BookingRules *BookingRulesDTO `json:"booking_rules,omitempty"` // Если не указано - правила по умолчанию
+
+	Rating      float64 `json:"rating"`       // Только в ответах
+	RatingCount int     `json:"rating_count"` // Только в ответах
+
+	// Координаты указываются вместе или не указываются вовсе
+	Address    string   `json:"address" binding:"max=255"`
+	Latitude   *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude  *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+	DistanceKm *float64 `json:"distance_km,omitempty"` // Только в ответах на поиск по координатам
+
+	Amenities []string `json:"amenities,omitempty"` // Коды удобств из каталога, при создании опционально
+
+	Cover  *PhotoDTO  `json:"cover,omitempty"`  // Только в ответах
+	Photos []PhotoDTO `json:"photos,omitempty"` // Только в ответах с деталями площадки
+
+	// Модерация, только в ответах. Статус меняется действиями модерации, а не через PUT
+	Status            models.VenueStatus `json:"status,omitempty"`
+	ModerationComment string             `json:"moderation_comment,omitempty"` // Причина отклонения или приостановки
+	SubmittedAt       *time.Time         `json:"submitted_at,omitempty"`
+	ReviewedAt        *time.Time         `json:"reviewed_at,omitempty"`
+
+	Revision int `json:"revision,omitempty"` // Версия цены и расписания, только в ответах
+	Version  int `json:"version,omitempty"`  // Версия карточки площадки для If-Match, только в ответах
+
+	IsFavorite *bool `json:"is_favorite,omitempty"` // Только в ответах авторизованному пользователю
+}
+
+// VenueRevisionDTO - версия цены и расписания площадки
+type VenueRevisionDTO struct {
+	Revision  int         `json:"revision"`
+	HourPrice int         `json:"hour_price"`
+	Weekdays  WeekdaysDTO `json:"weekdays"`
+	Changes   []string    `json:"changes"`              // hour_price, schedule; пусто - исходное состояние
+	ChangedBy *uint       `json:"changed_by,omitempty"` // Только для владельца площадки и администратора
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+// VenueRevisionListDTO - страница истории площадки
+type VenueRevisionListDTO struct {
+	Revisions []VenueRevisionDTO `json:"revisions"`
+	Total     int64              `json:"total"`
+	Page      int                `json:"page"`
+	Limit     int                `json:"limit"`
+}
+
+// ModerationActionDTO - тело запроса действия модерации
+type ModerationActionDTO struct {
+	Reason string `json:"reason" binding:"max=1000"` // Обязательна для reject и suspend
+}
+
+// PhotoDTO - DTO фотографии площадки со ссылками на оригинал и уменьшенные копии
+type PhotoDTO struct {
+	ID        uint   `json:"id"`
+	Position  int    `json:"position"`
+	IsCover   bool   `json:"is_cover"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	URL       string `json:"url"`        // Оригинал
+	MediumURL string `json:"medium_url"` // До 1280 px по длинной стороне
+	ThumbURL  string `json:"thumb_url"`  // До 320 px по длинной стороне
+}
+
+// PhotoOrderDTO - DTO нового порядка фотографий
+type PhotoOrderDTO struct {
+	PhotoIDs []uint `json:"photo_ids" binding:"required"`
+}
+
+// MediaURLFunc строит публичный URL файла по ключу в хранилище
+type MediaURLFunc func(key string) string
+
+// AmenitiesDTO - DTO для замены набора удобств площадки, пустой список очищает набор
+type AmenitiesDTO struct {
+	Amenities []string `json:"amenities" binding:"required"`
+}
+
+// AmenityDTO - DTO элемента каталога удобств
+type AmenityDTO struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+	Group string `json:"group"`
+}
+
+// VenueTypeDTO - элемент ответа GET /venue-types. value и label сохранены для старых клиентов,
+// label - название на запрошенном языке
+type VenueTypeDTO struct {
+	Value                     string            `json:"value"`
+	Label                     string            `json:"label"`
+	Names                     map[string]string `json:"names"`
+	Icon                      string            `json:"icon"`
+	SortOrder                 int               `json:"sort_order"`
+	IsActive                  bool              `json:"is_active"`
+	Shared                    bool              `json:"shared"`
+	DefaultMinDurationMinutes int               `json:"default_min_duration_minutes"`
+	Amenities                 []AmenityDTO      `json:"amenities"`
+}
+
+// VenueTypeInputDTO - DTO создания и изменения типа площадки администратором.
+// code указывается только при создании
+type VenueTypeInputDTO struct {
+	Code                      string            `json:"code"`
+	Names                     map[string]string `json:"names" binding:"required"`
+	Icon                      string            `json:"icon" binding:"max=255"`
+	SortOrder                 int               `json:"sort_order"`
+	IsActive                  *bool             `json:"is_active"` // По умолчанию true
+	Shared                    bool              `json:"shared"`
+	DefaultMinDurationMinutes int               `json:"default_min_duration_minutes" binding:"min=0"` // 0 - 60 минут
+	Amenities                 []string          `json:"amenities"`                                    // Удобства с type_specific, применимые к площадкам типа
+}
+
+// VenueImportRowDTO - площадка в файле импорта и экспорта (одна строка CSV или элемент JSON-массива).
+// Расписание дня - интервалы "HH:MM-HH:MM" через запятую, пустая строка - выходной.
+// Строка с id изменяет существующую площадку, без id - создаёт новую. status только выгружается
+type VenueImportRowDTO struct {
+	ID        uint     `json:"id,omitempty"`
+	OwnerID   uint     `json:"owner_id,omitempty"`
+	VenueType string   `json:"venue_type"`
+	District  string   `json:"district"`
+	HourPrice int      `json:"hour_price"`
+	Capacity  int      `json:"capacity"`
+	IsActive  *bool    `json:"is_active"` // По умолчанию true
+	Address   string   `json:"address"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Amenities []string `json:"amenities"`
+
+	Monday    string `json:"monday"`
+	Tuesday   string `json:"tuesday"`
+	Wednesday string `json:"wednesday"`
+	Thursday  string `json:"thursday"`
+	Friday    string `json:"friday"`
+	Saturday  string `json:"saturday"`
+	Sunday    string `json:"sunday"`
+
+	Status string `json:"status,omitempty"`
+}
+
+// VenueImportResultDTO - результат проверки или импорта файла
+type VenueImportResultDTO struct {
+	DryRun  bool                      `json:"dry_run"`
+	Applied bool                      `json:"applied"` // Изменения сохранены
+	Created int                       `json:"created"`
+	Updated int                       `json:"updated"`
+	Errors  int                       `json:"errors"` // Строк с ошибками
+	Rows    []VenueImportRowResultDTO `json:"rows"`
+}
+
+// VenueImportRowResultDTO - результат проверки строки файла
+type VenueImportRowResultDTO struct {
+	Line   int    `json:"line"`         // Строка CSV (заголовок - строка 1) или номер элемента JSON с 1
+	ID     uint   `json:"id,omitempty"` // Изменяемая или созданная площадка
+	Action string `json:"action"`       // create или update
+	Error  string `json:"error,omitempty"`
+}
+
+// DeletedVenueDTO - удалённая площадка в корзине администратора
+type DeletedVenueDTO struct {
+	VenueDTO
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAfter time.Time `json:"purge_after"` // С этого момента площадку можно удалить окончательно
+}
+
+type DeletedVenueListDTO struct {
+	Venues []DeletedVenueDTO `json:"venues"`
+	Total  int64             `json:"total"`
+	Page   int               `json:"page"`
+	Limit  int               `json:"limit"`
+}
+
+// BookingRulesDTO - DTO правил бронирования площадки
+// Нулевые max_advance_days, max_duration_minutes и start_step_minutes означают отсутствие ограничения,
+// нулевая min_duration_minutes - значение по умолчанию для типа площадки
+type BookingRulesDTO struct {
+	MinLeadMinutes     int `json:"min_lead_minutes" binding:"min=0"`
+	MaxAdvanceDays     int `json:"max_advance_days" binding:"min=0"`
+	MinDurationMinutes int `json:"min_duration_minutes" binding:"min=0"`
+	MaxDurationMinutes int `json:"max_duration_minutes" binding:"min=0"`
+	StartStepMinutes   int `json:"start_step_minutes" binding:"min=0,max=60"`
+
+	// Бронь без отметки о приходе по QR-коду завершается неявкой
+	CheckInRequired bool `json:"check_in_required"`
+
+	// Политика надёжности: при reliability_limit > 0 клиенты с таким числом неявок и поздних отмен
+	// блокируются (block, по умолчанию) или бронируют только с предоплатой (prepay)
+	LateCancelHours       int    `json:"late_cancel_hours" binding:"min=0"`
+	ReliabilityLimit      int    `json:"reliability_limit" binding:"min=0"`
+	ReliabilityPeriodDays int    `json:"reliability_period_days" binding:"min=0"`
+	ReliabilityAction     string `json:"reliability_action,omitempty" binding:"omitempty,oneof=block prepay"`
+}
+
+// VenueUnitDTO - DTO для бронируемой единицы площадки (корт, дорожка, половина поля)
+// HourPrice = nil означает, что используется цена площадки
+type VenueUnitDTO struct {
+	ID        uint   `json:"id,omitempty"` // Только в ответах
+	ParentID  *uint  `json:"parent_id,omitempty"`
+	Name      string `json:"name" binding:"required,max=100"`
+	HourPrice *int   `json:"hour_price,omitempty" binding:"omitempty,min=0"`
+	IsActive  *bool  `json:"is_active,omitempty"` // По умолчанию true
 }
 
 // ScheduleDTO - DTO для расписания работы площадки (ответ)
@@ -55,11 +276,14 @@ func toDayScheduleDTO(schedule models.DaySchedule) DayScheduleDTO {
 		Enabled: schedule.Enabled,
 	}
 	if schedule.Enabled && schedule.StartTime != nil && schedule.EndTime != nil {
-		startTimeStr := schedule.StartTime.Format("15:04")
-		endTimeStr := schedule.EndTime.Format("15:04")
+		startTimeStr := schedule.StartTime.Format(models.TimeLayout)
+		endTimeStr := schedule.EndTime.Format(models.TimeLayout)
 		dto.StartTime = &startTimeStr
 		dto.EndTime = &endTimeStr
 	}
+	for _, interval := range schedule.Windows() {
+		dto.Intervals = append(dto.Intervals, TimeIntervalDTO{Start: interval.Start, End: interval.End})
+	}
 	return dto
 }
 
@@ -69,9 +293,16 @@ func fromDayScheduleDTO(dto DayScheduleDTO) (models.DaySchedule, error) {
 		Enabled: dto.Enabled,
 	}
 
-	if dto.Enabled {
+	if !dto.Enabled {
+		if len(dto.Intervals) > 0 {
+			return schedule, fmt.Errorf("intervals должны быть пустыми, если день выключен")
+		}
+		return schedule, nil
+	}
+
+	if len(dto.Intervals) == 0 {
 		if dto.StartTime == nil || dto.EndTime == nil {
-			return schedule, fmt.Errorf("start_time и end_time обязательны, если день включен")
+			return schedule, fmt.Errorf("start_time и end_time или intervals обязательны, если день включен")
 		}
 
 		startTime, err := validation.ValidateTime(*dto.StartTime)
@@ -86,41 +317,228 @@ func fromDayScheduleDTO(dto DayScheduleDTO) (models.DaySchedule, error) {
 
 		schedule.StartTime = &startTime
 		schedule.EndTime = &endTime
+		return schedule, nil
+	}
+
+	// Интервалы приводятся к виду "HH:MM", границы дня берутся из первого и последнего интервала
+	times := make([]time.Time, 0, 2*len(dto.Intervals))
+	for i, interval := range dto.Intervals {
+		start, err := validation.ValidateTime(interval.Start)
+		if err != nil {
+			return schedule, fmt.Errorf("интервал %d: неверный формат start: %w", i+1, err)
+		}
+		end, err := validation.ValidateTime(interval.End)
+		if err != nil {
+			return schedule, fmt.Errorf("интервал %d: неверный формат end: %w", i+1, err)
+		}
+		if !start.Before(end) {
+			return schedule, fmt.Errorf("интервал %d: start должен быть раньше end", i+1)
+		}
+		if i > 0 && !start.After(times[len(times)-1]) {
+			return schedule, fmt.Errorf("интервал %d пересекается с предыдущим или примыкает к нему, интервалы должны идти по порядку", i+1)
+		}
+		times = append(times, start, end)
+		schedule.Intervals = append(schedule.Intervals, models.TimeInterval{
+			Start: start.Format(models.TimeLayout),
+			End:   end.Format(models.TimeLayout),
+		})
+	}
+	schedule.StartTime = &times[0]
+	schedule.EndTime = &times[len(times)-1]
+
+	// start_time/end_time вместе с intervals допустимы только как границы интервалов
+	if dto.StartTime != nil && *dto.StartTime != schedule.StartTime.Format(models.TimeLayout) ||
+		dto.EndTime != nil && *dto.EndTime != schedule.EndTime.Format(models.TimeLayout) {
+		return schedule, fmt.Errorf("start_time и end_time должны совпадать с началом первого и концом последнего интервала")
 	}
 
 	return schedule, nil
 }
 
 // ToVenueDTO конвертирует модель Venue в DTO (для ответов)
-func ToVenueDTO(venue *models.Venue) VenueDTO {
-	return VenueDTO{
+func ToVenueDTO(venue *models.Venue, mediaURL MediaURLFunc) VenueDTO {
+	dto := VenueDTO{
 		ID:        venue.ID,
 		VenueType: venue.VenueType,
 		OwnerID:   venue.OwnerID,
 		IsActive:  venue.IsActive,
 		HourPrice: venue.HourPrice,
 		District:  venue.District,
-		Weekdays: WeekdaysDTO{
-			Monday:    toDayScheduleDTO(venue.Weekdays.Monday),
-			Tuesday:   toDayScheduleDTO(venue.Weekdays.Tuesday),
-			Wednesday: toDayScheduleDTO(venue.Weekdays.Wednesday),
-			Thursday:  toDayScheduleDTO(venue.Weekdays.Thursday),
-			Friday:    toDayScheduleDTO(venue.Weekdays.Friday),
-			Saturday:  toDayScheduleDTO(venue.Weekdays.Saturday),
-			Sunday:    toDayScheduleDTO(venue.Weekdays.Sunday),
-		},
+		Capacity:  venue.Capacity,
+		Weekdays:  toWeekdaysDTO(venue.Weekdays),
+	}
+	if len(venue.Units) > 0 {
+		dto.Units = ToVenueUnitDTOList(venue.Units)
+	}
+	rules := ToBookingRulesDTO(venue.BookingRules)
+	dto.BookingRules = &rules
+	dto.Rating = venue.Rating
+	dto.RatingCount = venue.RatingCount
+	dto.Address = venue.Address
+	dto.Latitude = venue.Latitude
+	dto.Longitude = venue.Longitude
+	dto.DistanceKm = venue.DistanceKm
+	dto.Status = venue.Status
+	dto.ModerationComment = venue.ModerationComment
+	dto.SubmittedAt = venue.SubmittedAt
+	dto.ReviewedAt = venue.ReviewedAt
+	dto.Revision = venue.Revision
+	dto.Version = venue.Version
+	for _, a := range venue.Amenities {
+		dto.Amenities = append(dto.Amenities, a.Code)
+	}
+	if len(venue.Photos) > 0 {
+		dto.Photos = ToPhotoDTOList(venue.Photos, mediaURL)
+		for i := range dto.Photos {
+			if dto.Photos[i].IsCover {
+				cover := dto.Photos[i]
+				dto.Cover = &cover
+			}
+		}
+	}
+	return dto
+}
+
+// ToBookingRulesDTO конвертирует правила бронирования модели в DTO
+func ToBookingRulesDTO(rules models.BookingRules) BookingRulesDTO {
+	return BookingRulesDTO{
+		MinLeadMinutes:     rules.MinLeadMinutes,
+		MaxAdvanceDays:     rules.MaxAdvanceDays,
+		MinDurationMinutes: rules.MinDurationMinutes,
+		MaxDurationMinutes: rules.MaxDurationMinutes,
+		StartStepMinutes:   rules.StartStepMinutes,
+		CheckInRequired:    rules.CheckInRequired,
+
+		LateCancelHours:       rules.LateCancelHours,
+		ReliabilityLimit:      rules.ReliabilityLimit,
+		ReliabilityPeriodDays: rules.ReliabilityPeriodDays,
+		ReliabilityAction:     string(rules.ReliabilityAction),
+	}
+}
+
+// FromBookingRulesDTO конвертирует DTO правил бронирования в модель
+// Возвращает ошибку, если правила несогласованы
+func FromBookingRulesDTO(dto *BookingRulesDTO) (models.BookingRules, error) {
+	// Без правил и при нулевой минимальной длительности её подставляет сервис по типу площадки
+	if dto == nil {
+		return models.BookingRules{}, nil
+	}
+
+	rules := models.BookingRules{
+		MinLeadMinutes:     dto.MinLeadMinutes,
+		MaxAdvanceDays:     dto.MaxAdvanceDays,
+		MinDurationMinutes: dto.MinDurationMinutes,
+		MaxDurationMinutes: dto.MaxDurationMinutes,
+		StartStepMinutes:   dto.StartStepMinutes,
+		CheckInRequired:    dto.CheckInRequired,
+
+		LateCancelHours:       dto.LateCancelHours,
+		ReliabilityLimit:      dto.ReliabilityLimit,
+		ReliabilityPeriodDays: dto.ReliabilityPeriodDays,
+		ReliabilityAction:     models.ReliabilityAction(dto.ReliabilityAction),
+	}
+	if rules.ReliabilityLimit > 0 && rules.ReliabilityAction == "" {
+		rules.ReliabilityAction = models.ReliabilityBlock
+	}
+	if err := rules.WithDefaults(1).Validate(); err != nil {
+		return models.BookingRules{}, err
+	}
+	return rules, nil
+}
+
+// ToVenueUnitDTO конвертирует модель VenueUnit в DTO
+func ToVenueUnitDTO(unit *models.VenueUnit) VenueUnitDTO {
+	isActive := unit.IsActive
+	return VenueUnitDTO{
+		ID:        unit.ID,
+		ParentID:  unit.ParentID,
+		Name:      unit.Name,
+		HourPrice: unit.HourPrice,
+		IsActive:  &isActive,
+	}
+}
+
+// ToVenueUnitDTOList конвертирует список моделей VenueUnit в список DTO
+func ToVenueUnitDTOList(units []models.VenueUnit) []VenueUnitDTO {
+	dtoList := make([]VenueUnitDTO, len(units))
+	for i := range units {
+		dtoList[i] = ToVenueUnitDTO(&units[i])
+	}
+	return dtoList
+}
+
+// FromVenueUnitDTO конвертирует DTO в модель VenueUnit
+func FromVenueUnitDTO(dto *VenueUnitDTO) *models.VenueUnit {
+	isActive := true
+	if dto.IsActive != nil {
+		isActive = *dto.IsActive
+	}
+	return &models.VenueUnit{
+		ParentID:  dto.ParentID,
+		Name:      dto.Name,
+		HourPrice: dto.HourPrice,
+		IsActive:  isActive,
 	}
 }
 
+// VenueListDTO - страница списка площадок.
+// next_cursor передается в cursor для следующей страницы, пустой - страница последняя
+type VenueListDTO struct {
+	Venues     []VenueDTO `json:"venues"`
+	Total      int64      `json:"total"`
+	Page       int        `json:"page,omitempty"` // Только при пагинации по page
+	Limit      int        `json:"limit"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
 // ToVenueDTOList конвертирует список моделей Venue в список DTO
-func ToVenueDTOList(venues []models.Venue) []VenueDTO {
+// В списке у площадок загружена только обложка, поэтому photos не отдается
+func ToVenueDTOList(venues []models.Venue, mediaURL MediaURLFunc) []VenueDTO {
 	dtoList := make([]VenueDTO, len(venues))
 	for i := range venues {
-		dtoList[i] = ToVenueDTO(&venues[i])
+		dtoList[i] = ToVenueDTO(&venues[i], mediaURL)
+		dtoList[i].Photos = nil
 	}
 	return dtoList
 }
 
+// ToDeletedVenueDTOList конвертирует удалённые площадки в список DTO, как ToVenueDTOList - без галереи
+func ToDeletedVenueDTOList(venues []services.DeletedVenue, mediaURL MediaURLFunc) []DeletedVenueDTO {
+	dtoList := make([]DeletedVenueDTO, len(venues))
+	for i := range venues {
+		dtoList[i] = DeletedVenueDTO{
+			VenueDTO:   ToVenueDTO(&venues[i].Venue, mediaURL),
+			DeletedAt:  venues[i].DeletedAt.Time,
+			PurgeAfter: venues[i].PurgeAfter,
+		}
+		dtoList[i].Photos = nil
+	}
+	return dtoList
+}
+
+// ToPhotoDTO конвертирует фотографию в DTO
+func ToPhotoDTO(photo *models.VenuePhoto, mediaURL MediaURLFunc) PhotoDTO {
+	return PhotoDTO{
+		ID:        photo.ID,
+		Position:  photo.Position,
+		IsCover:   photo.IsCover,
+		Width:     photo.Width,
+		Height:    photo.Height,
+		URL:       mediaURL(photo.OriginalKey),
+		MediumURL: mediaURL(photo.MediumKey),
+		ThumbURL:  mediaURL(photo.ThumbKey),
+	}
+}
+
+// ToPhotoDTOList конвертирует список фотографий в DTO
+func ToPhotoDTOList(photos []models.VenuePhoto, mediaURL MediaURLFunc) []PhotoDTO {
+	result := make([]PhotoDTO, len(photos))
+	for i := range photos {
+		result[i] = ToPhotoDTO(&photos[i], mediaURL)
+	}
+	return result
+}
+
 // fromWeekdaysDTO конвертирует WeekdaysDTO в models.Weekdays
 func fromWeekdaysDTO(dto WeekdaysDTO) (models.Weekdays, error) {
 	dayNames := []struct {
@@ -164,13 +582,37 @@ func FromVenueDTO(dto *VenueDTO) (*models.Venue, error) {
 		return nil, err
 	}
 
+	capacity := dto.Capacity
+	if capacity == 0 {
+		capacity = 1
+	}
+
+	rules, err := FromBookingRulesDTO(dto.BookingRules)
+	if err != nil {
+		return nil, err
+	}
+
+	if (dto.Latitude == nil) != (dto.Longitude == nil) {
+		return nil, fmt.Errorf("latitude и longitude указываются вместе")
+	}
+
 	venue := &models.Venue{
 		VenueType: dto.VenueType,
 		OwnerID:   dto.OwnerID,
 		IsActive:  dto.IsActive,
 		HourPrice: dto.HourPrice,
 		District:  dto.District,
+		Capacity:  capacity,
 		Weekdays:  weekdays,
+
+		BookingRules: rules,
+
+		Address:   dto.Address,
+		Latitude:  dto.Latitude,
+		Longitude: dto.Longitude,
+	}
+	for _, code := range dto.Amenities {
+		venue.Amenities = append(venue.Amenities, models.VenueAmenity{Code: code})
 	}
 
 	// Если есть ID (для обновления), устанавливаем его
@@ -181,18 +623,39 @@ func FromVenueDTO(dto *VenueDTO) (*models.Venue, error) {
 	return venue, nil
 }
 
+// toWeekdaysDTO конвертирует расписание модели в DTO
+func toWeekdaysDTO(weekdays models.Weekdays) WeekdaysDTO {
+	return WeekdaysDTO{
+		Monday:    toDayScheduleDTO(weekdays.Monday),
+		Tuesday:   toDayScheduleDTO(weekdays.Tuesday),
+		Wednesday: toDayScheduleDTO(weekdays.Wednesday),
+		Thursday:  toDayScheduleDTO(weekdays.Thursday),
+		Friday:    toDayScheduleDTO(weekdays.Friday),
+		Saturday:  toDayScheduleDTO(weekdays.Saturday),
+		Sunday:    toDayScheduleDTO(weekdays.Sunday),
+	}
+}
+
+// ToVenueRevisionDTO конвертирует версию площадки в DTO
+func ToVenueRevisionDTO(revision *models.VenueRevision) VenueRevisionDTO {
+	changes := revision.Changes
+	if changes == nil {
+		changes = []string{}
+	}
+	return VenueRevisionDTO{
+		Revision:  revision.Revision,
+		HourPrice: revision.HourPrice,
+		Weekdays:  toWeekdaysDTO(revision.Weekdays),
+		Changes:   changes,
+		ChangedBy: revision.ChangedBy,
+		ChangedAt: revision.CreatedAt,
+	}
+}
+
 // ToScheduleDTO конвертирует модель Venue в ScheduleDTO
 func ToScheduleDTO(venue *models.Venue) ScheduleDTO {
 	return ScheduleDTO{
-		Weekdays: WeekdaysDTO{
-			Monday:    toDayScheduleDTO(venue.Weekdays.Monday),
-			Tuesday:   toDayScheduleDTO(venue.Weekdays.Tuesday),
-			Wednesday: toDayScheduleDTO(venue.Weekdays.Wednesday),
-			Thursday:  toDayScheduleDTO(venue.Weekdays.Thursday),
-			Friday:    toDayScheduleDTO(venue.Weekdays.Friday),
-			Saturday:  toDayScheduleDTO(venue.Weekdays.Saturday),
-			Sunday:    toDayScheduleDTO(venue.Weekdays.Sunday),
-		},
+		Weekdays: toWeekdaysDTO(venue.Weekdays),
 	}
 }
 
@@ -204,3 +667,413 @@ func FromScheduleUpdateDTO(dto *ScheduleUpdateDTO) (*models.Weekdays, error) {
 	}
 	return &weekdays, nil
 }
+
+// ReviewDTO - DTO отзыва о площадке (ответ)
+type ReviewDTO struct {
+	ID           uint       `json:"id"`
+	VenueID      uint       `json:"venue_id"`
+	BookingID    uint       `json:"booking_id"`
+	ClientID     uint       `json:"client_id"`
+	Rating       int        `json:"rating"`
+	Text         string     `json:"text"`
+	Reply        *string    `json:"reply,omitempty"`
+	RepliedAt    *time.Time `json:"replied_at,omitempty"`
+	IsHidden     bool       `json:"is_hidden,omitempty"`     // Видно только администратору
+	HiddenReason string     `json:"hidden_reason,omitempty"` // Видно только администратору
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CreateReviewDTO - DTO для создания отзыва по завершённой брони
+type CreateReviewDTO struct {
+	BookingID uint   `json:"booking_id" binding:"required,min=1"`
+	Rating    int    `json:"rating" binding:"required,min=1,max=5"`
+	Text      string `json:"text" binding:"max=2000"`
+}
+
+// ReviewReplyDTO - DTO ответа владельца на отзыв
+type ReviewReplyDTO struct {
+	Text string `json:"text" binding:"required,max=2000"`
+}
+
+// ReviewVisibilityDTO - DTO скрытия отзыва администратором
+type ReviewVisibilityDTO struct {
+	Hidden bool   `json:"hidden"`
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// ReviewListDTO - страница отзывов площадки
+type ReviewListDTO struct {
+	Reviews []ReviewDTO `json:"reviews"`
+	Total   int64       `json:"total"`
+	Page    int         `json:"page"`
+	Limit   int         `json:"limit"`
+}
+
+// ToReviewDTO конвертирует модель Review в DTO
+func ToReviewDTO(review *models.Review) ReviewDTO {
+	return ReviewDTO{
+		ID:           review.ID,
+		VenueID:      review.VenueID,
+		BookingID:    review.BookingID,
+		ClientID:     review.ClientID,
+		Rating:       review.Rating,
+		Text:         review.Text,
+		Reply:        review.Reply,
+		RepliedAt:    review.RepliedAt,
+		IsHidden:     review.IsHidden,
+		HiddenReason: review.HiddenReason,
+		CreatedAt:    review.CreatedAt,
+	}
+}
+
+// ToReviewDTOList конвертирует список отзывов в DTO
+func ToReviewDTOList(reviews []models.Review) []ReviewDTO {
+	dtos := make([]ReviewDTO, len(reviews))
+	for i := range reviews {
+		dtos[i] = ToReviewDTO(&reviews[i])
+	}
+	return dtos
+}
+
+// ToVenueTypeDTO конвертирует тип площадки в DTO с названием на языке locale
+func ToVenueTypeDTO(venueType *models.VenueTypeDefinition, locale string) VenueTypeDTO {
+	return VenueTypeDTO{
+		Value:                     venueType.Code.String(),
+		Label:                     venueType.Name(locale),
+		Names:                     venueType.Names,
+		Icon:                      venueType.Icon,
+		SortOrder:                 venueType.SortOrder,
+		IsActive:                  venueType.IsActive,
+		Shared:                    venueType.Shared,
+		DefaultMinDurationMinutes: venueType.DefaultMinDurationMinutes,
+		Amenities:                 ToAmenityDTOList(models.AmenitiesFor(venueType)),
+	}
+}
+
+// FromVenueTypeInputDTO конвертирует DTO типа площадки в модель
+func FromVenueTypeInputDTO(dto *VenueTypeInputDTO) *models.VenueTypeDefinition {
+	isActive := true
+	if dto.IsActive != nil {
+		isActive = *dto.IsActive
+	}
+	minDuration := dto.DefaultMinDurationMinutes
+	if minDuration == 0 {
+		minDuration = models.DefaultMinDurationMinutes
+	}
+	return &models.VenueTypeDefinition{
+		Code:                      models.VenueType(dto.Code),
+		Names:                     models.LocalizedNames(dto.Names),
+		Icon:                      dto.Icon,
+		SortOrder:                 dto.SortOrder,
+		IsActive:                  isActive,
+		Shared:                    dto.Shared,
+		DefaultMinDurationMinutes: minDuration,
+		Amenities:                 models.AmenityCodes(dto.Amenities),
+	}
+}
+
+// ToAmenityDTOList конвертирует элементы каталога удобств в DTO
+func ToAmenityDTOList(amenities []models.Amenity) []AmenityDTO {
+	result := make([]AmenityDTO, 0, len(amenities))
+	for _, a := range amenities {
+		result = append(result, AmenityDTO{Code: a.Code, Label: a.Label, Group: a.Group})
+	}
+	return result
+}
+
+// ToVenueImportRowDTO конвертирует площадку в строку файла экспорта
+func ToVenueImportRowDTO(venue *models.Venue) VenueImportRowDTO {
+	isActive := venue.IsActive
+	dto := VenueImportRowDTO{
+		ID:        venue.ID,
+		OwnerID:   venue.OwnerID,
+		VenueType: venue.VenueType.String(),
+		District:  venue.District,
+		HourPrice: venue.HourPrice,
+		Capacity:  venue.Capacity,
+		IsActive:  &isActive,
+		Address:   venue.Address,
+		Latitude:  venue.Latitude,
+		Longitude: venue.Longitude,
+		Amenities: make([]string, 0, len(venue.Amenities)),
+
+		Monday:    formatDaySchedule(venue.Weekdays.Monday),
+		Tuesday:   formatDaySchedule(venue.Weekdays.Tuesday),
+		Wednesday: formatDaySchedule(venue.Weekdays.Wednesday),
+		Thursday:  formatDaySchedule(venue.Weekdays.Thursday),
+		Friday:    formatDaySchedule(venue.Weekdays.Friday),
+		Saturday:  formatDaySchedule(venue.Weekdays.Saturday),
+		Sunday:    formatDaySchedule(venue.Weekdays.Sunday),
+
+		Status: string(venue.Status),
+	}
+	for _, a := range venue.Amenities {
+		dto.Amenities = append(dto.Amenities, a.Code)
+	}
+	return dto
+}
+
+// FromVenueImportRowDTO конвертирует строку файла импорта в модель.
+// Время проверяется так же, как в WeekdaysDTO
+func FromVenueImportRowDTO(dto *VenueImportRowDTO) (*models.Venue, error) {
+	venueType := models.VenueType(strings.TrimSpace(dto.VenueType))
+	if !venueType.IsValid() {
+		return nil, fmt.Errorf("неверный тип площадки: %q", dto.VenueType)
+	}
+	district := strings.TrimSpace(dto.District)
+	if district == "" {
+		return nil, fmt.Errorf("district обязателен")
+	}
+	if dto.HourPrice < 0 {
+		return nil, fmt.Errorf("hour_price не может быть отрицательным")
+	}
+	if dto.Capacity < 0 {
+		return nil, fmt.Errorf("capacity не может быть отрицательной")
+	}
+	if len(dto.Address) > 255 {
+		return nil, fmt.Errorf("address длиннее 255 символов")
+	}
+
+	weekdays := WeekdaysDTO{}
+	days := []struct {
+		name   string
+		value  string
+		target *DayScheduleDTO
+	}{
+		{"понедельник", dto.Monday, &weekdays.Monday},
+		{"вторник", dto.Tuesday, &weekdays.Tuesday},
+		{"среда", dto.Wednesday, &weekdays.Wednesday},
+		{"четверг", dto.Thursday, &weekdays.Thursday},
+		{"пятница", dto.Friday, &weekdays.Friday},
+		{"суббота", dto.Saturday, &weekdays.Saturday},
+		{"воскресенье", dto.Sunday, &weekdays.Sunday},
+	}
+	for _, day := range days {
+		schedule, err := parseDaySchedule(day.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", day.name, err)
+		}
+		*day.target = schedule
+	}
+
+	isActive := true
+	if dto.IsActive != nil {
+		isActive = *dto.IsActive
+	}
+	return FromVenueDTO(&VenueDTO{
+		ID:        dto.ID,
+		VenueType: venueType,
+		OwnerID:   dto.OwnerID,
+		IsActive:  isActive,
+		HourPrice: dto.HourPrice,
+		District:  district,
+		Capacity:  dto.Capacity,
+		Weekdays:  weekdays,
+		Address:   strings.TrimSpace(dto.Address),
+		Latitude:  dto.Latitude,
+		Longitude: dto.Longitude,
+		Amenities: dto.Amenities,
+	})
+}
+
+// parseDaySchedule разбирает расписание дня из файла импорта: "08:00-22:00" или "07:00-08:30,17:00-22:00".
+// Пустая строка - выходной
+func parseDaySchedule(value string) (DayScheduleDTO, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DayScheduleDTO{Enabled: false}, nil
+	}
+
+	dto := DayScheduleDTO{Enabled: true}
+	for _, part := range strings.Split(value, ",") {
+		start, end, ok := strings.Cut(strings.TrimSpace(part), "-")
+		if !ok {
+			return dto, fmt.Errorf("неверный интервал %q, ожидается HH:MM-HH:MM", part)
+		}
+		dto.Intervals = append(dto.Intervals, TimeIntervalDTO{Start: strings.TrimSpace(start), End: strings.TrimSpace(end)})
+	}
+	// Один интервал хранится как у площадок, созданных через API
+	if len(dto.Intervals) == 1 {
+		dto.StartTime, dto.EndTime = &dto.Intervals[0].Start, &dto.Intervals[0].End
+		dto.Intervals = nil
+	}
+	return dto, nil
+}
+
+// formatDaySchedule записывает расписание дня в формате файла импорта
+func formatDaySchedule(day models.DaySchedule) string {
+	windows := day.Windows()
+	parts := make([]string, 0, len(windows))
+	for _, w := range windows {
+		parts = append(parts, w.Start+"-"+w.End)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ToVenueImportResultDTO конвертирует результат импорта в DTO
+func ToVenueImportResultDTO(result *services.ImportResult) VenueImportResultDTO {
+	dto := VenueImportResultDTO{
+		DryRun:  result.DryRun,
+		Applied: result.Applied,
+		Created: result.Created,
+		Updated: result.Updated,
+		Errors:  result.Errors,
+		Rows:    make([]VenueImportRowResultDTO, 0, len(result.Rows)),
+	}
+	for _, row := range result.Rows {
+		dto.Rows = append(dto.Rows, VenueImportRowResultDTO{Line: row.Line, ID: row.ID, Action: row.Action, Error: row.Error})
+	}
+	return dto
+}
+
+// SavedSearchDTO - сохранённый поиск площадок
+type SavedSearchDTO struct {
+	ID        uint                 `json:"id,omitempty"` // Только в ответах
+	Name      string               `json:"name" binding:"required,max=100"`
+	Filter    SavedSearchFilterDTO `json:"filter"`
+	Time      TimePreferenceDTO    `json:"time"`
+	CreatedAt *time.Time           `json:"created_at,omitempty"` // Только в ответах
+	UpdatedAt *time.Time           `json:"updated_at,omitempty"` // Только в ответах
+}
+
+// SavedSearchFilterDTO - фильтр поиска, поля названы как query-параметры GET /venues
+type SavedSearchFilterDTO struct {
+	District  []string `json:"district,omitempty"`
+	VenueType []string `json:"venue_type,omitempty"`
+	MinPrice  int      `json:"min_price,omitempty" binding:"omitempty,min=0"`
+	MaxPrice  int      `json:"max_price,omitempty" binding:"omitempty,min=0"`
+	MinRating float64  `json:"min_rating,omitempty" binding:"omitempty,min=0,max=5"`
+
+	Lat      *float64 `json:"lat,omitempty" binding:"omitempty,min=-90,max=90"`
+	Lng      *float64 `json:"lng,omitempty" binding:"omitempty,min=-180,max=180"`
+	RadiusKm float64  `json:"radius_km,omitempty" binding:"omitempty,gt=0,max=500"`
+
+	MinLat *float64 `json:"min_lat,omitempty" binding:"omitempty,min=-90,max=90"`
+	MinLng *float64 `json:"min_lng,omitempty" binding:"omitempty,min=-180,max=180"`
+	MaxLat *float64 `json:"max_lat,omitempty" binding:"omitempty,min=-90,max=90"`
+	MaxLng *float64 `json:"max_lng,omitempty" binding:"omitempty,min=-180,max=180"`
+
+	Amenities      []string `json:"amenities,omitempty"`
+	AmenitiesMatch string   `json:"amenities_match,omitempty" binding:"omitempty,oneof=all any"`
+}
+
+// TimePreferenceDTO - удобное время: пустые days - любой день, пустые from и to - весь день
+type TimePreferenceDTO struct {
+	Days []string `json:"days,omitempty" binding:"omitempty,dive,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+	From string   `json:"from,omitempty"` // Формат "HH:MM"
+	To   string   `json:"to,omitempty"`   // Формат "HH:MM", не включая
+}
+
+// weekdayNames - названия дней недели в API
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "monday",
+	time.Tuesday:   "tuesday",
+	time.Wednesday: "wednesday",
+	time.Thursday:  "thursday",
+	time.Friday:    "friday",
+	time.Saturday:  "saturday",
+	time.Sunday:    "sunday",
+}
+
+// query представляет фильтр как параметры GET /venues, чтобы разобрать его теми же правилами
+func (dto *SavedSearchFilterDTO) query() GetVenuesQuery {
+	return GetVenuesQuery{
+		District:       dto.District,
+		VenueType:      dto.VenueType,
+		MinPrice:       dto.MinPrice,
+		MaxPrice:       dto.MaxPrice,
+		MinRating:      dto.MinRating,
+		Lat:            dto.Lat,
+		Lng:            dto.Lng,
+		RadiusKm:       dto.RadiusKm,
+		MinLat:         dto.MinLat,
+		MinLng:         dto.MinLng,
+		MaxLat:         dto.MaxLat,
+		MaxLng:         dto.MaxLng,
+		Amenities:      dto.Amenities,
+		AmenitiesMatch: dto.AmenitiesMatch,
+	}
+}
+
+// FromSavedSearchDTO конвертирует DTO в модель, проверяя фильтр как GET /venues
+func FromSavedSearchDTO(dto *SavedSearchDTO) (*models.SavedSearch, error) {
+	query := dto.Filter.query()
+	filter := models.SavedSearchFilter{
+		Districts:      splitValues(query.District),
+		MinRating:      query.MinRating,
+		AmenitiesMatch: query.AmenitiesMatch,
+	}
+
+	var err error
+	if filter.MinPrice, filter.MaxPrice, err = query.priceRange(); err != nil {
+		return nil, err
+	}
+	if filter.VenueTypes, err = query.venueTypes(); err != nil {
+		return nil, err
+	}
+	if filter.Geo, filter.BBox, err = query.geoFilter(); err != nil {
+		return nil, err
+	}
+	if filter.Amenities, err = query.amenityCodes(); err != nil {
+		return nil, err
+	}
+
+	preference := models.TimePreference{From: dto.Time.From, To: dto.Time.To}
+	for _, name := range dto.Time.Days {
+		for day, dayName := range weekdayNames {
+			if dayName == name && !slices.Contains(preference.Days, day) {
+				preference.Days = append(preference.Days, day)
+			}
+		}
+	}
+	slices.Sort(preference.Days)
+
+	return &models.SavedSearch{
+		Name:   strings.TrimSpace(dto.Name),
+		Filter: filter,
+		Time:   preference,
+	}, nil
+}
+
+// ToSavedSearchDTO конвертирует модель сохранённого поиска в DTO
+func ToSavedSearchDTO(search *models.SavedSearch) SavedSearchDTO {
+	filter := search.Filter
+	dto := SavedSearchDTO{
+		ID:   search.ID,
+		Name: search.Name,
+		Filter: SavedSearchFilterDTO{
+			District:       filter.Districts,
+			MinPrice:       filter.MinPrice,
+			MaxPrice:       filter.MaxPrice,
+			MinRating:      filter.MinRating,
+			Amenities:      filter.Amenities,
+			AmenitiesMatch: filter.AmenitiesMatch,
+		},
+		Time:      TimePreferenceDTO{From: search.Time.From, To: search.Time.To},
+		CreatedAt: &search.CreatedAt,
+		UpdatedAt: &search.UpdatedAt,
+	}
+	for _, venueType := range filter.VenueTypes {
+		dto.Filter.VenueType = append(dto.Filter.VenueType, string(venueType))
+	}
+	if geo := filter.Geo; geo != nil {
+		dto.Filter.Lat, dto.Filter.Lng, dto.Filter.RadiusKm = &geo.Lat, &geo.Lng, geo.RadiusKm
+	}
+	if box := filter.BBox; box != nil {
+		dto.Filter.MinLat, dto.Filter.MinLng = &box.MinLat, &box.MinLng
+		dto.Filter.MaxLat, dto.Filter.MaxLng = &box.MaxLat, &box.MaxLng
+	}
+	for _, day := range search.Time.Days {
+		dto.Time.Days = append(dto.Time.Days, weekdayNames[day])
+	}
+	return dto
+}
+
+// ToSavedSearchDTOList конвертирует список сохранённых поисков в DTO
+func ToSavedSearchDTOList(searches []models.SavedSearch) []SavedSearchDTO {
+	result := make([]SavedSearchDTO, len(searches))
+	for i := range searches {
+		result[i] = ToSavedSearchDTO(&searches[i])
+	}
+	return result
+}
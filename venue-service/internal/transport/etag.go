@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errWeakETag    = errors.New("If-Match принимает только сильный ETag")
+	errInvalidETag = errors.New("неверный формат ETag")
+	errManyETags   = errors.New("If-Match должен содержать один ETag")
+)
+
+// venueETag - сильный ETag площадки по её версии
+func venueETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseETag разбирает сильный ETag вида "12" в версию площадки
+func parseETag(tag string) (int, error) {
+	if strings.HasPrefix(tag, "W/") {
+		return 0, errWeakETag
+	}
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, errInvalidETag
+	}
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version <= 0 {
+		return 0, errInvalidETag
+	}
+	return version, nil
+}
+
+// notModified сообщает, совпадает ли текущая версия с одним из ETag из If-None-Match.
+// Сравнение слабое: W/"12" совпадает с "12"
+func notModified(c *gin.Context, version int) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	current := venueETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
+
+// writeVersioned отдаёт ответ с ETag площадки или 304, если у клиента актуальная версия
+func writeVersioned(c *gin.Context, version int, body any) {
+	c.Header("ETag", venueETag(version))
+	if notModified(c, version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// ifMatchVersion возвращает версию площадки из If-Match, 0 - заголовок не указан или "*".
+// При ошибке ответ уже записан
+func ifMatchVersion(c *gin.Context) (int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": errManyETags.Error(),
+		})
+		return 0, errManyETags
+	}
+	version, err := parseETag(header)
+	if err != nil {
+		// Слабый или чужой ETag не может совпасть с версией площадки
+		c.JSON(http.StatusPreconditionFailed, gin.H{
+			"error": err.Error(),
+		})
+		return 0, err
+	}
+	return version, nil
+}
@@ -0,0 +1,146 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"venue-service/internal/middleware"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FavoritesQuery - параметры списка избранных площадок
+type FavoritesQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type FavoriteHandler struct {
+	service   services.FavoriteService
+	logger    *slog.Logger
+	jwtSecret string
+	mediaURL  MediaURLFunc
+}
+
+func NewFavoriteHandler(service services.FavoriteService, logger *slog.Logger, jwtSecret string, mediaURL MediaURLFunc) *FavoriteHandler {
+	return &FavoriteHandler{
+		service:   service,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+		mediaURL:  mediaURL,
+	}
+}
+
+func (h *FavoriteHandler) RegisterRoutes(r *gin.Engine) {
+	venues := r.Group("/venues", middleware.AuthMiddleware(h.jwtSecret))
+	{
+		venues.GET("/favorites", h.GetList)
+		venues.PUT("/:id/favorite", h.Add)
+		venues.DELETE("/:id/favorite", h.Remove)
+	}
+}
+
+// GetList - избранные площадки пользователя, недавно добавленные первыми
+func (h *FavoriteHandler) GetList(c *gin.Context) {
+	var query FavoritesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Ошибка парсинга query параметров", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	venues, total, err := h.service.GetList(claims, query.Page, query.Limit)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения избранных площадок")
+		return
+	}
+
+	dtos := ToVenueDTOList(venues, h.mediaURL)
+	isFavorite := true
+	for i := range dtos {
+		dtos[i].IsFavorite = &isFavorite
+	}
+	c.JSON(http.StatusOK, VenueListDTO{
+		Venues: dtos,
+		Total:  total,
+		Page:   query.Page,
+		Limit:  query.Limit,
+	})
+}
+
+func (h *FavoriteHandler) Add(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Add(claims, id); err != nil {
+		h.writeError(c, err, "Ошибка добавления площадки в избранное", "venue_id", id)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FavoriteHandler) Remove(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Remove(claims, id); err != nil {
+		h.writeError(c, err, "Ошибка удаления площадки из избранного", "venue_id", id)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *FavoriteHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrVenueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// parseID вспомогательная функция для парсинга ID площадки из параметра пути
+func (h *FavoriteHandler) parseID(c *gin.Context) (uint, error) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		h.logger.Error("Неверный формат ID", "id", idStr, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "неверный формат ID",
+		})
+		if err == nil {
+			err = strconv.ErrRange
+		}
+		return 0, err
+	}
+	return uint(id), nil
+}
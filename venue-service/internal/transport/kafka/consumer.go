@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"venue-service/internal/config"
+	"venue-service/internal/services"
+
+	"contracts/events"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Пауза перед повторной обработкой сообщения, которое не удалось обработать
+const retryDelay = 5 * time.Second
+
+// Consumer читает отмены броней: освободившееся время проверяется по сохранённым поискам
+type Consumer struct {
+	searches       services.SavedSearchService
+	logger         *slog.Logger
+	brokers        []string
+	groupID        string
+	cancelledTopic string
+}
+
+func NewConsumer(searches services.SavedSearchService, brokers []string, logger *slog.Logger) *Consumer {
+	return &Consumer{
+		searches:       searches,
+		logger:         logger.With("layer", "transport"),
+		brokers:        brokers,
+		groupID:        config.GetEnv("KAFKA_GROUP_ID", "venue-service"),
+		cancelledTopic: config.GetEnv("KAFKA_TOPIC_BOOKING_CANCELLED", events.TopicBookingCancelled),
+	}
+}
+
+func (c *Consumer) Start(ctx context.Context) {
+	if len(c.brokers) == 0 {
+		c.logger.Warn("Kafka brokers not configured, consumer disabled")
+		return
+	}
+
+	go c.consumeBookingCancelled(ctx)
+}
+
+func (c *Consumer) consumeBookingCancelled(ctx context.Context) {
+	reader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers: c.brokers,
+		GroupID: c.groupID,
+		Topic:   c.cancelledTopic,
+	})
+	defer reader.Close()
+
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Error("ошибка чтения сообщения booking.cancelled", "error", err)
+			continue
+		}
+
+		// Смещение фиксируется только после обработки: при ошибке сообщение обрабатывается повторно.
+		// Повтор безопасен, уведомления о совпадении не дублируются
+		for c.handleBookingCancelled(ctx, msg.Value) != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+		}
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Error("ошибка фиксации смещения booking.cancelled", "error", err, "offset", msg.Offset)
+		}
+	}
+}
+
+// handleBookingCancelled обрабатывает отмену брони. Ошибка возвращается, только если
+// обработку стоит повторить; неразборчивые сообщения пропускаются
+func (c *Consumer) handleBookingCancelled(ctx context.Context, value []byte) error {
+	env, event, err := events.Decode[events.BookingCancelledV1](value)
+	if err != nil {
+		c.logger.Error("ошибка разбора booking.cancelled", "error", err, "event_id", env.EventID)
+		return nil
+	}
+	slot, ok := freedSlot(event)
+	if !ok {
+		return nil
+	}
+
+	if err := c.searches.SlotFreed(ctx, slot); err != nil {
+		c.logger.Error("ошибка проверки сохранённых поисков по отмене брони", "error", err, "booking_id", event.BookingID)
+		return err
+	}
+	return nil
+}
+
+// freedSlot переводит отмену брони в освободившееся время.
+// В событиях старых версий reservation-service времени брони нет - такие пропускаются
+func freedSlot(event events.BookingCancelledV1) (services.FreedSlot, bool) {
+	if event.VenueID == 0 || !event.StartAt.Before(event.EndAt) {
+		return services.FreedSlot{}, false
+	}
+	return services.FreedSlot{
+		BookingID: event.BookingID,
+		VenueID:   event.VenueID,
+		UnitID:    event.UnitID,
+		ClientID:  event.ClientID,
+		StartAt:   event.StartAt,
+		EndAt:     event.EndAt,
+	}, true
+}
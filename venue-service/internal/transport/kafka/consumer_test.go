@@ -0,0 +1,79 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	"venue-service/internal/services"
+
+	"contracts/events"
+	"contracts/events/fixtures"
+)
+
+func TestBookingCancelledFixtureToFreedSlot(t *testing.T) {
+	_, event, err := events.Decode[events.BookingCancelledV1](fixtures.Load("booking_cancelled.v1.json"))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	slot, ok := freedSlot(event)
+	if !ok {
+		t.Fatal("отмена брони не дала освободившееся время")
+	}
+	if slot.BookingID != 42 || slot.VenueID != event.VenueID || slot.UnitID == nil || *slot.UnitID != 3 {
+		t.Fatalf("unexpected slot: %+v", slot)
+	}
+	if !slot.StartAt.Equal(time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC)) || !slot.EndAt.Equal(time.Date(2026, 1, 25, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("время %s - %s", slot.StartAt, slot.EndAt)
+	}
+
+	// События без времени брони пропускаются
+	event.StartAt, event.EndAt = time.Time{}, time.Time{}
+	if _, ok := freedSlot(event); ok {
+		t.Fatal("событие без времени брони не пропущено")
+	}
+}
+
+// fakeSavedSearches запоминает освободившееся время и возвращает заданную ошибку
+type fakeSavedSearches struct {
+	services.SavedSearchService
+	err   error
+	slots []services.FreedSlot
+}
+
+func (s *fakeSavedSearches) SlotFreed(ctx context.Context, slot services.FreedSlot) error {
+	s.slots = append(s.slots, slot)
+	return s.err
+}
+
+// Ошибку обработки нужно повторить, а неразборчивое сообщение - пропустить, иначе оно заблокирует партицию
+func TestHandleBookingCancelled(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     []byte
+		slotErr   error
+		wantErr   bool
+		wantSlots int
+	}{
+		{name: "обработано", value: fixtures.Load("booking_cancelled.v1.json"), wantSlots: 1},
+		{name: "ошибка обработки", value: fixtures.Load("booking_cancelled.v1.json"), slotErr: errors.New("db down"), wantErr: true, wantSlots: 1},
+		{name: "неразборчивое сообщение", value: []byte("{"), slotErr: errors.New("db down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searches := &fakeSavedSearches{err: tt.slotErr}
+			consumer := &Consumer{searches: searches, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+			err := consumer.handleBookingCancelled(context.Background(), tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+			if len(searches.slots) != tt.wantSlots {
+				t.Fatalf("обработано %d отмен, ожидалось %d", len(searches.slots), tt.wantSlots)
+			}
+		})
+	}
+}
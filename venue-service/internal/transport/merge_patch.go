@@ -0,0 +1,23 @@
+package transport
+
+// mergePatch применяет JSON Merge Patch (RFC 7396) к документу.
+// null удаляет поле, объекты сливаются рекурсивно, остальные значения заменяются целиком
+func mergePatch(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = make(map[string]any, len(patch))
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		object, ok := value.(map[string]any)
+		if !ok {
+			target[key] = value
+			continue
+		}
+		current, _ := target[key].(map[string]any)
+		target[key] = mergePatch(current, object)
+	}
+	return target
+}
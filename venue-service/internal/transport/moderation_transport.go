@@ -0,0 +1,162 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"venue-service/internal/middleware"
+	"venue-service/internal/models"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueModerationHandler struct {
+	service   services.VenueModerationService
+	logger    *slog.Logger
+	jwtSecret string
+	mediaURL  MediaURLFunc
+}
+
+// ModerationQueueQuery - параметры очереди модерации
+type ModerationQueueQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft submitted approved rejected published suspended"` // По умолчанию submitted
+	Page   int    `form:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func NewVenueModerationHandler(service services.VenueModerationService, logger *slog.Logger, jwtSecret string, mediaURL MediaURLFunc) *VenueModerationHandler {
+	return &VenueModerationHandler{
+		service:   service,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+		mediaURL:  mediaURL,
+	}
+}
+
+func (h *VenueModerationHandler) RegisterRoutes(r *gin.Engine) {
+	venues := r.Group("/venues/:id", middleware.AuthMiddleware(h.jwtSecret))
+	{
+		venues.POST("/submit", h.apply(models.ActionSubmit))
+		venues.POST("/approve", h.apply(models.ActionApprove))
+		venues.POST("/reject", h.apply(models.ActionReject))
+		venues.POST("/publish", h.apply(models.ActionPublish))
+		venues.POST("/suspend", h.apply(models.ActionSuspend))
+		venues.POST("/reinstate", h.apply(models.ActionReinstate))
+	}
+
+	moderation := r.Group("/moderation", middleware.AuthMiddleware(h.jwtSecret))
+	{
+		moderation.GET("/venues", h.GetQueue)
+	}
+}
+
+// apply возвращает обработчик действия модерации. Тело с причиной необязательно
+func (h *VenueModerationHandler) apply(action models.ModerationAction) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := h.parseParam(c, "id")
+		if err != nil {
+			return
+		}
+
+		var dto ModerationActionDTO
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&dto); err != nil {
+				h.logger.Error("Ошибка парсинга JSON", "error", err)
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+		}
+
+		claims, _ := middleware.ClaimsFromContext(c)
+		venue, err := h.service.Apply(id, claims, action, dto.Reason)
+		if err != nil {
+			h.writeError(c, err, "Ошибка модерации площадки", "id", id, "action", action)
+			return
+		}
+
+		c.JSON(http.StatusOK, ToVenueDTO(venue, h.mediaURL))
+	}
+}
+
+// GetQueue - очередь модерации администратора, по умолчанию заявки на проверку
+func (h *VenueModerationHandler) GetQueue(c *gin.Context) {
+	var query ModerationQueueQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Ошибка парсинга query параметров", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if query.Status == "" {
+		query.Status = string(models.VenueSubmitted)
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	venues, total, err := h.service.GetQueue(claims, models.VenueStatus(query.Status), query.Page, query.Limit)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения очереди модерации", "status", query.Status)
+		return
+	}
+
+	c.JSON(http.StatusOK, VenueListDTO{
+		Venues: ToVenueDTOList(venues, h.mediaURL),
+		Total:  total,
+		Page:   query.Page,
+		Limit:  query.Limit,
+	})
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *VenueModerationHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrVenueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrModerationReasonRequired):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidTransition):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// parseParam вспомогательная функция для парсинга ID из параметра пути
+func (h *VenueModerationHandler) parseParam(c *gin.Context, name string) (uint, error) {
+	idStr := c.Param(name)
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		h.logger.Error("Неверный формат ID", name, idStr, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "неверный формат ID",
+		})
+		if err == nil {
+			err = strconv.ErrRange
+		}
+		return 0, err
+	}
+	return uint(id), nil
+}
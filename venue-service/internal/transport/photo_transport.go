@@ -0,0 +1,234 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"venue-service/internal/middleware"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead - запас на заголовки multipart сверх размера файла
+const multipartOverhead = 1 << 20
+
+type VenuePhotoHandler struct {
+	service        services.VenuePhotoService
+	logger         *slog.Logger
+	jwtSecret      string
+	mediaURL       MediaURLFunc
+	maxUploadBytes int64
+}
+
+func NewVenuePhotoHandler(service services.VenuePhotoService, logger *slog.Logger, jwtSecret string, mediaURL MediaURLFunc, maxUploadBytes int64) *VenuePhotoHandler {
+	return &VenuePhotoHandler{
+		service:        service,
+		logger:         logger.With("layer", "transport"),
+		jwtSecret:      jwtSecret,
+		mediaURL:       mediaURL,
+		maxUploadBytes: maxUploadBytes,
+	}
+}
+
+func (h *VenuePhotoHandler) RegisterRoutes(r *gin.Engine) {
+	photos := r.Group("/venues/:id/photos")
+	{
+		photos.GET("", h.GetList)
+		photos.POST("", middleware.AuthMiddleware(h.jwtSecret), h.Upload)
+		photos.PUT("/order", middleware.AuthMiddleware(h.jwtSecret), h.Reorder)
+		photos.PUT("/:photo_id/cover", middleware.AuthMiddleware(h.jwtSecret), h.SetCover)
+		photos.DELETE("/:photo_id", middleware.AuthMiddleware(h.jwtSecret), h.Delete)
+	}
+}
+
+func (h *VenuePhotoHandler) GetList(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	photos, err := h.service.GetByVenueID(venueID)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения фотографий", "venue_id", venueID)
+		return
+	}
+	c.JSON(http.StatusOK, ToPhotoDTOList(photos, h.mediaURL))
+}
+
+// Upload принимает multipart/form-data с файлом в поле file
+func (h *VenuePhotoHandler) Upload(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.writeTooLarge(c)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ожидается файл в поле file (multipart/form-data)",
+		})
+		return
+	}
+	if header.Size > h.maxUploadBytes {
+		h.writeTooLarge(c)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		h.logger.Error("Ошибка чтения загруженного файла", "venue_id", venueID, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "не удалось прочитать файл",
+		})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		h.logger.Error("Ошибка чтения загруженного файла", "venue_id", venueID, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "не удалось прочитать файл",
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	photo, err := h.service.Upload(c.Request.Context(), venueID, claims, data)
+	if err != nil {
+		h.writeError(c, err, "Ошибка загрузки фотографии", "venue_id", venueID)
+		return
+	}
+
+	h.logger.Info("Фотография загружена", "venue_id", venueID, "id", photo.ID)
+	c.JSON(http.StatusCreated, ToPhotoDTO(photo, h.mediaURL))
+}
+
+func (h *VenuePhotoHandler) Reorder(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var dto PhotoOrderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	photos, err := h.service.Reorder(venueID, claims, dto.PhotoIDs)
+	if err != nil {
+		h.writeError(c, err, "Ошибка изменения порядка фотографий", "venue_id", venueID)
+		return
+	}
+
+	h.logger.Info("Порядок фотографий изменён", "venue_id", venueID)
+	c.JSON(http.StatusOK, ToPhotoDTOList(photos, h.mediaURL))
+}
+
+func (h *VenuePhotoHandler) SetCover(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+	photoID, err := h.parseParam(c, "photo_id")
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	photos, err := h.service.SetCover(venueID, photoID, claims)
+	if err != nil {
+		h.writeError(c, err, "Ошибка установки обложки", "venue_id", venueID, "id", photoID)
+		return
+	}
+
+	h.logger.Info("Обложка площадки изменена", "venue_id", venueID, "id", photoID)
+	c.JSON(http.StatusOK, ToPhotoDTOList(photos, h.mediaURL))
+}
+
+func (h *VenuePhotoHandler) Delete(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+	photoID, err := h.parseParam(c, "photo_id")
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Delete(c.Request.Context(), venueID, photoID, claims); err != nil {
+		h.writeError(c, err, "Ошибка удаления фотографии", "venue_id", venueID, "id", photoID)
+		return
+	}
+
+	h.logger.Info("Фотография удалена", "venue_id", venueID, "id", photoID)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *VenuePhotoHandler) writeTooLarge(c *gin.Context) {
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error": "файл больше " + strconv.FormatInt(h.maxUploadBytes>>20, 10) + " МБ",
+	})
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *VenuePhotoHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrVenueNotFound), errors.Is(err, services.ErrPhotoNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidImage):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidPhotoOrder):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrPhotoLimit):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// parseParam вспомогательная функция для парсинга ID из параметра пути
+func (h *VenuePhotoHandler) parseParam(c *gin.Context, name string) (uint, error) {
+	idStr := c.Param(name)
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		h.logger.Error("Неверный формат ID", name, idStr, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "неверный формат ID",
+		})
+		if err == nil {
+			err = strconv.ErrRange
+		}
+		return 0, err
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,213 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"venue-service/internal/middleware"
+	"venue-service/internal/models"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueReviewHandler struct {
+	service   services.VenueReviewService
+	logger    *slog.Logger
+	jwtSecret string
+}
+
+type GetReviewsQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func NewVenueReviewHandler(service services.VenueReviewService, logger *slog.Logger, jwtSecret string) *VenueReviewHandler {
+	return &VenueReviewHandler{
+		service:   service,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+	}
+}
+
+func (h *VenueReviewHandler) RegisterRoutes(r *gin.Engine) {
+	reviews := r.Group("/venues/:id/reviews")
+	{
+		reviews.GET("", middleware.OptionalAuthMiddleware(h.jwtSecret), h.GetList)
+		reviews.POST("", middleware.AuthMiddleware(h.jwtSecret), h.Create)
+		reviews.POST("/:review_id/reply", middleware.AuthMiddleware(h.jwtSecret), h.Reply)
+		reviews.PUT("/:review_id/visibility", middleware.AuthMiddleware(h.jwtSecret), h.SetVisibility)
+	}
+}
+
+func (h *VenueReviewHandler) GetList(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var query GetReviewsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Ошибка парсинга query параметров", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+
+	// Для анонимного запроса claims нет, скрытые отзывы не возвращаются
+	claims, _ := middleware.ClaimsFromContext(c)
+	reviews, total, err := h.service.GetByVenueID(venueID, claims, query.Page, query.Limit)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения отзывов", "venue_id", venueID)
+		return
+	}
+
+	c.JSON(http.StatusOK, ReviewListDTO{
+		Reviews: ToReviewDTOList(reviews),
+		Total:   total,
+		Page:    query.Page,
+		Limit:   query.Limit,
+	})
+}
+
+func (h *VenueReviewHandler) Create(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var dto CreateReviewDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	review := &models.Review{
+		BookingID: dto.BookingID,
+		Rating:    dto.Rating,
+		Text:      dto.Text,
+	}
+	if err := h.service.Create(venueID, claims, review); err != nil {
+		h.writeError(c, err, "Ошибка создания отзыва", "venue_id", venueID, "booking_id", dto.BookingID)
+		return
+	}
+
+	h.logger.Info("Отзыв успешно создан", "venue_id", venueID, "id", review.ID)
+	c.JSON(http.StatusCreated, ToReviewDTO(review))
+}
+
+func (h *VenueReviewHandler) Reply(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+	reviewID, err := h.parseParam(c, "review_id")
+	if err != nil {
+		return
+	}
+
+	var dto ReviewReplyDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	review, err := h.service.Reply(venueID, reviewID, claims, dto.Text)
+	if err != nil {
+		h.writeError(c, err, "Ошибка ответа на отзыв", "venue_id", venueID, "id", reviewID)
+		return
+	}
+
+	h.logger.Info("Ответ на отзыв сохранён", "venue_id", venueID, "id", reviewID)
+	c.JSON(http.StatusOK, ToReviewDTO(review))
+}
+
+func (h *VenueReviewHandler) SetVisibility(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+	reviewID, err := h.parseParam(c, "review_id")
+	if err != nil {
+		return
+	}
+
+	var dto ReviewVisibilityDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	review, err := h.service.SetHidden(venueID, reviewID, claims, dto.Hidden, dto.Reason)
+	if err != nil {
+		h.writeError(c, err, "Ошибка изменения видимости отзыва", "venue_id", venueID, "id", reviewID)
+		return
+	}
+
+	h.logger.Info("Видимость отзыва изменена", "venue_id", venueID, "id", reviewID, "hidden", dto.Hidden)
+	c.JSON(http.StatusOK, ToReviewDTO(review))
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *VenueReviewHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrVenueNotFound), errors.Is(err, services.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrReviewNotAllowed):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrReviewExists), errors.Is(err, services.ErrReviewReplyExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// parseParam вспомогательная функция для парсинга ID из параметра пути
+func (h *VenueReviewHandler) parseParam(c *gin.Context, name string) (uint, error) {
+	idStr := c.Param(name)
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		h.logger.Error("Неверный формат ID", name, idStr, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "неверный формат ID",
+		})
+		if err == nil {
+			err = strconv.ErrRange
+		}
+		return 0, err
+	}
+	return uint(id), nil
+}
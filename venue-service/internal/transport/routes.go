@@ -11,7 +11,57 @@ func RegisterRoutes(
 	router *gin.Engine,
 	logger *slog.Logger,
 	venueService services.VenueService,
+	unitService services.VenueUnitService,
+	reviewService services.VenueReviewService,
+	photoService services.VenuePhotoService,
+	moderationService services.VenueModerationService,
+	venueTypeService services.VenueTypeService,
+	venueImportService services.VenueImportService,
+	deletedVenueService services.DeletedVenueService,
+	favoriteService services.FavoriteService,
+	savedSearchService services.SavedSearchService,
+	media MediaConfig,
+	jwtSecret string,
 ) {
-	venueHandler := NewVenueHandler(venueService, logger)
+	venueHandler := NewVenueHandler(venueService, favoriteService, logger, jwtSecret, media.URL)
 	venueHandler.RegisterRoutes(router)
+
+	favoriteHandler := NewFavoriteHandler(favoriteService, logger, jwtSecret, media.URL)
+	favoriteHandler.RegisterRoutes(router)
+
+	savedSearchHandler := NewSavedSearchHandler(savedSearchService, logger, jwtSecret)
+	savedSearchHandler.RegisterRoutes(router)
+
+	venueTypeHandler := NewVenueTypeHandler(venueTypeService, logger, jwtSecret)
+	venueTypeHandler.RegisterRoutes(router)
+
+	unitHandler := NewVenueUnitHandler(unitService, logger, jwtSecret)
+	unitHandler.RegisterRoutes(router)
+
+	reviewHandler := NewVenueReviewHandler(reviewService, logger, jwtSecret)
+	reviewHandler.RegisterRoutes(router)
+
+	photoHandler := NewVenuePhotoHandler(photoService, logger, jwtSecret, media.URL, media.MaxUploadBytes)
+	photoHandler.RegisterRoutes(router)
+
+	moderationHandler := NewVenueModerationHandler(moderationService, logger, jwtSecret, media.URL)
+	moderationHandler.RegisterRoutes(router)
+
+	importHandler := NewVenueImportHandler(venueImportService, logger, jwtSecret)
+	importHandler.RegisterRoutes(router)
+
+	deletedVenueHandler := NewDeletedVenueHandler(deletedVenueService, logger, jwtSecret, media.URL)
+	deletedVenueHandler.RegisterRoutes(router)
+
+	// Файлы локального хранилища раздает сам сервис
+	if media.LocalRoot != "" {
+		router.Static("/media", media.LocalRoot)
+	}
+}
+
+// MediaConfig - то, что транспорту нужно знать о хранилище фотографий
+type MediaConfig struct {
+	URL            MediaURLFunc
+	MaxUploadBytes int64
+	LocalRoot      string // Каталог для раздачи по /media, пусто - файлы раздает внешнее хранилище
 }
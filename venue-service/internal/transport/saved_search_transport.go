@@ -0,0 +1,174 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"venue-service/internal/middleware"
+	"venue-service/internal/models"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedSearchHandler struct {
+	service   services.SavedSearchService
+	logger    *slog.Logger
+	jwtSecret string
+}
+
+func NewSavedSearchHandler(service services.SavedSearchService, logger *slog.Logger, jwtSecret string) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		service:   service,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+	}
+}
+
+func (h *SavedSearchHandler) RegisterRoutes(r *gin.Engine) {
+	searches := r.Group("/saved-searches", middleware.AuthMiddleware(h.jwtSecret))
+	{
+		searches.GET("", h.GetList)
+		searches.POST("", h.Create)
+		searches.GET("/:id", h.GetByID)
+		searches.PUT("/:id", h.Update)
+		searches.DELETE("/:id", h.Delete)
+	}
+}
+
+func (h *SavedSearchHandler) GetList(c *gin.Context) {
+	claims, _ := middleware.ClaimsFromContext(c)
+	searches, err := h.service.GetList(claims)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения сохранённых поисков")
+		return
+	}
+	c.JSON(http.StatusOK, ToSavedSearchDTOList(searches))
+}
+
+func (h *SavedSearchHandler) Create(c *gin.Context) {
+	search, ok := h.bindSearch(c)
+	if !ok {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Create(claims, search); err != nil {
+		h.writeError(c, err, "Ошибка сохранения поиска")
+		return
+	}
+	c.JSON(http.StatusCreated, ToSavedSearchDTO(search))
+}
+
+func (h *SavedSearchHandler) GetByID(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	search, err := h.service.GetByID(id, claims)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения сохранённого поиска", "id", id)
+		return
+	}
+	c.JSON(http.StatusOK, ToSavedSearchDTO(search))
+}
+
+// Update заменяет поиск целиком
+func (h *SavedSearchHandler) Update(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+	search, ok := h.bindSearch(c)
+	if !ok {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Update(id, claims, search); err != nil {
+		h.writeError(c, err, "Ошибка обновления сохранённого поиска", "id", id)
+		return
+	}
+	c.JSON(http.StatusOK, ToSavedSearchDTO(search))
+}
+
+func (h *SavedSearchHandler) Delete(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Delete(id, claims); err != nil {
+		h.writeError(c, err, "Ошибка удаления сохранённого поиска", "id", id)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// bindSearch разбирает тело запроса в модель поиска. При ошибке ответ уже записан
+func (h *SavedSearchHandler) bindSearch(c *gin.Context) (*models.SavedSearch, bool) {
+	var dto SavedSearchDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+	search, err := FromSavedSearchDTO(&dto)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+	return search, true
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *SavedSearchHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrSavedSearchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidSavedSearch):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrTooManySavedSearches):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// parseID вспомогательная функция для парсинга ID поиска из параметра пути
+func (h *SavedSearchHandler) parseID(c *gin.Context) (uint, error) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		h.logger.Error("Неверный формат ID", "id", idStr, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "неверный формат ID",
+		})
+		if err == nil {
+			err = strconv.ErrRange
+		}
+		return 0, err
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,100 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+	"venue-service/internal/models"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeSavedSearchService запоминает сохраняемый поиск
+type fakeSavedSearchService struct {
+	services.SavedSearchService
+	created *models.SavedSearch
+}
+
+func (s *fakeSavedSearchService) Create(claims *models.Claims, search *models.SavedSearch) error {
+	search.ID = 5
+	search.UserID = claims.UserID
+	s.created = search
+	return nil
+}
+
+func newSavedSearchTestRouter(service services.SavedSearchService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	NewSavedSearchHandler(service, logger, testJWTSecret).RegisterRoutes(r)
+	return r
+}
+
+func TestSavedSearchCreate(t *testing.T) {
+	service := &fakeSavedSearchService{}
+	router := newSavedSearchTestRouter(service)
+
+	body := `{
+		"name": "Футбол по выходным",
+		"filter": {"district": ["Центральный,Северный"], "venue_type": ["football"], "min_price": 1000, "max_price": 3000,
+			"lat": 55.75, "lng": 37.61, "radius_km": 5, "amenities": ["lighting"]},
+		"time": {"days": ["saturday", "sunday"], "from": "18:00", "to": "22:00"}
+	}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/saved-searches", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", testToken(t, 9, models.RoleClient))
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("статус %d: %s", w.Code, w.Body.String())
+	}
+
+	search := service.created
+	if !slices.Equal(search.Filter.Districts, []string{"Центральный", "Северный"}) {
+		t.Fatalf("районы %v", search.Filter.Districts)
+	}
+	if search.Filter.Geo == nil || search.Filter.Geo.RadiusKm != 5 || search.Filter.MinPrice != 1000 || search.Filter.MaxPrice != 3000 {
+		t.Fatalf("фильтр %+v", search.Filter)
+	}
+	if !slices.Equal(search.Time.Days, []time.Weekday{time.Sunday, time.Saturday}) {
+		t.Fatalf("дни %v", search.Time.Days)
+	}
+
+	var resp SavedSearchDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != 5 || resp.Filter.Lat == nil || *resp.Filter.Lat != 55.75 || !slices.Equal(resp.Time.Days, []string{"sunday", "saturday"}) {
+		t.Fatalf("ответ %+v", resp)
+	}
+}
+
+func TestSavedSearchCreateInvalid(t *testing.T) {
+	bodies := map[string]string{
+		"без названия":         `{"filter": {}}`,
+		"lat без lng":          `{"name": "a", "filter": {"lat": 55.75}}`,
+		"неизвестное удобство": `{"name": "a", "filter": {"amenities": ["pool"]}}`,
+		"radius_km без точки":  `{"name": "a", "filter": {"radius_km": 5}}`,
+		"цена наоборот":        `{"name": "a", "filter": {"min_price": 3000, "max_price": 1000}}`,
+		"неверный день":        `{"name": "a", "time": {"days": ["someday"]}}`,
+	}
+
+	router := newSavedSearchTestRouter(&fakeSavedSearchService{})
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/saved-searches", bytes.NewBufferString(body))
+			req.Header.Set("Authorization", testToken(t, 9, models.RoleClient))
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("статус %d, ожидался 400: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
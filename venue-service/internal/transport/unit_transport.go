@@ -0,0 +1,169 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"venue-service/internal/middleware"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueUnitHandler struct {
+	service   services.VenueUnitService
+	logger    *slog.Logger
+	jwtSecret string
+}
+
+func NewVenueUnitHandler(service services.VenueUnitService, logger *slog.Logger, jwtSecret string) *VenueUnitHandler {
+	return &VenueUnitHandler{
+		service:   service,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+	}
+}
+
+func (h *VenueUnitHandler) RegisterRoutes(r *gin.Engine) {
+	units := r.Group("/venues/:id/units")
+	{
+		units.GET("", h.GetList)
+		units.POST("", middleware.AuthMiddleware(h.jwtSecret), h.Create)
+		units.PUT("/:unit_id", middleware.AuthMiddleware(h.jwtSecret), h.Update)
+		units.DELETE("/:unit_id", middleware.AuthMiddleware(h.jwtSecret), h.Delete)
+	}
+}
+
+func (h *VenueUnitHandler) GetList(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	units, err := h.service.GetByVenueID(venueID)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения единиц площадки", "venue_id", venueID)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToVenueUnitDTOList(units))
+}
+
+func (h *VenueUnitHandler) Create(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var dto VenueUnitDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	unit := FromVenueUnitDTO(&dto)
+	if err := h.service.Create(venueID, claims, unit); err != nil {
+		h.writeError(c, err, "Ошибка создания единицы площадки", "venue_id", venueID)
+		return
+	}
+
+	h.logger.Info("Единица площадки успешно создана", "venue_id", venueID, "id", unit.ID)
+	c.JSON(http.StatusCreated, ToVenueUnitDTO(unit))
+}
+
+func (h *VenueUnitHandler) Update(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+	unitID, err := h.parseParam(c, "unit_id")
+	if err != nil {
+		return
+	}
+
+	var dto VenueUnitDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	unit := FromVenueUnitDTO(&dto)
+	if err := h.service.Update(venueID, unitID, claims, unit); err != nil {
+		h.writeError(c, err, "Ошибка обновления единицы площадки", "venue_id", venueID, "id", unitID)
+		return
+	}
+
+	h.logger.Info("Единица площадки успешно обновлена", "venue_id", venueID, "id", unitID)
+	c.JSON(http.StatusOK, ToVenueUnitDTO(unit))
+}
+
+func (h *VenueUnitHandler) Delete(c *gin.Context) {
+	venueID, err := h.parseParam(c, "id")
+	if err != nil {
+		return
+	}
+	unitID, err := h.parseParam(c, "unit_id")
+	if err != nil {
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Delete(venueID, unitID, claims); err != nil {
+		h.writeError(c, err, "Ошибка удаления единицы площадки", "venue_id", venueID, "id", unitID)
+		return
+	}
+
+	h.logger.Info("Единица площадки успешно удалена", "venue_id", venueID, "id", unitID)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Единица площадки удалена",
+	})
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *VenueUnitHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrVenueNotFound), errors.Is(err, services.ErrUnitNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrUnitParentInvalid), errors.Is(err, services.ErrUnitHasChildren):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// parseParam вспомогательная функция для парсинга ID из параметра пути
+func (h *VenueUnitHandler) parseParam(c *gin.Context, name string) (uint, error) {
+	idStr := c.Param(name)
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		h.logger.Error("Неверный формат ID", name, idStr, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "неверный формат ID",
+		})
+		if err == nil {
+			err = strconv.ErrRange
+		}
+		return 0, err
+	}
+	return uint(id), nil
+}
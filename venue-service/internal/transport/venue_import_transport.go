@@ -0,0 +1,508 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"venue-service/internal/middleware"
+	"venue-service/internal/services"
+
+	"pkg/export"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Форматы файлов импорта и экспорта
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// maxImportBytes - максимальный размер файла импорта
+const maxImportBytes = 5 << 20
+
+// utf8BOM в начале CSV нужен Excel, чтобы открыть файл в UTF-8
+const utf8BOM = "\ufeff"
+
+// venueImportColumns - колонки CSV в порядке экспорта
+var venueImportColumns = []string{
+	"id", "owner_id", "venue_type", "district", "hour_price", "capacity", "is_active",
+	"address", "latitude", "longitude", "amenities",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+	"status",
+}
+
+// venueImportRequiredColumns - колонки, без которых файл не принимается
+var venueImportRequiredColumns = []string{
+	"venue_type", "district", "hour_price",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+}
+
+// venueImportOptionalFields - необязательные поля, которые при изменении площадки можно не передавать
+var venueImportOptionalFields = []string{
+	services.ImportFieldCapacity, services.ImportFieldIsActive, services.ImportFieldAddress,
+	services.ImportFieldLatitude, services.ImportFieldLongitude, services.ImportFieldAmenities,
+}
+
+// missingImportFields возвращает необязательные поля, которых нет среди present
+func missingImportFields(present func(name string) bool) map[string]bool {
+	missing := make(map[string]bool)
+	for _, name := range venueImportOptionalFields {
+		if !present(name) {
+			missing[name] = true
+		}
+	}
+	return missing
+}
+
+// VenueImportQuery - параметры импорта
+type VenueImportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json"` // По умолчанию - по Content-Type или расширению файла
+	DryRun *bool  `form:"dry_run"`                                   // По умолчанию true: только проверка
+}
+
+// VenueExportQuery - параметры экспорта
+type VenueExportQuery struct {
+	Format  string `form:"format" binding:"omitempty,oneof=csv json"` // По умолчанию csv
+	OwnerID uint   `form:"owner_id"`                                  // Только для администратора, без него - все площадки
+}
+
+type VenueImportHandler struct {
+	service   services.VenueImportService
+	logger    *slog.Logger
+	jwtSecret string
+}
+
+func NewVenueImportHandler(service services.VenueImportService, logger *slog.Logger, jwtSecret string) *VenueImportHandler {
+	return &VenueImportHandler{
+		service:   service,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+	}
+}
+
+func (h *VenueImportHandler) RegisterRoutes(r *gin.Engine) {
+	venues := r.Group("/venues")
+	{
+		venues.POST("/import", middleware.AuthMiddleware(h.jwtSecret), h.Import)
+		venues.GET("/export", middleware.AuthMiddleware(h.jwtSecret), h.Export)
+	}
+}
+
+// Import принимает файл в теле запроса или в поле file формы multipart/form-data
+func (h *VenueImportHandler) Import(c *gin.Context) {
+	var query VenueImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	body := io.Reader(c.Request.Body)
+	format := query.Format
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, header, err := c.Request.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("файл не передан в поле file: %v", err),
+			})
+			return
+		}
+		defer file.Close()
+		body = file
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+	} else if format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			format = FormatCSV
+		case "application/json":
+			format = FormatJSON
+		}
+	}
+
+	var rows []services.ImportRow
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = readImportCSV(body)
+	case FormatJSON:
+		rows, err = readImportJSON(body)
+	default:
+		err = fmt.Errorf("укажите format=csv или format=json")
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": fmt.Sprintf("файл импорта больше %d МБ", maxImportBytes>>20),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	dryRun := query.DryRun == nil || *query.DryRun
+	result, err := h.service.Import(claims, rows, dryRun)
+	if err != nil {
+		h.writeError(c, err, "Ошибка импорта площадок", "rows", len(rows))
+		return
+	}
+
+	status := http.StatusOK
+	if result.Errors > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, ToVenueImportResultDTO(result))
+}
+
+func (h *VenueImportHandler) Export(c *gin.Context) {
+	var query VenueExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	venues, err := h.service.Export(claims, query.OwnerID)
+	if err != nil {
+		h.writeError(c, err, "Ошибка экспорта площадок", "owner_id", query.OwnerID)
+		return
+	}
+
+	rows := make([]VenueImportRowDTO, 0, len(venues))
+	for i := range venues {
+		rows = append(rows, ToVenueImportRowDTO(&venues[i]))
+	}
+
+	if query.Format == FormatJSON {
+		c.Header("Content-Disposition", `attachment; filename="venues.json"`)
+		c.JSON(http.StatusOK, rows)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeImportCSV(&buf, rows); err != nil {
+		h.writeError(c, err, "Ошибка записи CSV")
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="venues.csv"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *VenueImportHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrImportEmpty), errors.Is(err, services.ErrImportTooLarge):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrRevisionConflict), errors.Is(err, services.ErrVenueConflict):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// readImportCSV читает площадки из CSV с заголовком. Разделитель - запятая или точка с запятой
+// (так сохраняет Excel с русской локалью). Ошибки отдельных строк не прерывают чтение
+func readImportCSV(r io.Reader) ([]services.ImportRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	headerLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(headerLine, []byte(";")) > bytes.Count(headerLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать заголовок CSV: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !containsString(venueImportColumns, name) {
+			return nil, fmt.Errorf("неизвестная колонка %q", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range venueImportRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("нет обязательной колонки %q", name)
+		}
+	}
+	missing := missingImportFields(func(name string) bool {
+		_, ok := columns[name]
+		return ok
+	})
+
+	var rows []services.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, services.ImportRow{Line: parseErr.StartLine, Err: err})
+			continue
+		}
+		for i := range record {
+			record[i] = export.UnescapeCell(record[i])
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := services.ImportRow{Line: line, Missing: missing}
+		dto, err := csvRecordToDTO(record, columns)
+		if err == nil {
+			row.Venue, err = FromVenueImportRowDTO(dto)
+		}
+		row.Err = err
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// csvRecordToDTO разбирает значения строки CSV
+func csvRecordToDTO(record []string, columns map[string]int) (*VenueImportRowDTO, error) {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	dto := &VenueImportRowDTO{
+		VenueType: value("venue_type"),
+		District:  value("district"),
+		Address:   value("address"),
+		Monday:    value("monday"),
+		Tuesday:   value("tuesday"),
+		Wednesday: value("wednesday"),
+		Thursday:  value("thursday"),
+		Friday:    value("friday"),
+		Saturday:  value("saturday"),
+		Sunday:    value("sunday"),
+	}
+
+	uints := []struct {
+		name   string
+		target *uint
+	}{
+		{"id", &dto.ID},
+		{"owner_id", &dto.OwnerID},
+	}
+	for _, field := range uints {
+		if v := value(field.name); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: ожидается целое число, получено %q", field.name, v)
+			}
+			*field.target = uint(n)
+		}
+	}
+
+	ints := []struct {
+		name   string
+		target *int
+	}{
+		{"hour_price", &dto.HourPrice},
+		{"capacity", &dto.Capacity},
+	}
+	for _, field := range ints {
+		if v := value(field.name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: ожидается целое число, получено %q", field.name, v)
+			}
+			*field.target = n
+		}
+	}
+
+	floats := []struct {
+		name   string
+		target **float64
+	}{
+		{"latitude", &dto.Latitude},
+		{"longitude", &dto.Longitude},
+	}
+	for _, field := range floats {
+		if v := value(field.name); v != "" {
+			// Excel с русской локалью пишет дробную часть через запятую
+			f, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: ожидается число, получено %q", field.name, v)
+			}
+			*field.target = &f
+		}
+	}
+
+	if v := value("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return nil, fmt.Errorf("is_active: ожидается true или false, получено %q", v)
+		}
+		dto.IsActive = &isActive
+	}
+
+	for _, code := range strings.Split(value("amenities"), ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			dto.Amenities = append(dto.Amenities, code)
+		}
+	}
+	return dto, nil
+}
+
+// readImportJSON читает площадки из JSON-массива. Номер строки результата - номер элемента с 1
+func readImportJSON(r io.Reader) ([]services.ImportRow, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("ожидается JSON-массив площадок: %w", err)
+	}
+
+	rows := make([]services.ImportRow, 0, len(items))
+	for i, item := range items {
+		row := services.ImportRow{Line: i + 1}
+
+		var dto VenueImportRowDTO
+		decoder := json.NewDecoder(bytes.NewReader(item))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&dto)
+		if err == nil {
+			row.Venue, err = FromVenueImportRowDTO(&dto)
+		}
+		if err == nil {
+			var keys map[string]json.RawMessage
+			if err = json.Unmarshal(item, &keys); err == nil {
+				row.Missing = missingImportFields(func(name string) bool {
+					_, ok := keys[name]
+					return ok
+				})
+			}
+		}
+		row.Err = err
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// formatImportFloat форматирует необязательную координату, nil - пустая ячейка
+func formatImportFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+// venueExportColumns - колонки CSV экспорта, ключи и порядок совпадают с venueImportColumns
+var venueExportColumns = []export.Column[VenueImportRowDTO]{
+	{Key: "id", Title: "ID", Numeric: true, Value: func(r VenueImportRowDTO, _ export.Locale) string {
+		return strconv.FormatUint(uint64(r.ID), 10)
+	}},
+	{Key: "owner_id", Title: "Владелец", Numeric: true, Value: func(r VenueImportRowDTO, _ export.Locale) string {
+		return strconv.FormatUint(uint64(r.OwnerID), 10)
+	}},
+	{Key: "venue_type", Title: "Тип", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.VenueType }},
+	{Key: "district", Title: "Район", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.District }},
+	{Key: "hour_price", Title: "Цена за час", Numeric: true, Value: func(r VenueImportRowDTO, _ export.Locale) string {
+		return strconv.Itoa(r.HourPrice)
+	}},
+	{Key: "capacity", Title: "Вместимость", Numeric: true, Value: func(r VenueImportRowDTO, _ export.Locale) string {
+		return strconv.Itoa(r.Capacity)
+	}},
+	{Key: "is_active", Title: "Активна", Value: func(r VenueImportRowDTO, _ export.Locale) string {
+		return strconv.FormatBool(r.IsActive == nil || *r.IsActive)
+	}},
+	{Key: "address", Title: "Адрес", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Address }},
+	{Key: "latitude", Title: "Широта", Numeric: true, Value: func(r VenueImportRowDTO, _ export.Locale) string {
+		return formatImportFloat(r.Latitude)
+	}},
+	{Key: "longitude", Title: "Долгота", Numeric: true, Value: func(r VenueImportRowDTO, _ export.Locale) string {
+		return formatImportFloat(r.Longitude)
+	}},
+	{Key: "amenities", Title: "Удобства", Value: func(r VenueImportRowDTO, _ export.Locale) string {
+		return strings.Join(r.Amenities, ",")
+	}},
+	{Key: "monday", Title: "Понедельник", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Monday }},
+	{Key: "tuesday", Title: "Вторник", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Tuesday }},
+	{Key: "wednesday", Title: "Среда", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Wednesday }},
+	{Key: "thursday", Title: "Четверг", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Thursday }},
+	{Key: "friday", Title: "Пятница", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Friday }},
+	{Key: "saturday", Title: "Суббота", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Saturday }},
+	{Key: "sunday", Title: "Воскресенье", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Sunday }},
+	{Key: "status", Title: "Статус", Value: func(r VenueImportRowDTO, _ export.Locale) string { return r.Status }},
+}
+
+// writeImportCSV записывает площадки в CSV в формате, который принимает импорт.
+// Текст, похожий на формулу, экранируется общим пакетом выгрузок, импорт снимает экранирование
+func writeImportCSV(w io.Writer, rows []VenueImportRowDTO) error {
+	locale, err := export.ParseLocale("", "")
+	if err != nil {
+		return err
+	}
+	table, err := export.NewTable(w, export.FormatCSV, "Площадки", venueExportColumns, locale)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := table.Write(row); err != nil {
+			return err
+		}
+	}
+	return table.Close()
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
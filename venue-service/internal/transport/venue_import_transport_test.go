@@ -0,0 +1,203 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"venue-service/internal/models"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeVenueImportService запоминает разобранные строки и помечает ошибкой строки, которые не удалось разобрать
+type fakeVenueImportService struct {
+	rows   []services.ImportRow
+	dryRun bool
+	venues []models.Venue
+}
+
+func (s *fakeVenueImportService) Import(claims *models.Claims, rows []services.ImportRow, dryRun bool) (*services.ImportResult, error) {
+	s.rows, s.dryRun = rows, dryRun
+	result := &services.ImportResult{DryRun: dryRun}
+	for _, row := range rows {
+		rowResult := services.ImportRowResult{Line: row.Line, Action: services.ImportCreate}
+		if row.Err != nil {
+			rowResult.Error = row.Err.Error()
+			result.Errors++
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+	return result, nil
+}
+
+func (s *fakeVenueImportService) Export(claims *models.Claims, ownerID uint) ([]models.Venue, error) {
+	return s.venues, nil
+}
+
+func newImportTestRouter(service services.VenueImportService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	NewVenueImportHandler(service, logger, testJWTSecret).RegisterRoutes(r)
+	return r
+}
+
+func TestVenueImportCSV(t *testing.T) {
+	// Так сохраняет Excel с русской локалью: BOM, точка с запятой, дробная часть через запятую
+	body := "\ufeffvenue_type;district;hour_price;latitude;longitude;amenities;monday;tuesday;wednesday;thursday;friday;saturday;sunday\n" +
+		"football;Центральный;3000;55,75;37,61;lighting,parking;09:00-13:00,15:00-21:00;09:00-21:00;;;;;\n" +
+		"football;Центральный;много;;;;09:00-21:00;;;;;;\n" +
+		";;;;;;;;;;;;\n" +
+		"tennis;Северный;1500;;;;с 9 до 21;;;;;;\n"
+
+	service := &fakeVenueImportService{}
+	r := newImportTestRouter(service)
+	req := httptest.NewRequest(http.MethodPost, "/venues/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	req.Header.Set("Authorization", testToken(t, 7, models.RoleOwner))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("статус %d, ожидался %d: %s", w.Code, http.StatusUnprocessableEntity, w.Body.String())
+	}
+	if !service.dryRun {
+		t.Fatalf("без dry_run импорт должен только проверять файл")
+	}
+	if len(service.rows) != 3 {
+		t.Fatalf("разобрано %d строк, ожидалось 3 (пустая пропускается)", len(service.rows))
+	}
+
+	first := service.rows[0]
+	if first.Err != nil || first.Line != 2 {
+		t.Fatalf("первая строка: %d, %v", first.Line, first.Err)
+	}
+	venue := first.Venue
+	if venue.HourPrice != 3000 || venue.Latitude == nil || *venue.Latitude != 55.75 || len(venue.Amenities) != 2 {
+		t.Fatalf("неверно разобрана площадка: %+v", venue)
+	}
+	// Колонок capacity, is_active и address нет: при изменении площадки они сохранят текущие значения
+	wantMissing := map[string]bool{"capacity": true, "is_active": true, "address": true}
+	if !reflect.DeepEqual(first.Missing, wantMissing) {
+		t.Fatalf("отсутствующие поля: %v, ожидалось %v", first.Missing, wantMissing)
+	}
+	if windows := venue.Weekdays.Monday.Windows(); len(windows) != 2 || windows[1].Start != "15:00" {
+		t.Fatalf("понедельник: %+v", windows)
+	}
+	if venue.Weekdays.Wednesday.Enabled {
+		t.Fatalf("пустая ячейка - выходной день")
+	}
+
+	if service.rows[1].Err == nil || !strings.Contains(service.rows[1].Err.Error(), "hour_price") {
+		t.Fatalf("ожидалась ошибка цены: %v", service.rows[1].Err)
+	}
+	if service.rows[2].Err == nil || service.rows[2].Line != 5 {
+		t.Fatalf("ожидалась ошибка расписания в строке 5: %d, %v", service.rows[2].Line, service.rows[2].Err)
+	}
+}
+
+func TestVenueImportRejectsUnknownColumn(t *testing.T) {
+	r := newImportTestRouter(&fakeVenueImportService{})
+	req := httptest.NewRequest(http.MethodPost, "/venues/import?format=csv", strings.NewReader("venue_type,district,price\n"))
+	req.Header.Set("Authorization", testToken(t, 7, models.RoleOwner))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("статус %d, ожидался %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestVenueExportRoundTrip(t *testing.T) {
+	start, end := "10:00", "22:00"
+	venue, err := FromVenueDTO(&VenueDTO{
+		VenueType: "football",
+		HourPrice: 3000,
+		District:  "Центральный",
+		Address:   "ул. Ленина, 1",
+		Weekdays: WeekdaysDTO{
+			Monday:   DayScheduleDTO{Enabled: true, StartTime: &start, EndTime: &end},
+			Saturday: DayScheduleDTO{Enabled: true, Intervals: []TimeIntervalDTO{{Start: "08:00", End: "12:00"}, {Start: "14:00", End: "20:00"}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	venue.ID, venue.OwnerID, venue.Capacity, venue.IsActive = 5, 7, 2, true
+	venue.Amenities = []models.VenueAmenity{{Code: "lighting"}, {Code: "parking"}}
+	// Текст, похожий на формулу, экранируется в CSV, отрицательные координаты - нет
+	lat, lng := -33.86, 151.2
+	venue.Latitude, venue.Longitude = &lat, &lng
+	venue.Address = "=HYPERLINK(\"http://example.com\")"
+
+	for _, format := range []string{FormatCSV, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			service := &fakeVenueImportService{venues: []models.Venue{*venue}}
+			r := newImportTestRouter(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/venues/export?format="+format, nil)
+			req.Header.Set("Authorization", testToken(t, 7, models.RoleOwner))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("экспорт: статус %d: %s", w.Code, w.Body.String())
+			}
+			if format == FormatCSV && (!strings.Contains(w.Body.String(), `"'=HYPERLINK(`) || !strings.Contains(w.Body.String(), ",-33.86,")) {
+				t.Fatalf("неожиданный CSV: %s", w.Body.String())
+			}
+
+			// Выгруженный файл принимается импортом без изменений
+			req = httptest.NewRequest(http.MethodPost, "/venues/import?format="+format, bytes.NewReader(w.Body.Bytes()))
+			req.Header.Set("Authorization", testToken(t, 7, models.RoleOwner))
+			w = httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("импорт: статус %d: %s", w.Code, w.Body.String())
+			}
+
+			var result VenueImportResultDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+				t.Fatal(err)
+			}
+			if len(service.rows) != 1 || result.Errors != 0 {
+				t.Fatalf("ожидалась одна корректная строка: %+v", result)
+			}
+			got, want := ToVenueImportRowDTO(service.rows[0].Venue), ToVenueImportRowDTO(venue)
+			want.Status = ""
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("после выгрузки и загрузки\n%+v\nожидалось\n%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestVenueImportJSONMissingFields(t *testing.T) {
+	body := `[
+		{"id": 1, "venue_type": "football", "district": "Центральный", "hour_price": 3000, "latitude": null, "longitude": null},
+		{"id": 2, "venue_type": "football", "district": "Центральный", "hour_price": 3000, "capacity": 2, "is_active": true,
+		 "address": "ул. Ленина, 1", "latitude": 55.75, "longitude": 37.61, "amenities": []}
+	]`
+	rows, err := readImportJSON(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("readImportJSON: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Err != nil || rows[1].Err != nil {
+		t.Fatalf("неверно разобраны строки: %+v", rows)
+	}
+
+	// Явный null в latitude/longitude стирает координаты, отсутствующие ключи сохраняют значения
+	wantMissing := map[string]bool{"capacity": true, "is_active": true, "address": true, "amenities": true}
+	if !reflect.DeepEqual(rows[0].Missing, wantMissing) {
+		t.Fatalf("первая строка: %v, ожидалось %v", rows[0].Missing, wantMissing)
+	}
+	if len(rows[1].Missing) != 0 {
+		t.Fatalf("вторая строка передаёт все поля: %v", rows[1].Missing)
+	}
+}
@@ -1,58 +1,191 @@
 package transport
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"venue-service/internal/middleware"
 	"venue-service/internal/models"
 	"venue-service/internal/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type VenueHandler struct {
-	service services.VenueService
-	logger  *slog.Logger
+	service   services.VenueService
+	favorites services.FavoriteService
+	logger    *slog.Logger
+	jwtSecret string
+	mediaURL  MediaURLFunc
 }
 
 type GetVenuesQuery struct {
-	District  string `form:"district"`
-	VenueType string `form:"venue_type"`
-	HourPrice int    `form:"hour_price"`
-	IsActive  *bool  `form:"is_active"`
-	OwnerID   uint   `form:"owner_id"`
-	Page      int    `form:"page" binding:"omitempty,min=1"`
-	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	District  []string `form:"district"`   // Несколько значений: district=A&district=B или district=A,B
+	VenueType []string `form:"venue_type"` // Несколько значений, как у district
+	HourPrice int      `form:"hour_price"`
+	IsActive  *bool    `form:"is_active"`
+	OwnerID   uint     `form:"owner_id"`
+	Page      int      `form:"page" binding:"omitempty,min=1"`
+	Limit     int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor    string   `form:"cursor"`   // next_cursor предыдущей страницы, вместо page
+	Envelope  bool     `form:"envelope"` // true - объект {venues, total, page, limit, next_cursor}, иначе массив площадок
+
+	MinPrice     int     `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice     int     `form:"max_price" binding:"omitempty,min=0"`
+	MinHourPrice int     `form:"min_hour_price" binding:"omitempty,min=0"` // Устаревший синоним min_price
+	MaxHourPrice int     `form:"max_hour_price" binding:"omitempty,min=0"` // Устаревший синоним max_price
+	MinRating    float64 `form:"min_rating" binding:"omitempty,min=0,max=5"`
+	Sort         string  `form:"sort" binding:"omitempty,oneof=newest price rating distance"` // newest (по умолчанию), price - по возрастанию цены, rating - по убыванию рейтинга, distance - по удалённости от lat/lng
+
+	// Поиск вокруг точки: lat и lng обязательны вместе, radius_km ограничивает расстояние
+	Lat      *float64 `form:"lat" binding:"omitempty,min=-90,max=90"`
+	Lng      *float64 `form:"lng" binding:"omitempty,min=-180,max=180"`
+	RadiusKm float64  `form:"radius_km" binding:"omitempty,gt=0,max=500"`
+
+	// Область карты: все четыре границы обязательны вместе
+	MinLat *float64 `form:"min_lat" binding:"omitempty,min=-90,max=90"`
+	MinLng *float64 `form:"min_lng" binding:"omitempty,min=-180,max=180"`
+	MaxLat *float64 `form:"max_lat" binding:"omitempty,min=-90,max=90"`
+	MaxLng *float64 `form:"max_lng" binding:"omitempty,min=-180,max=180"`
+
+	// Удобства: amenities=lighting&amenities=showers или amenities=lighting,showers
+	Amenities      []string `form:"amenities"`
+	AmenitiesMatch string   `form:"amenities_match" binding:"omitempty,oneof=all any"` // all (по умолчанию) - все перечисленные, any - хотя бы одно
 }
 
-func NewVenueHandler(service services.VenueService, logger *slog.Logger) *VenueHandler {
+// splitValues разбирает многозначный query-параметр (повторы и значения через запятую), убирая дубли
+func splitValues(values []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" || seen[item] {
+				continue
+			}
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// amenityCodes разбирает коды удобств из запроса.
+// Неизвестный код - ошибка, чтобы опечатка не давала пустую выдачу
+func (q GetVenuesQuery) amenityCodes() ([]string, error) {
+	codes := splitValues(q.Amenities)
+	for _, code := range codes {
+		if _, ok := models.FindAmenity(code); !ok {
+			return nil, fmt.Errorf("неизвестное удобство: %s", code)
+		}
+	}
+	return codes, nil
+}
+
+// venueTypes разбирает и проверяет типы площадок из запроса
+func (q GetVenuesQuery) venueTypes() ([]models.VenueType, error) {
+	var types []models.VenueType
+	for _, value := range splitValues(q.VenueType) {
+		venueType := models.VenueType(value)
+		if !venueType.IsValid() {
+			return nil, fmt.Errorf("неверный тип площадки: %s", value)
+		}
+		types = append(types, venueType)
+	}
+	return types, nil
+}
+
+// priceRange возвращает диапазон цены; min_price/max_price имеют приоритет над устаревшими синонимами
+func (q GetVenuesQuery) priceRange() (int, int, error) {
+	minPrice, maxPrice := q.MinPrice, q.MaxPrice
+	if minPrice == 0 {
+		minPrice = q.MinHourPrice
+	}
+	if maxPrice == 0 {
+		maxPrice = q.MaxHourPrice
+	}
+	if maxPrice > 0 && minPrice > maxPrice {
+		return 0, 0, fmt.Errorf("min_price не может быть больше max_price")
+	}
+	return minPrice, maxPrice, nil
+}
+
+// geoFilter разбирает параметры гео-поиска из запроса.
+// Без lat/lng сортировка по расстоянию и radius_km недопустимы
+func (q GetVenuesQuery) geoFilter() (*models.GeoPoint, *models.BoundingBox, error) {
+	var point *models.GeoPoint
+	if (q.Lat == nil) != (q.Lng == nil) {
+		return nil, nil, fmt.Errorf("lat и lng указываются вместе")
+	}
+	if q.Lat != nil {
+		point = &models.GeoPoint{Lat: *q.Lat, Lng: *q.Lng, RadiusKm: q.RadiusKm}
+	} else if q.RadiusKm > 0 || q.Sort == "distance" {
+		return nil, nil, fmt.Errorf("radius_km и sort=distance требуют lat и lng")
+	}
+
+	bounds := []*float64{q.MinLat, q.MinLng, q.MaxLat, q.MaxLng}
+	set := 0
+	for _, b := range bounds {
+		if b != nil {
+			set++
+		}
+	}
+	if set == 0 {
+		return point, nil, nil
+	}
+	if set != len(bounds) {
+		return nil, nil, fmt.Errorf("min_lat, min_lng, max_lat и max_lng указываются вместе")
+	}
+	if *q.MinLat > *q.MaxLat {
+		return nil, nil, fmt.Errorf("min_lat не может быть больше max_lat")
+	}
+	// min_lng > max_lng допустимо: область пересекает 180-й меридиан
+	box := &models.BoundingBox{MinLat: *q.MinLat, MinLng: *q.MinLng, MaxLat: *q.MaxLat, MaxLng: *q.MaxLng}
+	return point, box, nil
+}
+
+func NewVenueHandler(service services.VenueService, favorites services.FavoriteService, logger *slog.Logger, jwtSecret string, mediaURL MediaURLFunc) *VenueHandler {
 	return &VenueHandler{
-		service: service,
-		logger:  logger.With("layer", "transport"),
+		service:   service,
+		favorites: favorites,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+		mediaURL:  mediaURL,
 	}
 }
 
 func (h *VenueHandler) RegisterRoutes(r *gin.Engine) {
 	venues := r.Group("/venues")
 	{
-		venues.GET("", h.GetList)
-		venues.POST("", h.Create)
+		venues.GET("", middleware.OptionalAuthMiddleware(h.jwtSecret), h.GetList)
+		venues.POST("", middleware.AuthMiddleware(h.jwtSecret), h.Create)
 		venues.GET("/:id/schedule", h.GetSchedule)
-		venues.PUT("/:id/schedule", h.UpdateSchedule)
-		venues.GET("/:id", h.GetByID)
-		venues.PUT("/:id", h.Update)
-		venues.DELETE("/:id", h.Delete)
+		venues.GET("/:id/history", middleware.OptionalAuthMiddleware(h.jwtSecret), h.GetHistory)
+		venues.GET("/:id/history/:revision", h.GetRevision)
+		venues.PUT("/:id/schedule", middleware.AuthMiddleware(h.jwtSecret), h.UpdateSchedule)
+		venues.GET("/:id/booking-rules", h.GetBookingRules)
+		venues.PUT("/:id/booking-rules", middleware.AuthMiddleware(h.jwtSecret), h.UpdateBookingRules)
+		venues.PUT("/:id/amenities", middleware.AuthMiddleware(h.jwtSecret), h.UpdateAmenities)
+		venues.GET("/:id", middleware.OptionalAuthMiddleware(h.jwtSecret), h.GetByID)
+		venues.PUT("/:id", middleware.AuthMiddleware(h.jwtSecret), h.Update)
+		venues.PATCH("/:id", middleware.AuthMiddleware(h.jwtSecret), h.Patch)
+		venues.DELETE("/:id", middleware.AuthMiddleware(h.jwtSecret), h.Delete)
 	}
 
-	venueTypes := r.Group("/venue-types")
+	users := r.Group("/users")
 	{
-		venueTypes.GET("", h.GetVenueTypes)
+		users.GET("/:id/venues", middleware.OptionalAuthMiddleware(h.jwtSecret), h.GetByOwnerID)
 	}
 
-	users := r.Group("/users")
+	// Чтение площадки в любом статусе для других сервисов (reservation-service). Gateway этот путь не проксирует
+	internal := r.Group("/internal")
 	{
-		users.GET("/:id/venues", h.GetByOwnerID)
+		internal.GET("/venues/:id", h.GetInternal)
 	}
 }
 
@@ -75,29 +208,73 @@ func (h *VenueHandler) GetList(c *gin.Context) {
 	}
 
 	filter := services.VenueFilter{
-		District:  query.District,
+		Districts: splitValues(query.District),
 		HourPrice: query.HourPrice,
 		IsActive:  query.IsActive,
 		OwnerID:   query.OwnerID,
 		Page:      query.Page,
 		Limit:     query.Limit,
+		Cursor:    query.Cursor,
+
+		MinRating: query.MinRating,
+		Sort:      query.Sort,
+	}
+
+	var err error
+	if filter.MinHourPrice, filter.MaxHourPrice, err = query.priceRange(); err != nil {
+		h.logger.Error("Некорректный диапазон цены", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
 	}
 
 	// Валидация VenueType
-	if query.VenueType != "" {
-		venueType := models.VenueType(query.VenueType)
-		if !venueType.IsValid() {
-			h.logger.Error("Неверный тип площадки", "venue_type", query.VenueType)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": fmt.Sprintf("неверный тип площадки: %s", query.VenueType),
-			})
-			return
+	if filter.VenueTypes, err = query.venueTypes(); err != nil {
+		h.logger.Error("Неверный тип площадки", "venue_type", query.VenueType)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	geo, bbox, err := query.geoFilter()
+	if err != nil {
+		h.logger.Error("Некорректные параметры гео-поиска", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	filter.Geo, filter.BBox = geo, bbox
+
+	filter.Amenities, err = query.amenityCodes()
+	if err != nil {
+		h.logger.Error("Некорректный фильтр удобств", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	filter.AmenitiesMatch = query.AmenitiesMatch
+
+	// По умолчанию новые сначала, при поиске вокруг точки - ближайшие.
+	// Сортировка задается явно, так как курсор привязан к ней
+	if filter.Sort == "" {
+		filter.Sort = "newest"
+		if filter.Geo != nil {
+			filter.Sort = "distance"
 		}
-		filter.VenueType = venueType
 	}
 
-	venues, err := h.service.GetList(filter)
+	list, err := h.service.GetList(filter)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "некорректный cursor или он выдан для другой сортировки",
+			})
+			return
+		}
 		h.logger.Error("Ошибка получения списка площадок", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -106,17 +283,38 @@ func (h *VenueHandler) GetList(c *gin.Context) {
 	}
 
 	// Конвертируем модели в DTO
-	dtoList := ToVenueDTOList(venues)
-	c.JSON(http.StatusOK, dtoList)
+	resp := VenueListDTO{
+		Venues:     ToVenueDTOList(list.Venues, h.mediaURL),
+		Total:      list.Total,
+		Limit:      query.Limit,
+		NextCursor: list.NextCursor,
+	}
+	h.markFavorites(c, resp.Venues)
+	if query.Cursor == "" {
+		resp.Page = query.Page
+	}
+
+	// Старые клиенты получают массив, как раньше: total и next_cursor для них в заголовках
+	c.Header("X-Total-Count", strconv.FormatInt(resp.Total, 10))
+	if resp.NextCursor != "" {
+		c.Header("X-Next-Cursor", resp.NextCursor)
+	}
+	if !query.Envelope {
+		c.JSON(http.StatusOK, resp.Venues)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
+// GetByID возвращает площадку. Неопубликованную видят только владелец и администратор
 func (h *VenueHandler) GetByID(c *gin.Context) {
 	id, err := h.parseID(c)
 	if err != nil {
 		return
 	}
 
-	venue, err := h.service.GetByID(id)
+	claims, _ := middleware.ClaimsFromContext(c)
+	venue, err := h.service.GetVisible(id, claims)
 	if err != nil {
 		if err == services.ErrVenueNotFound {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -133,8 +331,40 @@ func (h *VenueHandler) GetByID(c *gin.Context) {
 
 	h.logger.Info("Площадка успешно получена", "id", id)
 	// Конвертируем модель в DTO
-	venueDTO := ToVenueDTO(venue)
-	c.JSON(http.StatusOK, venueDTO)
+	venueDTO := ToVenueDTO(venue, h.mediaURL)
+	dtos := []VenueDTO{venueDTO}
+	if h.markFavorites(c, dtos) {
+		// is_favorite зависит от пользователя, 304 по версии площадки ему не отдаём
+		c.Header("ETag", venueETag(venue.Version))
+		c.JSON(http.StatusOK, dtos[0])
+		return
+	}
+	writeVersioned(c, venue.Version, venueDTO)
+}
+
+// GetInternal возвращает площадку независимо от статуса модерации: reservation-service
+// сам проверяет статус при бронировании и должен видеть площадки уже созданных броней
+func (h *VenueHandler) GetInternal(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	venue, err := h.service.GetByID(id)
+	if err != nil {
+		if err == services.ErrVenueNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Ошибка получения площадки по ID", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, ToVenueDTO(venue, h.mediaURL))
 }
 
 func (h *VenueHandler) Create(c *gin.Context) {
@@ -167,17 +397,15 @@ func (h *VenueHandler) Create(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Create(venue); err != nil {
-		h.logger.Error("Ошибка создания площадки", "venue_type", venue.VenueType, "owner_id", venue.OwnerID, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Create(claims, venue); err != nil {
+		h.writeError(c, err, "Ошибка создания площадки", "venue_type", venue.VenueType, "owner_id", venue.OwnerID)
 		return
 	}
 
 	h.logger.Info("Площадка успешно создана", "id", venue.ID, "venue_type", venue.VenueType, "owner_id", venue.OwnerID)
 	// Конвертируем модель обратно в DTO для ответа
-	venueDTO := ToVenueDTO(venue)
+	venueDTO := ToVenueDTO(venue, h.mediaURL)
 	c.JSON(http.StatusCreated, venueDTO)
 }
 
@@ -186,6 +414,10 @@ func (h *VenueHandler) Update(c *gin.Context) {
 	if err != nil {
 		return
 	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return
+	}
 
 	// PUT-семантика: требуем все обязательные поля для полного обновления записи
 	var dto VenueDTO
@@ -217,15 +449,6 @@ func (h *VenueHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Проверка OwnerID (не может быть 0)
-	if dto.OwnerID == 0 {
-		h.logger.Error("Отсутствует обязательное поле owner_id")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "PUT требует все поля: owner_id обязателен и не может быть 0",
-		})
-		return
-	}
-
 	// Проверка HourPrice (может быть 0, но не отрицательным)
 	if dto.HourPrice < 0 {
 		h.logger.Error("Некорректное значение hour_price", "hour_price", dto.HourPrice)
@@ -254,21 +477,111 @@ func (h *VenueHandler) Update(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Update(id, venue); err != nil {
-		if err == services.ErrVenueNotFound {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
-			return
-		}
-		h.logger.Error("Ошибка обновления площадки", "id", id, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Update(id, claims, venue, version); err != nil {
+		h.writeError(c, err, "Ошибка обновления площадки", "id", id)
 		return
 	}
 
 	h.logger.Info("Площадка успешно обновлена", "id", id)
+	h.writeUpdatedVenue(c, id)
+}
+
+// Patch - частичное обновление площадки в формате JSON Merge Patch (RFC 7396).
+// Изменения накладываются на текущую карточку, результат проверяется по правилам PUT
+func (h *VenueHandler) Patch(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return
+	}
+
+	var patch map[string]any
+	if err := c.ShouldBindJSON(&patch); err != nil || patch == nil {
+		h.logger.Error("Ошибка парсинга JSON Merge Patch", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "тело PATCH должно быть JSON-объектом",
+		})
+		return
+	}
+	// Удобства хранятся отдельно от карточки, молча игнорировать их в PATCH нельзя
+	if _, ok := patch["amenities"]; ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "amenities меняются через PUT /venues/:id/amenities",
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	err = h.service.Patch(id, claims, func(current *models.Venue) (*models.Venue, error) {
+		return h.applyVenuePatch(current, patch)
+	}, version)
+	if err != nil {
+		h.writeError(c, err, "Ошибка частичного обновления площадки", "id", id)
+		return
+	}
+
+	h.logger.Info("Площадка успешно обновлена через PATCH", "id", id)
+	h.writeUpdatedVenue(c, id)
+}
+
+// applyVenuePatch накладывает merge patch на карточку площадки и проверяет результат как тело PUT
+func (h *VenueHandler) applyVenuePatch(current *models.Venue, patch map[string]any) (*models.Venue, error) {
+	base := ToVenueDTO(current, h.mediaURL)
+	// День с одним окном описывается start_time/end_time, с несколькими - intervals,
+	// чтобы патч одного из представлений не перекрывался другим
+	for _, day := range base.Weekdays.days() {
+		if len(day.Intervals) > 1 {
+			day.StartTime, day.EndTime = nil, nil
+		} else {
+			day.Intervals = nil
+		}
+	}
+
+	raw, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if raw, err = json.Marshal(mergePatch(doc, patch)); err != nil {
+		return nil, err
+	}
+
+	var dto VenueDTO
+	if err := json.Unmarshal(raw, &dto); err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidVenue, err)
+	}
+	// Выключенный день не хранит время работы
+	for _, day := range dto.Weekdays.days() {
+		if !day.Enabled {
+			day.StartTime, day.EndTime, day.Intervals = nil, nil, nil
+		}
+	}
+	if err := binding.Validator.ValidateStruct(&dto); err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidVenue, err)
+	}
+	if !dto.VenueType.IsValid() {
+		return nil, fmt.Errorf("%w: неверный тип площадки: %s", services.ErrInvalidVenue, dto.VenueType)
+	}
+	if dto.HourPrice < 0 {
+		return nil, fmt.Errorf("%w: hour_price не может быть отрицательным", services.ErrInvalidVenue)
+	}
+
+	venue, err := FromVenueDTO(&dto)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidVenue, err)
+	}
+	return venue, nil
+}
+
+// writeUpdatedVenue отдаёт площадку после изменения вместе с новым ETag
+func (h *VenueHandler) writeUpdatedVenue(c *gin.Context, id uint) {
 	// Получаем обновленную площадку для ответа
 	updatedVenue, err := h.service.GetByID(id)
 	if err != nil {
@@ -283,7 +596,8 @@ func (h *VenueHandler) Update(c *gin.Context) {
 		c.Status(http.StatusNoContent)
 		return
 	}
-	venueDTO := ToVenueDTO(updatedVenue)
+	venueDTO := ToVenueDTO(updatedVenue, h.mediaURL)
+	c.Header("ETag", venueETag(updatedVenue.Version))
 	c.JSON(http.StatusOK, venueDTO)
 }
 
@@ -293,17 +607,9 @@ func (h *VenueHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Delete(id); err != nil {
-		if err == services.ErrVenueNotFound {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
-			return
-		}
-		h.logger.Error("Ошибка деактивации площадки", "id", id, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Delete(id, claims); err != nil {
+		h.writeError(c, err, "Ошибка деактивации площадки", "id", id)
 		return
 	}
 
@@ -336,7 +642,7 @@ func (h *VenueHandler) GetSchedule(c *gin.Context) {
 
 	// Конвертируем модель в ScheduleDTO
 	scheduleDTO := ToScheduleDTO(venue)
-	c.JSON(http.StatusOK, scheduleDTO)
+	writeVersioned(c, venue.Version, scheduleDTO)
 }
 
 func (h *VenueHandler) UpdateSchedule(c *gin.Context) {
@@ -344,6 +650,10 @@ func (h *VenueHandler) UpdateSchedule(c *gin.Context) {
 	if err != nil {
 		return
 	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return
+	}
 
 	var dto ScheduleUpdateDTO
 	if err := c.ShouldBindJSON(&dto); err != nil {
@@ -364,17 +674,9 @@ func (h *VenueHandler) UpdateSchedule(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.UpdateSchedule(id, *weekdays); err != nil {
-		if err == services.ErrVenueNotFound {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
-			return
-		}
-		h.logger.Error("Ошибка обновления расписания", "id", id, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.UpdateSchedule(id, claims, *weekdays, version); err != nil {
+		h.writeError(c, err, "Ошибка обновления расписания", "id", id)
 		return
 	}
 
@@ -393,18 +695,159 @@ func (h *VenueHandler) UpdateSchedule(c *gin.Context) {
 		return
 	}
 	scheduleDTO := ToScheduleDTO(updatedVenue)
+	c.Header("ETag", venueETag(updatedVenue.Version))
 	c.JSON(http.StatusOK, scheduleDTO)
 }
 
-func (h *VenueHandler) GetVenueTypes(c *gin.Context) {
-	types := []gin.H{
-		{"value": string(models.VenueFootball), "label": "Футбол"},
-		{"value": string(models.VenueBasketball), "label": "Баскетбол"},
-		{"value": string(models.VenueTennis), "label": "Теннис"},
-		{"value": string(models.VenueGym), "label": "Тренажерный зал"},
-		{"value": string(models.VenueSwimming), "label": "Плавание"},
+func (h *VenueHandler) GetBookingRules(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	venue, err := h.service.GetByID(id)
+	if err != nil {
+		if err == services.ErrVenueNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Ошибка получения правил бронирования", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToBookingRulesDTO(venue.BookingRules))
+}
+
+func (h *VenueHandler) UpdateBookingRules(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	var dto BookingRulesDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	rules, err := FromBookingRulesDTO(&dto)
+	if err != nil {
+		h.logger.Error("Некорректные правила бронирования", "id", id, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.UpdateBookingRules(id, claims, rules); err != nil {
+		h.writeError(c, err, "Ошибка обновления правил бронирования", "id", id)
+		return
+	}
+
+	h.logger.Info("Правила бронирования успешно обновлены", "id", id)
+	c.JSON(http.StatusOK, ToBookingRulesDTO(rules))
+}
+
+// GetHistoryQuery - параметры истории цены и расписания
+type GetHistoryQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (h *VenueHandler) GetHistory(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	var query GetHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Error("Ошибка парсинга query параметров", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	revisions, total, err := h.service.GetHistory(id, claims, query.Page, query.Limit)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения истории площадки", "id", id)
+		return
+	}
+
+	result := VenueRevisionListDTO{
+		Revisions: make([]VenueRevisionDTO, 0, len(revisions)),
+		Total:     total,
+		Page:      query.Page,
+		Limit:     query.Limit,
+	}
+	for i := range revisions {
+		result.Revisions = append(result.Revisions, ToVenueRevisionDTO(&revisions[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *VenueHandler) GetRevision(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "неверный номер версии",
+		})
+		return
+	}
+
+	result, err := h.service.GetRevision(id, revision)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения версии площадки", "id", id, "revision", revision)
+		return
 	}
-	c.JSON(http.StatusOK, types)
+	c.JSON(http.StatusOK, ToVenueRevisionDTO(result))
+}
+
+func (h *VenueHandler) UpdateAmenities(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		return
+	}
+
+	var dto AmenitiesDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	codes, err := h.service.UpdateAmenities(id, claims, dto.Amenities)
+	if err != nil {
+		h.writeError(c, err, "Ошибка обновления удобств площадки", "id", id)
+		return
+	}
+
+	h.logger.Info("Удобства площадки успешно обновлены", "id", id)
+	c.JSON(http.StatusOK, AmenitiesDTO{Amenities: codes})
 }
 
 func (h *VenueHandler) GetByOwnerID(c *gin.Context) {
@@ -413,7 +856,8 @@ func (h *VenueHandler) GetByOwnerID(c *gin.Context) {
 		return
 	}
 
-	venues, err := h.service.GetByOwnerID(ownerID)
+	claims, _ := middleware.ClaimsFromContext(c)
+	venues, err := h.service.GetByOwnerID(ownerID, claims)
 	if err != nil {
 		h.logger.Error("Ошибка получения площадок владельца", "owner_id", ownerID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -423,10 +867,65 @@ func (h *VenueHandler) GetByOwnerID(c *gin.Context) {
 	}
 
 	// Конвертируем модели в DTO
-	dtoList := ToVenueDTOList(venues)
+	dtoList := ToVenueDTOList(venues, h.mediaURL)
+	h.markFavorites(c, dtoList)
 	c.JSON(http.StatusOK, dtoList)
 }
 
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *VenueHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrVenueNotFound), errors.Is(err, services.ErrRevisionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrVersionMismatch):
+		c.JSON(http.StatusPreconditionFailed, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrRevisionConflict), errors.Is(err, services.ErrVenueConflict):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidAmenities), errors.Is(err, services.ErrInvalidVenueType), errors.Is(err, services.ErrInvalidVenue):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
+
+// markFavorites заполняет is_favorite, если запрос от авторизованного пользователя.
+// Ошибка избранного не ломает выдачу: флаг просто не отдаётся
+func (h *VenueHandler) markFavorites(c *gin.Context, venues []VenueDTO) bool {
+	claims, ok := middleware.ClaimsFromContext(c)
+	if !ok || claims == nil {
+		return false
+	}
+	ids := make([]uint, len(venues))
+	for i := range venues {
+		ids[i] = venues[i].ID
+	}
+	favorites, err := h.favorites.FavoriteIDs(claims, ids)
+	if err != nil {
+		h.logger.Error("Ошибка получения избранного", "user_id", claims.UserID, "error", err)
+		return false
+	}
+	for i := range venues {
+		isFavorite := favorites[venues[i].ID]
+		venues[i].IsFavorite = &isFavorite
+	}
+	return true
+}
+
 // parseID вспомогательная функция для парсинга ID из параметра
 func (h *VenueHandler) parseID(c *gin.Context) (uint, error) {
 	idStr := c.Param("id")
@@ -0,0 +1,443 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"venue-service/internal/models"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testJWTSecret = "test-secret"
+
+// fakeVenueService запоминает claims, с которыми вызваны изменяющие методы
+type fakeVenueService struct {
+	services.VenueService
+	claims  *models.Claims
+	err     error
+	venue   *models.Venue // Площадка для чтения и PATCH
+	patched *models.Venue // Результат применения PATCH
+	hidden  bool          // GetVisible не показывает площадку (не опубликована, чужая)
+}
+
+func (s *fakeVenueService) Create(claims *models.Claims, venue *models.Venue) error {
+	s.claims = claims
+	if s.err != nil {
+		return s.err
+	}
+	venue.OwnerID = claims.UserID
+	return nil
+}
+
+func (s *fakeVenueService) Delete(id uint, claims *models.Claims) error {
+	s.claims = claims
+	return s.err
+}
+
+func (s *fakeVenueService) GetByID(id uint) (*models.Venue, error) {
+	if s.venue == nil {
+		return nil, services.ErrVenueNotFound
+	}
+	return s.venue, nil
+}
+
+func (s *fakeVenueService) GetVisible(id uint, claims *models.Claims) (*models.Venue, error) {
+	s.claims = claims
+	if s.hidden {
+		return nil, services.ErrVenueNotFound
+	}
+	return s.GetByID(id)
+}
+
+func (s *fakeVenueService) GetList(filter services.VenueFilter) (*services.VenueList, error) {
+	if s.venue == nil {
+		return &services.VenueList{}, nil
+	}
+	return &services.VenueList{Venues: []models.Venue{*s.venue}, Total: 5, NextCursor: "next"}, nil
+}
+
+func (s *fakeVenueService) Patch(id uint, claims *models.Claims, patch services.VenuePatch, version int) error {
+	s.claims = claims
+	if s.err != nil {
+		return s.err
+	}
+	current := *s.venue
+	venue, err := patch(&current)
+	if err != nil {
+		return err
+	}
+	s.patched = venue
+	return nil
+}
+
+// fakeFavoriteService - избранное пользователя с заданным ID
+type fakeFavoriteService struct {
+	services.FavoriteService
+	userID    uint
+	favorites map[uint]bool
+}
+
+func (s *fakeFavoriteService) FavoriteIDs(claims *models.Claims, venueIDs []uint) (map[uint]bool, error) {
+	if claims == nil || claims.UserID != s.userID {
+		return map[uint]bool{}, nil
+	}
+	return s.favorites, nil
+}
+
+func newTestRouter(service services.VenueService) *gin.Engine {
+	return newTestRouterWithFavorites(service, &fakeFavoriteService{})
+}
+
+func newTestRouterWithFavorites(service services.VenueService, favorites services.FavoriteService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	NewVenueHandler(service, favorites, logger, testJWTSecret, func(key string) string { return key }).RegisterRoutes(r)
+	return r
+}
+
+func testToken(t *testing.T, userID uint, role models.Role) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &models.Claims{UserID: userID, Role: role}).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Bearer " + token
+}
+
+const testVenueBody = `{
+	"venue_type": "football",
+	"hour_price": 3000,
+	"district": "Центральный",
+	"weekdays": {
+		"monday": {"enabled": true, "start_time": "09:00", "end_time": "21:00"},
+		"tuesday": {"enabled": false},
+		"wednesday": {"enabled": false},
+		"thursday": {"enabled": false},
+		"friday": {"enabled": false},
+		"saturday": {"enabled": false},
+		"sunday": {"enabled": false}
+	}
+}`
+
+func TestVenueMutationsRequireToken(t *testing.T) {
+	requests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/venues"},
+		{http.MethodPut, "/venues/1"},
+		{http.MethodDelete, "/venues/1"},
+		{http.MethodPut, "/venues/1/schedule"},
+		{http.MethodPut, "/venues/1/booking-rules"},
+		{http.MethodPut, "/venues/1/amenities"},
+	}
+
+	router := newTestRouter(&fakeVenueService{})
+	for _, req := range requests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(req.method, req.path, bytes.NewBufferString(testVenueBody)))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s %s без токена: статус %d, ожидался 401", req.method, req.path, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/venues", bytes.NewBufferString(testVenueBody))
+	req.Header.Set("Authorization", "Bearer invalid")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("POST /venues с неверным токеном: статус %d, ожидался 401", w.Code)
+	}
+}
+
+func TestVenueCreatePassesTokenClaims(t *testing.T) {
+	roles := []struct {
+		name       string
+		role       models.Role
+		serviceErr error
+		wantStatus int
+	}{
+		{"владелец", models.RoleOwner, nil, http.StatusCreated},
+		{"администратор", models.RoleAdmin, nil, http.StatusCreated},
+		{"клиент", models.RoleClient, services.ErrForbidden, http.StatusForbidden},
+	}
+
+	for _, tt := range roles {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &fakeVenueService{err: tt.serviceErr}
+			router := newTestRouter(service)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/venues", bytes.NewBufferString(testVenueBody))
+			req.Header.Set("Authorization", testToken(t, 7, tt.role))
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("статус %d, ожидался %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if service.claims == nil || service.claims.UserID != 7 || service.claims.Role != tt.role {
+				t.Fatalf("в сервис переданы claims %+v", service.claims)
+			}
+		})
+	}
+}
+
+func TestVenueDeleteForbidden(t *testing.T) {
+	router := newTestRouter(&fakeVenueService{err: services.ErrForbidden})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/venues/1", nil)
+	req.Header.Set("Authorization", testToken(t, 8, models.RoleOwner))
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("статус %d, ожидался 403", w.Code)
+	}
+}
+
+func patchTestVenue() *models.Venue {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 1, 21, 0, 0, 0, time.UTC)
+	day := models.DaySchedule{Enabled: true, StartTime: &start, EndTime: &end}
+	venue := &models.Venue{
+		VenueType: models.VenueFootball,
+		OwnerID:   7,
+		IsActive:  true,
+		HourPrice: 3000,
+		District:  "Центральный",
+		Capacity:  1,
+		Weekdays: models.Weekdays{
+			Monday: day, Tuesday: day, Wednesday: day, Thursday: day,
+			Friday: day, Saturday: day, Sunday: day,
+		},
+		BookingRules: models.DefaultBookingRules(),
+	}
+	venue.ID = 1
+	venue.Version = 3
+	return venue
+}
+
+func TestVenueGetETag(t *testing.T) {
+	router := newTestRouter(&fakeVenueService{venue: patchTestVenue()})
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{"без условия", "", http.StatusOK},
+		{"актуальная версия", `"3"`, http.StatusNotModified},
+		{"слабый ETag", `W/"3"`, http.StatusNotModified},
+		{"устаревшая версия", `"2"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/venues/1", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("статус %d, ожидался %d", w.Code, tt.wantStatus)
+			}
+			if etag := w.Header().Get("ETag"); etag != `"3"` {
+				t.Fatalf("ETag = %s", etag)
+			}
+		})
+	}
+}
+
+func TestVenueGetIsFavorite(t *testing.T) {
+	favorites := &fakeFavoriteService{userID: 9, favorites: map[uint]bool{1: true}}
+	router := newTestRouterWithFavorites(&fakeVenueService{venue: patchTestVenue()}, favorites)
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+		want       *bool
+	}{
+		{"без токена", "", http.StatusNotModified, nil},
+		{"в избранном", testToken(t, 9, models.RoleClient), http.StatusOK, ptr(true)},
+		{"не в избранном", testToken(t, 10, models.RoleClient), http.StatusOK, ptr(false)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/venues/1", nil)
+			// Актуальная версия у клиента не даёт 304 авторизованному: is_favorite зависит от него
+			req.Header.Set("If-None-Match", `"3"`)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("статус %d, ожидался %d", w.Code, tt.wantStatus)
+			}
+			if tt.want == nil {
+				return
+			}
+			var body VenueDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.IsFavorite == nil || *body.IsFavorite != *tt.want {
+				t.Fatalf("is_favorite = %v, ожидалось %v", body.IsFavorite, *tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestVenuePatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		ifMatch    string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "изменение адреса", body: `{"address": "ул. Ленина, 1"}`, wantStatus: http.StatusOK},
+		{name: "удаление координат", body: `{"latitude": null, "longitude": null}`, ifMatch: `"3"`, wantStatus: http.StatusOK},
+		{name: "отрицательная цена", body: `{"hour_price": -1}`, wantStatus: http.StatusBadRequest},
+		{name: "удаление обязательного поля", body: `{"district": null}`, wantStatus: http.StatusBadRequest},
+		{name: "не объект", body: `[]`, wantStatus: http.StatusBadRequest},
+		{name: "удобства", body: `{"amenities": ["lighting"]}`, wantStatus: http.StatusBadRequest},
+		{name: "слабый If-Match", body: `{}`, ifMatch: `W/"3"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "несколько If-Match", body: `{}`, ifMatch: `"2", "3"`, wantStatus: http.StatusBadRequest},
+		{name: "устаревшая версия", body: `{}`, ifMatch: `"2"`, serviceErr: services.ErrVersionMismatch, wantStatus: http.StatusPreconditionFailed},
+		{name: "параллельное изменение", body: `{}`, serviceErr: services.ErrVenueConflict, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &fakeVenueService{venue: patchTestVenue(), err: tt.serviceErr}
+			router := newTestRouter(service)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, "/venues/1", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			req.Header.Set("Authorization", testToken(t, 7, models.RoleOwner))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("статус %d, ожидался %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if w.Header().Get("ETag") != `"3"` {
+				t.Fatalf("ETag = %s", w.Header().Get("ETag"))
+			}
+			patched := service.patched
+			if patched.District != "Центральный" || patched.VenueType != models.VenueFootball {
+				t.Fatalf("поля вне патча изменились: %+v", patched)
+			}
+		})
+	}
+}
+
+func TestVenuePatchSchedule(t *testing.T) {
+	service := &fakeVenueService{venue: patchTestVenue()}
+	router := newTestRouter(service)
+
+	w := httptest.NewRecorder()
+	body := `{"hour_price": 4500, "weekdays": {"monday": {"start_time": "10:00"}, "sunday": {"enabled": false}}}`
+	req := httptest.NewRequest(http.MethodPatch, "/venues/1", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", testToken(t, 7, models.RoleOwner))
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("статус %d: %s", w.Code, w.Body.String())
+	}
+
+	patched := service.patched
+	monday := patched.Weekdays.Monday
+	if patched.HourPrice != 4500 || monday.StartTime.Format(models.TimeLayout) != "10:00" || monday.EndTime.Format(models.TimeLayout) != "21:00" {
+		t.Fatalf("hour_price = %d, понедельник %+v", patched.HourPrice, monday)
+	}
+	if patched.Weekdays.Sunday.Enabled || patched.Weekdays.Sunday.StartTime != nil {
+		t.Fatalf("воскресенье должно быть выключено: %+v", patched.Weekdays.Sunday)
+	}
+	if !patched.Weekdays.Tuesday.Enabled {
+		t.Fatalf("вторник не должен измениться")
+	}
+}
+
+func TestVenueListFormats(t *testing.T) {
+	router := newTestRouter(&fakeVenueService{venue: patchTestVenue()})
+
+	t.Run("массив по умолчанию", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/venues?limit=1", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("статус %d", w.Code)
+		}
+		var body []VenueDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("ожидался массив: %v", err)
+		}
+		if len(body) != 1 || body[0].ID != 1 {
+			t.Fatalf("unexpected body: %+v", body)
+		}
+		if w.Header().Get("X-Total-Count") != "5" || w.Header().Get("X-Next-Cursor") != "next" {
+			t.Fatalf("заголовки: total=%q cursor=%q", w.Header().Get("X-Total-Count"), w.Header().Get("X-Next-Cursor"))
+		}
+	})
+
+	t.Run("объект по envelope=true", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/venues?limit=1&envelope=true", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("статус %d", w.Code)
+		}
+		var body VenueListDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body.Venues) != 1 || body.Total != 5 || body.Page != 1 || body.Limit != 1 || body.NextCursor != "next" {
+			t.Fatalf("unexpected body: %+v", body)
+		}
+	})
+}
+
+func TestVenueGetHiddenVenue(t *testing.T) {
+	service := &fakeVenueService{venue: patchTestVenue(), hidden: true}
+	router := newTestRouter(service)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/venues/1", nil)
+	req.Header.Set("Authorization", testToken(t, 8, models.RoleOwner))
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("статус %d, ожидался 404", w.Code)
+	}
+	if service.claims == nil || service.claims.UserID != 8 {
+		t.Fatalf("claims не переданы в сервис: %+v", service.claims)
+	}
+
+	// Другие сервисы читают площадку в любом статусе
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/internal/venues/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("внутренний запрос: статус %d, ожидался 200", w.Code)
+	}
+}
@@ -0,0 +1,154 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"venue-service/internal/middleware"
+	"venue-service/internal/models"
+	"venue-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VenueTypesQuery - параметры списка типов площадок
+type VenueTypesQuery struct {
+	Lang            string `form:"lang"`             // Язык label, по умолчанию ru
+	IncludeInactive bool   `form:"include_inactive"` // Отключённые типы, только для администратора
+}
+
+type VenueTypeHandler struct {
+	service   services.VenueTypeService
+	logger    *slog.Logger
+	jwtSecret string
+}
+
+func NewVenueTypeHandler(service services.VenueTypeService, logger *slog.Logger, jwtSecret string) *VenueTypeHandler {
+	return &VenueTypeHandler{
+		service:   service,
+		logger:    logger.With("layer", "transport"),
+		jwtSecret: jwtSecret,
+	}
+}
+
+func (h *VenueTypeHandler) RegisterRoutes(r *gin.Engine) {
+	venueTypes := r.Group("/venue-types")
+	{
+		venueTypes.GET("", middleware.OptionalAuthMiddleware(h.jwtSecret), h.GetList)
+		venueTypes.POST("", middleware.AuthMiddleware(h.jwtSecret), h.Create)
+		venueTypes.PUT("/:code", middleware.AuthMiddleware(h.jwtSecret), h.Update)
+		venueTypes.DELETE("/:code", middleware.AuthMiddleware(h.jwtSecret), h.Delete)
+	}
+}
+
+func (h *VenueTypeHandler) GetList(c *gin.Context) {
+	var query VenueTypesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	types, err := h.service.GetList(claims, query.IncludeInactive)
+	if err != nil {
+		h.writeError(c, err, "Ошибка получения типов площадок")
+		return
+	}
+
+	result := make([]VenueTypeDTO, 0, len(types))
+	for i := range types {
+		result = append(result, ToVenueTypeDTO(&types[i], query.Lang))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *VenueTypeHandler) Create(c *gin.Context) {
+	var dto VenueTypeInputDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	venueType := FromVenueTypeInputDTO(&dto)
+	if err := h.service.Create(claims, venueType); err != nil {
+		h.writeError(c, err, "Ошибка создания типа площадки", "code", dto.Code)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToVenueTypeDTO(venueType, c.Query("lang")))
+}
+
+func (h *VenueTypeHandler) Update(c *gin.Context) {
+	code := models.VenueType(c.Param("code"))
+
+	var dto VenueTypeInputDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.Error("Ошибка парсинга JSON", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if dto.Code != "" && models.VenueType(dto.Code) != code {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "код типа площадки изменить нельзя",
+		})
+		return
+	}
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	venueType := FromVenueTypeInputDTO(&dto)
+	if err := h.service.Update(claims, code, venueType); err != nil {
+		h.writeError(c, err, "Ошибка обновления типа площадки", "code", code)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToVenueTypeDTO(venueType, c.Query("lang")))
+}
+
+func (h *VenueTypeHandler) Delete(c *gin.Context) {
+	code := models.VenueType(c.Param("code"))
+
+	claims, _ := middleware.ClaimsFromContext(c)
+	if err := h.service.Delete(claims, code); err != nil {
+		h.writeError(c, err, "Ошибка удаления типа площадки", "code", code)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Тип площадки удалён",
+	})
+}
+
+// writeError преобразует ошибку сервиса в HTTP-ответ
+func (h *VenueTypeHandler) writeError(c *gin.Context, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, services.ErrVenueTypeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidVenueType):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrVenueTypeExists), errors.Is(err, services.ErrVenueTypeInUse):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		h.logger.Error(msg, append(args, "error", err)...)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}